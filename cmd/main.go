@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"hello-gin/config"
 	_ "hello-gin/docs"
 	"hello-gin/internal/controllers"
 	"hello-gin/internal/repository"
 	"hello-gin/internal/routes"
+	"hello-gin/internal/scheduler"
 	"hello-gin/internal/services"
 	"os"
 	"time"
@@ -31,18 +33,77 @@ import (
 // @host      localhost:8080
 // @BasePath  /api
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the access token from /auth/login.
+
 func main() {
 	// Kết nối DB
 	config.ConnectDB()
 
 	// Khởi tạo repositories
 	eventRepo := repository.NewEventRepository(config.DB)
+	userRepo := repository.NewUserRepository(config.DB)
+	excuseRepo := repository.NewExcuseRepository(config.DB)
+	registrationRepo := repository.NewRegistrationRepository(config.DB)
+	idempotencyRepo := repository.NewIdempotencyRepository(config.DB)
+	certificateRepo := repository.NewCertificateRepository(config.DB)
+	roomRepo := repository.NewRoomRepository(config.DB)
+	studentRepo := repository.NewStudentRepository(config.DB)
+	classRepo := repository.NewClassRepository(config.DB)
+	enrollmentRepo := repository.NewEnrollmentRepository(config.DB)
+	teacherRepo := repository.NewTeacherRepository(config.DB)
+	trashRepo := repository.NewTrashRepository(config.DB)
 
 	// Khởi tạo services
 	eventService := services.NewEventService(eventRepo)
+	authService := services.NewAuthService(userRepo, config.LoadJWTConfig())
+	excuseService := services.NewExcuseService(excuseRepo, enrollmentRepo)
+	registrationService := services.NewRegistrationService(registrationRepo, eventRepo)
+	certificateService := services.NewCertificateService(certificateRepo, eventRepo)
+	roomService := services.NewRoomService(roomRepo)
+	studentService := services.NewStudentService(studentRepo, classRepo, enrollmentRepo)
+	classService := services.NewClassService(classRepo)
+	teacherService := services.NewTeacherService(teacherRepo)
+	trashService := services.NewTrashService(trashRepo)
+	calendarService := services.NewCalendarService(eventRepo, classRepo, teacherRepo)
+	sessionService := services.NewAttendanceSessionService(roomRepo)
+	seriesService := services.NewSessionSeriesService(roomRepo)
+	checkInService := services.NewCheckInService(registrationRepo)
+	rosterService := services.NewRosterService(enrollmentRepo, excuseRepo)
+
+	// Tự động bắt đầu / kết thúc events theo start_date và end_date
+	if config.SchedulerEnabled() {
+		scheduler.NewEventScheduler(eventService, config.SchedulerInterval()).Start(context.Background())
+	}
+
+	// Xoá hẳn các bản ghi nằm trong thùng rác quá TRASH_RETENTION_DAYS ngày
+	if config.SchedulerEnabled() && config.TrashRetention() > 0 {
+		scheduler.NewTrashPurger(trashService, config.TrashRetention(), time.Hour).Start(context.Background())
+	}
+
+	// Xoá các Idempotency-Key đã hết hạn
+	if config.SchedulerEnabled() {
+		scheduler.NewIdempotencyPurger(idempotencyRepo, time.Hour).Start(context.Background())
+	}
 
 	// Khởi tạo controllers với interface
 	eventController := controllers.NewEventController(eventService)
+	authController := controllers.NewAuthController(authService)
+	excuseController := controllers.NewExcuseController(excuseService)
+	registrationController := controllers.NewRegistrationController(registrationService)
+	certificateController := controllers.NewCertificateController(certificateService)
+	roomController := controllers.NewRoomController(roomService)
+	studentController := controllers.NewStudentController(studentService)
+	classController := controllers.NewClassController(classService)
+	teacherController := controllers.NewTeacherController(teacherService)
+	trashController := controllers.NewTrashController(trashService)
+	calendarController := controllers.NewCalendarController(calendarService)
+	sessionController := controllers.NewAttendanceSessionController(sessionService, rosterService)
+	seriesController := controllers.NewSessionSeriesController(seriesService)
+	attendanceController := controllers.NewAttendanceController(checkInService)
+	checkinPageController := controllers.NewCheckinPageController(checkInService)
 
 	// Khởi tạo Gin
 	r := gin.Default()
@@ -61,14 +122,14 @@ func main() {
 			"http://127.0.0.1:8080",
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Requested-With", "Accept", "Accept-Language", "Accept-Encoding"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "Idempotency-Key", "X-Requested-With", "Accept", "Accept-Language", "Accept-Encoding"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "Content-Disposition", "Idempotent-Replayed", "X-Certificate-Count"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
 	// Đăng ký routes
-	routes.RegisterRoutes(r, eventController)
+	routes.RegisterRoutes(r, eventController, authController, excuseController, registrationController, certificateController, roomController, studentController, classController, teacherController, trashController, calendarController, sessionController, seriesController, attendanceController, checkinPageController, authService, idempotencyRepo)
 
 	// Swagger endpoint
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
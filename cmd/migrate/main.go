@@ -3,8 +3,11 @@ package main
 import (
 	"hello-gin/config"
 	"hello-gin/internal/migrations"
+	"hello-gin/internal/repository"
+	"hello-gin/internal/services"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -17,10 +20,13 @@ func main() {
 
 	// Check command line arguments
 	if len(os.Args) < 2 {
-		log.Println("Usage: go run cmd/migrate/main.go [migrate|drop|reset]")
-		log.Println("  migrate - Run migrations")
-		log.Println("  drop    - Drop all tables")
-		log.Println("  reset   - Drop all tables and run migrations")
+		log.Println("Usage: go run cmd/migrate/main.go [migrate|drop|reset|dedupe|create-user]")
+		log.Println("  migrate                                                   - Run migrations")
+		log.Println("  drop                                                      - Drop all tables")
+		log.Println("  reset                                                     - Drop all tables and run migrations")
+		log.Println("  dedupe                                                    - Remove duplicated check-ins and add the unique indexes")
+		log.Println("  create-user <username> <password> [role] [linked_id]      - Create a login account (role: admin|organizer|teacher|kiosk|student)")
+		log.Println("                                                              linked_id is the teacher ID or student ID for teacher/student accounts")
 		os.Exit(1)
 	}
 
@@ -51,9 +57,39 @@ func main() {
 		}
 		log.Println("✅ Database reset completed!")
 
+	case "dedupe":
+		if _, err := migrations.DedupeAttendances(config.DB); err != nil {
+			log.Fatal("Dedupe failed:", err)
+		}
+		log.Println("✅ Dedupe completed!")
+
+	case "create-user":
+		if len(os.Args) < 4 {
+			log.Fatal("Usage: go run cmd/migrate/main.go create-user <username> <password> [role] [linked_id]")
+		}
+		role := "admin"
+		if len(os.Args) > 4 {
+			role = os.Args[4]
+		}
+		var linkedID *uint
+		if len(os.Args) > 5 {
+			id, err := strconv.ParseUint(os.Args[5], 10, 32)
+			if err != nil {
+				log.Fatal("Invalid linked_id:", err)
+			}
+			linkedIDUint := uint(id)
+			linkedID = &linkedIDUint
+		}
+		authService := services.NewAuthService(repository.NewUserRepository(config.DB), config.LoadJWTConfig())
+		user, err := authService.CreateUser(os.Args[2], os.Args[3], role, linkedID)
+		if err != nil {
+			log.Fatal("Create user failed:", err)
+		}
+		log.Printf("✅ User '%s' created with ID %d and role %s!", user.Username, user.ID, user.Role)
+
 	default:
 		log.Printf("Unknown command: %s\n", command)
-		log.Println("Available commands: migrate, drop, reset")
+		log.Println("Available commands: migrate, drop, reset, dedupe, create-user")
 		os.Exit(1)
 	}
 }
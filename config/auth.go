@@ -0,0 +1,61 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// JWTConfig holds the settings used to sign and verify API tokens
+type JWTConfig struct {
+	Secret     []byte
+	Issuer     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// LoadJWTConfig reads the JWT settings from the environment
+func LoadJWTConfig() JWTConfig {
+	secret := os.Getenv("JWT_SECRET") // Bắt buộc phải có từ .env
+	if secret == "" {
+		log.Fatal("❌ JWT_SECRET is required in .env file")
+	}
+
+	return JWTConfig{
+		Secret:     []byte(secret),
+		Issuer:     getEnvWithDefault("JWT_ISSUER", "hello-gin"),
+		AccessTTL:  getDurationWithDefault("JWT_ACCESS_TTL", 15*time.Minute),
+		RefreshTTL: getDurationWithDefault("JWT_REFRESH_TTL", 7*24*time.Hour),
+	}
+}
+
+// Helper function to parse a duration (e.g. "15m", "168h") from the environment
+func getDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("⚠️ Invalid duration for %s (%q), using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// Helper function to parse a duration that may be zero, such as a tolerance
+// that can be turned off; only negative values fall back to the default
+func getNonNegativeDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("⚠️ Invalid duration for %s (%q), using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// CalendarFeedSecret returns the key used to sign calendar feed links.
+// It falls back to JWT_SECRET; changing it revokes every feed link handed out.
+func CalendarFeedSecret() []byte {
+	if secret := os.Getenv("CALENDAR_FEED_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
+
+// CalendarFeedURL returns the public base URL of the .ics feeds served at
+// /api/calendar/:scope/:id.ics
+func CalendarFeedURL() string {
+	return strings.TrimRight(getEnvWithDefault("CALENDAR_FEED_URL", "http://localhost:8080/api/calendar"), "/")
+}
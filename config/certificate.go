@@ -0,0 +1,9 @@
+package config
+
+import "strings"
+
+// CertificateVerifyURL returns the public URL that certificates point to for
+// verification; the certificate's code is appended as the last path segment
+func CertificateVerifyURL() string {
+	return strings.TrimRight(getEnvWithDefault("CERTIFICATE_VERIFY_URL", "http://localhost:8080/api/certificates/verify"), "/")
+}
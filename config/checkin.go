@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// CheckinTokenSecret returns the key used to sign rotating check-in tokens.
+// It falls back to JWT_SECRET so a single secret is enough for development.
+func CheckinTokenSecret() []byte {
+	if secret := os.Getenv("CHECKIN_TOKEN_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
+
+// DefaultCheckinTokenTTL is used for events that do not set their own token lifetime
+func DefaultCheckinTokenTTL() time.Duration {
+	return getDurationWithDefault("CHECKIN_TOKEN_TTL", 30*time.Second)
+}
+
+// DefaultCheckinTokenSkew is used for events that do not set their own clock-skew
+// tolerance; 0 accepts only the current token
+func DefaultCheckinTokenSkew() time.Duration {
+	return getNonNegativeDurationWithDefault("CHECKIN_TOKEN_SKEW", 5*time.Second)
+}
+
+// CheckinFormTTL is how long the check-in page's form stays valid after the QR
+// code was scanned. It is kept close to the QR rotation window so that a form
+// ticket is not much more useful than the QR code itself.
+func CheckinFormTTL() time.Duration {
+	return getDurationWithDefault("CHECKIN_FORM_TTL", time.Minute)
+}
+
+// CheckinURL returns the attendee-facing check-in page that QR codes point to.
+// It defaults to the page served by this server at /checkin/:sessionId.
+func CheckinURL() string {
+	return strings.TrimRight(getEnvWithDefault("CHECKIN_URL", "http://localhost:8080/checkin"), "/")
+}
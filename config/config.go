@@ -65,7 +65,8 @@ func ConnectDB() {
 	)
 
 	log.Printf("🔗 Kết nối database '%s'...", dbname)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError maps unique violations to gorm.ErrDuplicatedKey
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("❌ Kết nối DB thất bại: ", err)
 	}
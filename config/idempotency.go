@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+// IdempotencyTTL is how long the response to a request with an Idempotency-Key is kept for replay
+func IdempotencyTTL() time.Duration {
+	return getDurationWithDefault("IDEMPOTENCY_TTL", 24*time.Hour)
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// SchedulerEnabled reports whether this server instance runs the background
+// scheduler. It is safe to leave enabled on every instance.
+func SchedulerEnabled() bool {
+	return !strings.EqualFold(getEnvWithDefault("SCHEDULER_ENABLED", "true"), "false")
+}
+
+// SchedulerInterval is how often the scheduler looks for events to start or close
+func SchedulerInterval() time.Duration {
+	return getDurationWithDefault("SCHEDULER_INTERVAL", time.Minute)
+}
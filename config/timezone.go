@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+	_ "time/tzdata" // Time zone database for hosts without one
+)
+
+// DefaultTimezone is the IANA time zone recurring sessions are scheduled in
+// unless they name their own
+func DefaultTimezone() string {
+	return getEnvWithDefault("APP_TIMEZONE", "Asia/Ho_Chi_Minh")
+}
+
+// DefaultLocation loads DefaultTimezone, falling back to the server's local time zone
+func DefaultLocation() *time.Location {
+	location, err := time.LoadLocation(DefaultTimezone())
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
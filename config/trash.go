@@ -0,0 +1,16 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// TrashRetention is how long deleted records stay in the trash before the
+// scheduler purges them for good; zero disables the automatic purge
+func TrashRetention() time.Duration {
+	days, err := strconv.Atoi(getEnvWithDefault("TRASH_RETENTION_DAYS", "30"))
+	if err != nil || days < 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
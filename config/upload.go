@@ -0,0 +1,6 @@
+package config
+
+// UploadDir returns the directory where uploaded files such as excuse attachments are stored
+func UploadDir() string {
+	return getEnvWithDefault("UPLOAD_DIR", "uploads")
+}
@@ -26,7 +26,12 @@ const docTemplate = `{
     "paths": {
         "/attendance-sessions": {
             "get": {
-                "description": "Get all attendance sessions with class and teacher information",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all attendance sessions with class and teacher information. Teachers only get their own sessions; kiosks get them without attendances.",
                 "produces": [
                     "application/json"
                 ],
@@ -62,7 +67,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new attendance session in the database",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new attendance session in the database. Sessions that overlap another session of the same teacher, class or room are rejected with 409 and the list of conflicts; set force to create them anyway, in which case the conflicts are returned as a warning.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,6 +92,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.CreateAttendanceSessionRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -98,6 +114,20 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -108,22 +138,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendance-sessions/{id}": {
+        "/attendance-sessions/conflicts": {
             "get": {
-                "description": "Get a specific attendance session with class, teacher, and attendances",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List pairs of sessions that overlap between from and to and share a teacher, class or room. Dates may be RFC3339 timestamps or YYYY-MM-DD days, where to includes the whole day; the range is at most 92 days. Teachers only see conflicts involving their own sessions, listed first as session_id; other_session_id is left out when the other session belongs to another teacher.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "attendance-sessions"
                 ],
-                "summary": "Get attendance session by ID",
+                "summary": "Report overlapping attendance sessions",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Attendance Session ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Start of the range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range",
+                        "name": "to",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -131,7 +173,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AttendanceSession"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SessionConflictPair"
+                            }
                         }
                     },
                     "400": {
@@ -141,13 +186,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -158,24 +196,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendances": {
+        "/attendance-sessions/{id}": {
             "get": {
-                "description": "Get all attendance records with session information",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a specific attendance session with class, teacher, and attendances. Teachers can only get their own sessions; kiosks get the session without attendances.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "attendances"
+                    "attendance-sessions"
+                ],
+                "summary": "Get attendance session by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attendance Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all attendances",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Attendance"
-                            }
+                            "$ref": "#/definitions/models.AttendanceSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -186,35 +256,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new attendance record",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/attendance-sessions/{id}/checkin-token": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get the rotating check-in token that attendees must submit with POST /attendances, and when it expires",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "attendances"
+                    "attendance-sessions"
                 ],
-                "summary": "Create a new attendance",
+                "summary": "Get the current check-in token of a session",
                 "parameters": [
                     {
-                        "description": "Attendance data",
-                        "name": "attendance",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateAttendanceRequest"
-                        }
+                        "type": "integer",
+                        "description": "Attendance Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Attendance"
+                            "$ref": "#/definitions/models.CheckinTokenResponse"
                         }
                     },
                     "400": {
@@ -224,8 +296,15 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -234,30 +313,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendances/{id}": {
-            "get": {
-                "description": "Get a specific attendance record with session information",
+        "/attendance-sessions/{id}/marks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark students of the session's class as present, absent, excused or late in one transaction. Marking a student again updates their existing mark.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "attendances"
+                    "attendance-sessions"
                 ],
-                "summary": "Get attendance by ID",
+                "summary": "Mark attendance for a session's class",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Attendance ID",
+                        "description": "Attendance Session ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Marks",
+                        "name": "marks",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkMarkAttendanceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Attendance"
+                            "$ref": "#/definitions/models.SessionRoster"
                         }
                     },
                     "400": {
@@ -267,41 +363,25 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/classes": {
-            "get": {
-                "description": "Get all classes from the database",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "classes"
-                ],
-                "summary": "Get all classes",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Class"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -312,35 +392,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new class in the database",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/attendance-sessions/{id}/qr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Render the current check-in link as a QR code for the projector. The code rotates with the token, so clients should refresh it before expires_at.",
                 "produces": [
-                    "application/json"
+                    "image/png",
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "classes"
+                    "attendance-sessions"
                 ],
-                "summary": "Create a new class",
+                "summary": "Get the check-in QR code of a session",
                 "parameters": [
                     {
-                        "description": "Class data",
-                        "name": "class",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateClassRequest"
-                        }
+                        "type": "integer",
+                        "description": "Attendance Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image format: png (default) or svg",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image size in pixels (default 512)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Class"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -350,6 +445,20 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -360,20 +469,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/classes/{id}": {
+        "/attendance-sessions/{id}/roster": {
             "get": {
-                "description": "Get a specific class with its students",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every student in the session's class as present, late, absent or excused, plus check-ins that could not be matched to a student",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "classes"
+                    "attendance-sessions"
                 ],
-                "summary": "Get class by ID",
+                "summary": "Get the class roster of a session",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Class ID",
+                        "description": "Attendance Session ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -383,7 +497,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Class"
+                            "$ref": "#/definitions/models.SessionRoster"
                         }
                     },
                     "400": {
@@ -393,6 +507,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -410,29 +531,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/events": {
+        "/attendances": {
             "get": {
-                "description": "Get a list of all events",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get all attendance records with session information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
                 ],
-                "summary": "Get all events",
+                "summary": "Get all attendances",
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attendance"
+                            }
+                        }
                     },
                     "500": {
-                        "description": "error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -441,7 +566,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new event with the provided information",
+                "description": "Self check-in to a session. Requires the rotating check-in token shown as a QR code for the session.\nCheck-ins after the session's late_after time are marked late; check-ins outside opens_at/closes_at are rejected.\nRepeat check-ins with the same email (or phone, when the event dedupes by phone) return the existing record with 200.\nEvents with a form_schema take the custom fields in answers; other events require work_unit and work_unit_address.",
                 "consumes": [
                     "application/json"
                 ],
@@ -449,37 +574,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
                 ],
-                "summary": "Create a new event",
+                "summary": "Create a new attendance",
                 "parameters": [
                     {
-                        "description": "Event data",
-                        "name": "event",
+                        "description": "Attendance data",
+                        "name": "attendance",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateEventRequest"
+                            "$ref": "#/definitions/models.CreateAttendanceRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Already checked in",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attendance"
+                        }
+                    },
                     "201": {
-                        "description": "success",
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Outside the session's check-in window",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -488,9 +645,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/active": {
-            "get": {
-                "description": "Get a list of all active events",
+        "/attendances/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply check-ins recorded by a kiosk, possibly while offline. Each item carries a client-generated UUID and the device's check-in time; uploading an item again reports it as a duplicate. Each item gets its own result: created, duplicate, or rejected with a reason.",
                 "consumes": [
                     "application/json"
                 ],
@@ -498,19 +660,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
+                ],
+                "summary": "Upload kiosk check-ins in a batch",
+                "parameters": [
+                    {
+                        "description": "Check-ins to upload",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchAttendanceRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all active events",
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BatchItemResult"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -519,9 +694,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}": {
-            "get": {
-                "description": "Get a single event by its ID",
+        "/attendances/checkout": {
+            "post": {
+                "description": "Record the attendee's check-out time and attended duration. Requires the session's current check-in token.",
                 "consumes": [
                     "application/json"
                 ],
@@ -529,126 +704,146 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
                 ],
-                "summary": "Get event by ID",
+                "summary": "Check out of a session",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Check-out data",
+                        "name": "checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckoutRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "error",
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing event with the provided information",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/attendances/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get a specific attendance record with session information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
                 ],
-                "summary": "Update an event",
+                "summary": "Get attendance by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Event ID",
+                        "description": "Attendance ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Event data",
-                        "name": "event",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateEventRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "error",
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an event by its ID",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/attendances/{id}/certificate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Render the certificate of attendance of the person behind an attendance as PDF. The certificate covers all their sessions of the event and keeps its verification code when downloaded again.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "events"
+                    "certificates"
                 ],
-                "summary": "Delete an event",
+                "summary": "Download an attendee's certificate",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Event ID",
+                        "description": "Attendance ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -656,19 +851,32 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
+                    "404": {
                         "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "422": {
+                        "description": "Not eligible",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "error",
                         "schema": {
@@ -679,66 +887,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}/active": {
-            "put": {
-                "description": "Set the active status of an event by its ID (1 = active, 0 = inactive)",
-                "consumes": [
-                    "application/json"
+        "/attendances/{id}/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Record the check-out time and attended duration of an attendance on behalf of the attendee",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
                 ],
-                "summary": "Set event active status",
+                "summary": "Check out an attendance",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Event ID",
+                        "description": "Attendance ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Active status (1 = active, 0 = inactive)",
-                        "name": "active",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "active": {
-                                    "type": "integer"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "error",
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -747,14 +956,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}/attendances": {
-            "get": {
-                "responses": {}
-            }
-        },
-        "/events/{id}/sessions": {
-            "get": {
-                "description": "Get a single event by its ID including all attendance sessions",
+        "/attendances/{id}/student": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Manually resolve a check-in that could not be matched to a student",
                 "consumes": [
                     "application/json"
                 ],
@@ -762,42 +971,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "attendances"
                 ],
-                "summary": "Get event with sessions",
+                "summary": "Link a check-in to a student",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Event ID",
+                        "description": "Attendance ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Student to link",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResolveAttendanceStudentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Attendance"
                         }
                     },
                     "400": {
-                        "description": "error",
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "error",
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -806,9 +1037,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Check if the application and database are running",
+        "/auth/login": {
+            "post": {
+                "description": "Exchange a username and password for an access token and a refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -816,19 +1047,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -837,44 +1093,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/sessions/{sessionId}/attendances": {
+        "/auth/me": {
             "get": {
-                "description": "Get all attendance records for a specific session",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the account the access token was issued to",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "attendances"
+                    "auth"
                 ],
-                "summary": "Get attendances by session ID",
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a valid refresh token for a new access token and refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Session ID",
-                        "name": "sessionId",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "success",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Attendance"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -883,27 +1182,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/students": {
+        "/calendar/{scope}/{file}": {
             "get": {
-                "description": "Get a list of all students",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the sessions of an event, class or teacher as an iCalendar (.ics) feed for calendar apps. Requires the token of the feed link instead of an access token.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "students"
+                    "calendar"
+                ],
+                "summary": "Get a calendar feed",
+                "parameters": [
+                    {
+                        "enum": [
+                            "events",
+                            "classes",
+                            "teachers"
+                        ],
+                        "type": "string",
+                        "description": "Feed scope",
+                        "name": "scope",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID followed by .ics, e.g. 1.ics",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token from the feed link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all students",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "iCalendar document",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Student"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -914,46 +1249,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new student in the database",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/certificates/verify/{code}": {
+            "get": {
+                "description": "Check that a certificate of attendance is genuine using the code printed on it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "students"
+                    "certificates"
                 ],
-                "summary": "Create a new student",
+                "summary": "Verify a certificate",
                 "parameters": [
                     {
-                        "description": "Student data",
-                        "name": "student",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateStudentRequest"
-                        }
+                        "type": "string",
+                        "description": "Verification code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "success",
                         "schema": {
-                            "$ref": "#/definitions/models.Student"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -962,23 +1295,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/teachers": {
+        "/classes": {
             "get": {
-                "description": "Get all teachers from the database",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all classes from the database",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "teachers"
+                    "classes"
                 ],
-                "summary": "Get all teachers",
+                "summary": "Get all classes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Teacher"
+                                "$ref": "#/definitions/models.Class"
                             }
                         }
                     },
@@ -992,7 +1330,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new teacher in the database",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new class in the database. The class code must not be used by another class.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1000,25 +1343,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teachers"
+                    "classes"
                 ],
-                "summary": "Create a new teacher",
+                "summary": "Create a new class",
                 "parameters": [
                     {
-                        "description": "Teacher data",
-                        "name": "teacher",
+                        "description": "Class data",
+                        "name": "class",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateTeacherRequest"
+                            "$ref": "#/definitions/models.CreateClassRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Teacher"
+                            "$ref": "#/definitions/models.Class"
                         }
                     },
                     "400": {
@@ -1028,6 +1377,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1038,20 +1394,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/teachers/{id}": {
+        "/classes/{id}": {
             "get": {
-                "description": "Get a specific teacher by ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a specific class with its students",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "teachers"
+                    "classes"
                 ],
-                "summary": "Get teacher by ID",
+                "summary": "Get class by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Teacher ID",
+                        "description": "Class ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1061,7 +1422,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Teacher"
+                            "$ref": "#/definitions/models.Class"
                         }
                     },
                     "400": {
@@ -1086,62 +1447,4783 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.Attendance": {
-            "type": "object",
-            "properties": {
-                "checked_in_at": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "session": {
-                    "description": "Relationships",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.AttendanceSession"
-                        }
-                    ]
-                },
-                "session_id": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a class together with its enrollments. A class that still has students is only deleted with cascade=true, which deletes its students too; otherwise the request fails with 409. Students whose main class is another one stay, no longer enrolled in this class. Restoring the class from the trash brings its enrollments and students back. Sessions keep their class ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "classes"
+                ],
+                "summary": "Delete a class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also delete the students of the class",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the code or name of a class; omitted fields are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "classes"
+                ],
+                "summary": "Update a class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "class",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateClassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Class"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a subscribable .ics link with every session of the class. The link works without an access token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the calendar feed link of a class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get all events",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new event with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Create a new event",
+                "parameters": [
+                    {
+                        "description": "Event data. form_schema defines custom check-in form fields (types: text, textarea, number, email, phone, date, checkbox, select, multiselect)",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateEventRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/active": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all active events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get all active events",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single event by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get event by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing event with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Update an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an event to the trash together with its session series, sessions, their attendances, its registrations and its certificates, which stop verifying until it is restored; POST /trash/events/{id}/restore brings them back",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Delete an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/active": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deprecated, use PUT /events/{id}/status. 1 moves the event to ongoing, publishing a draft first; 0 closes a published or ongoing event and leaves other events as they are. Archived events cannot become active (409).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Set event active status",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Active status (1 = ongoing, 0 = closed)",
+                        "name": "active",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "active": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/attendance-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total minutes attended, sessions attended and partial sessions per person across all sessions of an event",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendances"
+                ],
+                "summary": "Get attended time per person for an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AttendeeReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/events/{id}/attendances/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the attendances of an event as a CSV file, with one column per custom form field of the event.\nTeachers only get the attendances of their own sessions.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "attendances"
+                ],
+                "summary": "Export event attendances as CSV",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a subscribable .ics link with every session of the event. The link works without an access token, so share it only with the event's attendees.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the calendar feed link of an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/certificates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the certificates of every eligible attendee of an event as a ZIP of PDFs. Attendees who left a session early or did not reach the event's certificate_min_hours are left out.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "certificates"
+                ],
+                "summary": "Download all certificates of an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copy an event and its sessions as a new draft event. Give either start_date, to move the event's start (or first session) there, or offset_days, to move every date by that many days keeping its time of day. Sessions keep their class, teacher and location; attendances and registrations are not copied. Moved sessions that overlap another session of the same teacher, class or room are rejected with 409 and the list of conflicts; set force to clone anyway, in which case the conflicts are returned as a warning.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Clone an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New dates",
+                        "name": "clone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CloneEventRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/no-shows": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the registered people of an event who have no check-in. Registrations for a session need a check-in for that session; event registrations are satisfied by any session of the event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registrations"
+                ],
+                "summary": "Get event no-shows",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only registrations for this session",
+                        "name": "session_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/registrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the registrations of an event in registration order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registrations"
+                ],
+                "summary": "Get event registrations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only registrations for this session",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "registered",
+                            "waitlisted",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register for an event, or for one of its sessions when session_id is set. Only published and ongoing events take registrations; others fail with 409. Registrations beyond the capacity are waitlisted and promoted automatically when someone cancels. Keep the returned cancel_token to cancel later.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registrations"
+                ],
+                "summary": "Register for an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Registration data",
+                        "name": "registration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single event by its ID including its attendance sessions; teachers only get the sessions they teach",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get event with sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an event to another status. Allowed transitions: draft -\u003e published|archived, published -\u003e draft|ongoing|closed, ongoing -\u003e closed, closed -\u003e ongoing|archived. Attendees can only check in while the event is ongoing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Change event status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateEventStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/status-log": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every status change of an event, oldest first, including the changes made automatically at its start and end dates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get event status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EventStatusLog"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Students see their own requests, teachers see requests for their sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Get excuse requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by attendance session",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by student",
+                        "name": "student_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Explain an absence from an attendance session. Student accounts submit for themselves; managers must pass student_id.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Submit an excuse request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attendance session ID",
+                        "name": "session_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Student ID (ignored for student accounts)",
+                        "name": "student_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason for the absence",
+                        "name": "reason",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Supporting document (PDF, JPG or PNG, max 5 MB)",
+                        "name": "attachment",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single excuse request including its history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Get excuse request by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Excuse request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending excuse request; the student is shown as excused on the session roster. Teachers can only review requests for their own sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Approve an excuse request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Excuse request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewExcuseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/attachment": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the supporting document of an excuse request",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Download excuse attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Excuse request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every submission and review of an excuse request, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Get excuse request history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Excuse request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending excuse request. Teachers can only review requests for their own sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Reject an excuse request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Excuse request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewExcuseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the application and database are running",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/registrations/cancel": {
+            "post": {
+                "description": "Cancel a registration with the token received when registering. The freed place goes to the next person on the waitlist; promoted is the number of waitlisted registrations that got a place.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registrations"
+                ],
+                "summary": "Cancel a registration",
+                "parameters": [
+                    {
+                        "description": "Cancel token",
+                        "name": "cancel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success: message, data (the cancelled registration), promoted (count)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/registrations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a registration as an organizer. The freed place goes to the next person on the waitlist; promoted is the number of waitlisted registrations that got a place.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registrations"
+                ],
+                "summary": "Cancel a registration by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success: message, data (the cancelled registration), promoted (count)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all rooms, ordered by building and name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get all rooms",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a room that sessions can be booked in. Its capacity also caps registrations and check-ins of those sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Create a new room",
+                "parameters": [
+                    {
+                        "description": "Room data",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/available": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the rooms without any session overlapping the range, e.g. from=2025-09-02T08:00:00+07:00\u0026to=2025-09-02T10:00:00+07:00. Dates may also be YYYY-MM-DD days, where to includes the whole day.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get free rooms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only rooms holding at least this many people",
+                        "name": "min_capacity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single room by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get room by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the fields of a room that are given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Update a room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a room that has no upcoming sessions; past sessions keep their room ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Delete a room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/session-series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get recurring session series; teachers only see the series they teach",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session-series"
+                ],
+                "summary": "Get recurring session series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by Event ID",
+                        "name": "event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SessionSeries"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a series from an RFC 5545 RRULE (FREQ=DAILY, WEEKLY, MONTHLY or YEARLY, ending with COUNT or UNTIL) and generate one attendance session per occurrence. Occurrences keep the time of day of starts_at in the series time zone; exdates skip whole days. Check-in windows are given in minutes relative to each occurrence. Series whose occurrences overlap another session of the same teacher, class or room, or each other, are rejected with 409 and the list of conflicts; set force to create them anyway, in which case the conflicts are returned as a warning.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session-series"
+                ],
+                "summary": "Create a recurring session series",
+                "parameters": [
+                    {
+                        "description": "Session series data",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSessionSeriesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SessionSeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/session-series/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a session series with its generated sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session-series"
+                ],
+                "summary": "Get a recurring session series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SessionSeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a series and regenerate its sessions that have not started yet. Past sessions are never changed, and upcoming sessions that already have attendances are kept even when they no longer occur. Changes that make occurrences overlap another session of the same teacher, class or room are rejected with 409 unless force is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session-series"
+                ],
+                "summary": "Update a recurring session series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSessionSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SessionSeriesChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a series and remove its sessions that have not started yet. Past sessions and upcoming sessions that already have attendances are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session-series"
+                ],
+                "summary": "Cancel a recurring session series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SessionSeriesChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all attendance records for a specific session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendances"
+                ],
+                "summary": "Get attendances by session ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (present, late)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attendance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all students",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Get all students",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Student"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new student in the database. The class must exist and the student code must not be used by another student. The student is enrolled in the class from today.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Create a new student",
+                "parameters": [
+                    {
+                        "description": "Student data",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateStudentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a student with their class. Students can only get themselves.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Get student by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a student; their attendances and excuse requests are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Delete a student",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the fields of a student that are given; omitted fields are kept. A new class_id transfers the student from today; use POST /students/{id}/transfer for another date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Update a student",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/enrollments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the classes a student is or was enrolled in, oldest first. enrolled_to is the first day no longer enrolled, null while still enrolled. Students can only get their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Get a student's enrollments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Enrollment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enroll a student in another class, e.g. a second course section, as a student, auditor or assistant. The class becomes the student's main class when they have none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Enroll a student in a class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Class and role",
+                        "name": "enrollment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EnrollStudentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End the student's enrollment in from_class_id (their main class by default) and enroll them in to_class_id from effective_date, which may be in the past. Rosters of sessions before that date keep listing the student in the old class.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Transfer a student to another class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Classes and effective date",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferStudentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End the student's enrollment in class_id (their main class by default) from effective_date. The student record is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Withdraw a student from a class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Class and effective date",
+                        "name": "withdrawal",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.WithdrawStudentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all teachers from the database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Get all teachers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Teacher"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new teacher in the database. The teacher code must not be used by another teacher.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Create a new teacher",
+                "parameters": [
+                    {
+                        "description": "Teacher data",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTeacherRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Teacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a specific teacher by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Get teacher by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Teacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a teacher who has no upcoming sessions; past sessions keep their teacher ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Delete a teacher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the fields of a teacher that are given; omitted fields are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Update a teacher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTeacherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Teacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a subscribable .ics link with every session the teacher teaches. Teachers can only get their own link.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the calendar feed link of a teacher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{resource}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the deleted events, students, classes, teachers or rooms, most recently deleted first. purge_at is when the automatic purge removes a record (TRASH_RETENTION_DAYS after deletion), null when it is disabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "List deleted records",
+                "parameters": [
+                    {
+                        "enum": [
+                            "events",
+                            "students",
+                            "classes",
+                            "teachers",
+                            "rooms"
+                        ],
+                        "type": "string",
+                        "description": "Resource",
+                        "name": "resource",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{resource}/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently delete a record from the trash. Purging an event also removes its session series, sessions, attendances, registrations, excuse requests and certificates; purging a class removes its deleted students. Records still used elsewhere, such as a teacher with past sessions, cannot be purged. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Purge a deleted record",
+                "parameters": [
+                    {
+                        "enum": [
+                            "events",
+                            "students",
+                            "classes",
+                            "teachers",
+                            "rooms"
+                        ],
+                        "type": "string",
+                        "description": "Resource",
+                        "name": "resource",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{resource}/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a deleted record together with the records deleted with it: an event's session series, sessions, attendances, registrations and certificates, or the students deleted with a class. Records deleted on their own stay in the trash. restored counts the records brought back per table.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a deleted record",
+                "parameters": [
+                    {
+                        "enum": [
+                            "events",
+                            "students",
+                            "classes",
+                            "teachers",
+                            "rooms"
+                        ],
+                        "type": "string",
+                        "description": "Resource",
+                        "name": "resource",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Attendance": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "description": "Answers to the event's custom form fields",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FormAnswers"
+                        }
+                    ]
+                },
+                "checked_in_at": {
+                    "type": "string"
+                },
+                "checked_out_at": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "description": "Kiosk sync: ClientID is generated by the tablet so that replayed uploads\nare recognized; CheckedInAt then holds the device time and SyncedAt the upload time",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_partial": {
+                    "description": "Stayed less than the session's minimum duration",
+                    "type": "boolean"
+                },
+                "marked_at": {
+                    "type": "string"
+                },
+                "marked_by_user_id": {
+                    "type": "integer"
+                },
+                "matched_by": {
+                    "description": "Student matching: NeedsReview is set when a check-in could not be\nlinked to exactly one student and must be resolved manually",
+                    "type": "string"
+                },
+                "needs_review": {
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "session": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AttendanceSession"
+                        }
+                    ]
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student": {
+                    "$ref": "#/definitions/models.Student"
+                },
+                "student_id": {
+                    "type": "integer"
+                },
+                "student_name": {
+                    "type": "string"
+                },
+                "synced_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "work_unit": {
+                    "type": "string"
+                },
+                "work_unit_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AttendanceMark": {
+            "type": "object",
+            "required": [
+                "status",
+                "student_id"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Đến muộn do kẹt xe"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "present",
+                        "absent",
+                        "excused",
+                        "late"
+                    ],
+                    "example": "present"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.AttendanceSession": {
+            "type": "object",
+            "properties": {
+                "attendances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attendance"
+                    }
+                },
+                "capacity": {
+                    "description": "Limits registrations, or check-ins for events without registration\n(nil = unlimited). The room's capacity applies as well.",
+                    "type": "integer"
+                },
+                "class": {
+                    "$ref": "#/definitions/models.Class"
+                },
+                "class_id": {
+                    "type": "integer"
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "description": "Length of the session, used for calendar feeds and double-booking checks.\nSessions without one last until ClosesAt, else MinDurationMinutes, else an hour.",
+                    "type": "integer"
+                },
+                "event": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    ]
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "late_after": {
+                    "type": "string"
+                },
+                "location": {
+                    "description": "Address or room outside the room list, shown in calendar feeds",
+                    "type": "string"
+                },
+                "min_duration_minutes": {
+                    "description": "Attendances checked out before this many minutes are flagged as partial",
+                    "type": "integer"
+                },
+                "opens_at": {
+                    "description": "Check-in window: check-ins before OpensAt or after ClosesAt are rejected,\ncheck-ins after LateAfter are marked late. Nil bounds are not enforced.",
+                    "type": "string"
+                },
+                "room": {
+                    "$ref": "#/definitions/models.Room"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "series_id": {
+                    "description": "Set for sessions generated by a recurring series",
+                    "type": "integer"
+                },
+                "session_date": {
+                    "type": "string"
+                },
+                "teacher": {
+                    "$ref": "#/definitions/models.Teacher"
+                },
+                "teacher_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AttendeeReport": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "not_checked_out": {
+                    "type": "integer"
+                },
+                "partial_sessions": {
+                    "type": "integer"
+                },
+                "sessions_attended": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "integer"
+                },
+                "student_name": {
+                    "type": "string"
+                },
+                "total_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BatchAttendanceItem": {
+            "type": "object",
+            "required": [
+                "checked_in_at",
+                "client_id",
+                "email",
+                "phone",
+                "session_id",
+                "student_name"
+            ],
+            "properties": {
+                "answers": {
+                    "$ref": "#/definitions/models.FormAnswers"
+                },
+                "checked_in_at": {
+                    "description": "Device time of the check-in",
+                    "type": "string",
+                    "example": "2025-08-20T08:31:46Z"
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "6f1c2a9e-4b7d-4e8a-9c3f-1d2e3f4a5b6c"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "student@example.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "0123456789"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "student_code": {
+                    "type": "string",
+                    "example": "SV001"
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Nguyen Van A"
+                },
+                "work_unit": {
+                    "type": "string",
+                    "example": "Công ty ABC"
+                },
+                "work_unit_address": {
+                    "type": "string",
+                    "example": "123 Đường ABC, Quận 1, TP.HCM"
+                }
+            }
+        },
+        "models.BatchAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.BatchAttendanceItem"
+                    }
+                }
+            }
+        },
+        "models.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "attendance": {
+                    "$ref": "#/definitions/models.Attendance"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "created"
+                }
+            }
+        },
+        "models.BulkMarkAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "marks"
+            ],
+            "properties": {
+                "marks": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.AttendanceMark"
+                    }
+                }
+            }
+        },
+        "models.CalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "events"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/calendar/events/1.ics?token=q8Xk3v0dUe9pQm1sT2aZ4w"
+                },
+                "webcal_url": {
+                    "type": "string",
+                    "example": "webcal://localhost:8080/api/calendar/events/1.ics?token=q8Xk3v0dUe9pQm1sT2aZ4w"
+                }
+            }
+        },
+        "models.CancelRegistrationRequest": {
+            "type": "object",
+            "required": [
+                "cancel_token"
+            ],
+            "properties": {
+                "cancel_token": {
+                    "type": "string",
+                    "example": "3f2a9c0e8b7d4f1a6c5e2d9b8a7f6e5d"
+                }
+            }
+        },
+        "models.CheckinTokenResponse": {
+            "type": "object",
+            "properties": {
+                "checkin_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/checkin/1?token=57812345.q8Xk3v0dUe9pQm1sT2aZ4w"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "token": {
+                    "type": "string",
+                    "example": "57812345.q8Xk3v0dUe9pQm1sT2aZ4w"
+                },
+                "ttl_seconds": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "models.CheckoutRequest": {
+            "type": "object",
+            "required": [
+                "checkin_token",
+                "email",
+                "session_id"
+            ],
+            "properties": {
+                "checkin_token": {
+                    "type": "string",
+                    "example": "57812345.q8Xk3v0dUe9pQm1sT2aZ4w"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "student@example.com"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.Class": {
+            "type": "object",
+            "properties": {
+                "class_code": {
+                    "type": "string"
+                },
+                "class_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "student_name": {
-                    "type": "string"
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AttendanceSession"
+                    }
+                },
+                "students": {
+                    "description": "Relationships",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Student"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CloneEventRequest": {
+            "type": "object",
+            "properties": {
+                "event_name": {
+                    "description": "Defaults to the original name with \" (copy)\"",
+                    "type": "string",
+                    "example": "Workshop AI - Spring 2026"
+                },
+                "force": {
+                    "description": "Clone even when the moved sessions overlap other sessions of the same teacher, class or room",
+                    "type": "boolean",
+                    "example": false
+                },
+                "offset_days": {
+                    "type": "integer",
+                    "example": 182
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-02T08:00:00+07:00"
+                }
+            }
+        },
+        "models.CreateAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "checkin_token",
+                "email",
+                "phone",
+                "session_id",
+                "student_name"
+            ],
+            "properties": {
+                "answers": {
+                    "description": "Answers to the event's custom form fields by field key",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FormAnswers"
+                        }
+                    ]
+                },
+                "checkin_token": {
+                    "type": "string",
+                    "example": "57812345.q8Xk3v0dUe9pQm1sT2aZ4w"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "student@example.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "0123456789"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "student_code": {
+                    "type": "string",
+                    "example": "SV001"
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Nguyen Van A"
+                },
+                "work_unit": {
+                    "description": "Required when the event has no form schema",
+                    "type": "string",
+                    "example": "Công ty ABC"
+                },
+                "work_unit_address": {
+                    "description": "Required when the event has no form schema",
+                    "type": "string",
+                    "example": "123 Đường ABC, Quận 1, TP.HCM"
+                }
+            }
+        },
+        "models.CreateAttendanceSessionRequest": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "class_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "closes_at": {
+                    "type": "string",
+                    "example": "2025-08-20T10:00:00Z"
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "force": {
+                    "description": "Create the session even when it overlaps another session of the same teacher, class or room",
+                    "type": "boolean",
+                    "example": false
+                },
+                "late_after": {
+                    "type": "string",
+                    "example": "2025-08-20T08:40:00Z"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Room A101"
+                },
+                "min_duration_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "2025-08-20T08:15:00Z"
+                },
+                "room_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "session_date": {
+                    "type": "string",
+                    "example": "2025-08-20T08:31:46.121Z"
+                },
+                "teacher_id": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "models.CreateClassRequest": {
+            "type": "object",
+            "required": [
+                "class_code",
+                "class_name"
+            ],
+            "properties": {
+                "class_code": {
+                    "type": "string",
+                    "example": "LOP001"
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "Lớp Khoa học máy tính K65"
+                }
+            }
+        },
+        "models.CreateEventRequest": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "certificate_min_hours": {
+                    "type": "number",
+                    "example": 4
+                },
+                "certificate_template": {
+                    "type": "string",
+                    "example": "This is to certify that {name} attended {event} on {dates}, for a total of {hours} hours."
+                },
+                "checkin_token_skew_seconds": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "checkin_token_ttl_seconds": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "dedupe_by_phone": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Workshop về trí tuệ nhân tạo"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-03T00:00:00Z"
+                },
+                "event_name": {
+                    "type": "string",
+                    "example": "Workshop AI"
+                },
+                "form_schema": {
+                    "description": "Replaces the event's custom check-in form fields; an empty list restores the default work unit fields",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FormField"
+                    }
+                },
+                "require_registration": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.CreateRegistrationRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "student@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Nguyen Van A"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "0123456789"
+                },
+                "session_id": {
+                    "description": "Omit to register for the whole event",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateSessionSeriesRequest": {
+            "type": "object",
+            "required": [
+                "rrule",
+                "starts_at"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "class_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "closes_after_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "exdates": {
+                    "description": "Days to skip (YYYY-MM-DD)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-09-02"
+                    ]
+                },
+                "force": {
+                    "description": "Create the series even when occurrences overlap other sessions of the same teacher, class or room",
+                    "type": "boolean",
+                    "example": false
+                },
+                "late_after_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Room A101"
+                },
+                "min_duration_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "opens_before_minutes": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "room_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20251220T235959Z"
                 },
-                "updated_at": {
-                    "type": "string"
+                "starts_at": {
+                    "description": "First occurrence",
+                    "type": "string",
+                    "example": "2025-09-01T08:00:00+07:00"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "timezone": {
+                    "description": "Defaults to APP_TIMEZONE",
+                    "type": "string",
+                    "example": "Asia/Ho_Chi_Minh"
+                }
+            }
+        },
+        "models.CreateStudentRequest": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "date_of_birth": {
+                    "type": "string",
+                    "example": "2000-01-01T00:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "0123456789"
+                },
+                "student_code": {
+                    "type": "string",
+                    "example": "SV001"
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Nguyen Van A"
                 },
                 "work_unit": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Công ty ABC"
+                }
+            }
+        },
+        "models.CreateTeacherRequest": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string",
+                    "example": "1980-01-01T00:00:00Z"
                 },
-                "work_unit_address": {
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "example": "teacher@example.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "0123456789"
+                },
+                "teacher_code": {
+                    "type": "string",
+                    "example": "GV001"
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Nguyen Thi B"
+                },
+                "work_unit": {
+                    "type": "string",
+                    "example": "Trường Đại học ABC"
                 }
             }
         },
-        "models.AttendanceSession": {
+        "models.EnrollStudentRequest": {
             "type": "object",
+            "required": [
+                "class_id"
+            ],
             "properties": {
-                "attendances": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Attendance"
-                    }
+                "class_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "enrolled_from": {
+                    "description": "YYYY-MM-DD or RFC3339; defaults to today",
+                    "type": "string",
+                    "example": "2025-09-01"
                 },
+                "role": {
+                    "description": "student (default), auditor or assistant",
+                    "type": "string",
+                    "example": "student"
+                }
+            }
+        },
+        "models.Enrollment": {
+            "type": "object",
+            "properties": {
                 "class": {
                     "$ref": "#/definitions/models.Class"
                 },
@@ -1151,27 +6233,32 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
-                "event": {
-                    "description": "Relationships",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Event"
-                        }
-                    ]
+                "end_reason": {
+                    "type": "string",
+                    "example": "transferred"
                 },
-                "event_id": {
-                    "type": "integer"
+                "enrolled_from": {
+                    "type": "string"
+                },
+                "enrolled_to": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "session_date": {
-                    "type": "string"
+                "role": {
+                    "type": "string",
+                    "example": "student"
                 },
-                "teacher": {
-                    "$ref": "#/definitions/models.Teacher"
+                "student": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Student"
+                        }
+                    ]
                 },
-                "teacher_id": {
+                "student_id": {
                     "type": "integer"
                 },
                 "updated_at": {
@@ -1179,225 +6266,484 @@ const docTemplate = `{
                 }
             }
         },
-        "models.Class": {
+        "models.Event": {
             "type": "object",
             "properties": {
-                "class_code": {
-                    "type": "string"
+                "capacity": {
+                    "description": "Registration: Capacity limits event-wide registrations (nil = unlimited),\nRequireRegistration rejects check-ins from people who did not register",
+                    "type": "integer"
                 },
-                "class_name": {
+                "certificate_min_hours": {
+                    "type": "number"
+                },
+                "certificate_template": {
+                    "description": "Certificates of attendance: the template text takes the placeholders {name},\n{event}, {dates} and {hours}; attendees need CertificateMinHours when set",
                     "type": "string"
                 },
+                "checkin_token_skew_seconds": {
+                    "type": "integer"
+                },
+                "checkin_token_ttl_seconds": {
+                    "description": "Rotating QR check-in token settings, in seconds (nil = server default)",
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "dedupe_by_phone": {
+                    "description": "Also treat check-ins with the same phone number as duplicates (email always is)",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "event_name": {
+                    "type": "string"
+                },
+                "form_schema": {
+                    "description": "Custom check-in form fields; when empty the form asks for the work unit instead",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FormField"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
+                "require_registration": {
+                    "type": "boolean"
+                },
                 "sessions": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/models.AttendanceSession"
                     }
                 },
-                "students": {
-                    "description": "Relationships",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Student"
-                    }
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Lifecycle: draft -\u003e published -\u003e ongoing -\u003e closed -\u003e archived (see EventService.SetEventStatus).\nAttendees can only check in while the event is ongoing. Published events\nbecome ongoing at StartDate and are closed at EndDate by the scheduler.",
+                    "type": "string",
+                    "example": "draft"
+                },
+                "status_changed_at": {
+                    "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.CreateAttendanceRequest": {
+        "models.EventStatusLog": {
             "type": "object",
-            "required": [
-                "email",
-                "phone",
-                "session_id",
-                "student_name",
-                "work_unit",
-                "work_unit_address"
-            ],
             "properties": {
-                "email": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "from_status": {
                     "type": "string",
-                    "example": "student@example.com"
+                    "example": "published"
                 },
-                "phone": {
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string",
-                    "example": "0123456789"
+                    "example": "start_date reached"
                 },
-                "session_id": {
-                    "type": "integer",
-                    "example": 1
+                "source": {
+                    "type": "string",
+                    "example": "scheduler"
                 },
-                "student_name": {
+                "to_status": {
                     "type": "string",
-                    "example": "Nguyen Van A"
+                    "example": "ongoing"
+                }
+            }
+        },
+        "models.FormAnswers": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "models.FormField": {
+            "type": "object",
+            "properties": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Computer Science",
+                        "Mathematics"
+                    ]
                 },
-                "work_unit": {
+                "key": {
+                    "description": "Answers are stored under this key",
                     "type": "string",
-                    "example": "Công ty ABC"
+                    "example": "faculty"
                 },
-                "work_unit_address": {
+                "label": {
                     "type": "string",
-                    "example": "123 Đường ABC, Quận 1, TP.HCM"
+                    "example": "Faculty"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "example": "select"
                 }
             }
         },
-        "models.CreateAttendanceSessionRequest": {
+        "models.LoginRequest": {
             "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
             "properties": {
-                "class_id": {
+                "password": {
+                    "type": "string",
+                    "example": "secret"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "models.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "models.ResolveAttendanceStudentRequest": {
+            "type": "object",
+            "required": [
+                "student_id"
+            ],
+            "properties": {
+                "student_id": {
                     "type": "integer",
                     "example": 1
+                }
+            }
+        },
+        "models.ReviewExcuseRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Đã xem giấy khám bệnh"
+                }
+            }
+        },
+        "models.Room": {
+            "type": "object",
+            "properties": {
+                "building": {
+                    "type": "string",
+                    "example": "Building B"
                 },
-                "event_id": {
+                "capacity": {
+                    "description": "nil = unlimited",
+                    "type": "integer",
+                    "example": 60
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 10.7769
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.7009
+                },
+                "name": {
+                    "type": "string",
+                    "example": "A101"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RoomRequest": {
+            "type": "object",
+            "properties": {
+                "building": {
+                    "type": "string",
+                    "example": "Building B"
+                },
+                "capacity": {
                     "type": "integer",
-                    "example": 1
+                    "example": 60
                 },
-                "session_date": {
-                    "type": "string",
-                    "example": "2025-08-20T08:31:46.121Z"
+                "latitude": {
+                    "type": "number",
+                    "example": 10.7769
                 },
-                "teacher_id": {
+                "longitude": {
+                    "type": "number",
+                    "example": 106.7009
+                },
+                "name": {
+                    "description": "Required when creating a room",
                     "type": "string",
-                    "example": "1"
+                    "example": "A101"
                 }
             }
         },
-        "models.CreateClassRequest": {
+        "models.RosterEntry": {
             "type": "object",
-            "required": [
-                "class_code",
-                "class_name"
-            ],
             "properties": {
-                "class_code": {
+                "attendance": {
+                    "$ref": "#/definitions/models.Attendance"
+                },
+                "role": {
+                    "description": "Enrollment role in the class",
                     "type": "string",
-                    "example": "LOP001"
+                    "example": "student"
                 },
-                "class_name": {
+                "status": {
                     "type": "string",
-                    "example": "Lớp Khoa học máy tính K65"
+                    "example": "present"
+                },
+                "student": {
+                    "$ref": "#/definitions/models.Student"
                 }
             }
         },
-        "models.CreateEventRequest": {
+        "models.SessionConflict": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Workshop về trí tuệ nhân tạo"
+                "ends_at": {
+                    "type": "string"
                 },
-                "event_name": {
-                    "type": "string",
-                    "example": "Workshop AI"
+                "occurrence": {
+                    "description": "When several sessions are planned at once (a series, a cloned event),\nthe start of the planned session that overlaps this one",
+                    "type": "string"
                 },
-                "start_date": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                "reasons": {
+                    "description": "What the sessions share: \"teacher\", \"class\" and/or \"room\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "teacher"
+                    ]
+                },
+                "session_date": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
-        "models.CreateStudentRequest": {
+        "models.SessionConflictPair": {
             "type": "object",
             "properties": {
-                "class_id": {
+                "other_session_id": {
                     "type": "integer",
-                    "example": 1
-                },
-                "date_of_birth": {
-                    "type": "string",
-                    "example": "2000-01-01T00:00:00Z"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                    "example": 15
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "0123456789"
+                "overlap_end": {
+                    "type": "string"
                 },
-                "student_code": {
-                    "type": "string",
-                    "example": "SV001"
+                "overlap_start": {
+                    "type": "string"
                 },
-                "student_name": {
-                    "type": "string",
-                    "example": "Nguyen Van A"
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "teacher"
+                    ]
                 },
-                "work_unit": {
-                    "type": "string",
-                    "example": "Công ty ABC"
+                "session_id": {
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
-        "models.CreateTeacherRequest": {
+        "models.SessionRoster": {
             "type": "object",
             "properties": {
-                "date_of_birth": {
-                    "type": "string",
-                    "example": "1980-01-01T00:00:00Z"
+                "class_id": {
+                    "type": "integer"
                 },
-                "email": {
-                    "type": "string",
-                    "example": "teacher@example.com"
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RosterEntry"
+                    }
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "0123456789"
+                "guests": {
+                    "description": "Matched to students outside the class",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attendance"
+                    }
                 },
-                "teacher_code": {
-                    "type": "string",
-                    "example": "GV001"
+                "session_id": {
+                    "type": "integer"
                 },
-                "teacher_name": {
-                    "type": "string",
-                    "example": "Nguyen Thi B"
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "work_unit": {
-                    "type": "string",
-                    "example": "Trường Đại học ABC"
+                "unmatched": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attendance"
+                    }
                 }
             }
         },
-        "models.Event": {
+        "models.SessionSeries": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "cancelled_at": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "capacity": {
+                    "type": "integer"
                 },
-                "event_name": {
+                "class_id": {
+                    "type": "integer"
+                },
+                "closes_after_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "created_at": {
                     "type": "string"
                 },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "exdates": {
+                    "description": "Skipped days (YYYY-MM-DD)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-09-02"
+                    ]
+                },
                 "id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "late_after_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "location": {
+                    "description": "Copied to every session",
+                    "type": "string",
+                    "example": "Room A101"
+                },
+                "min_duration_minutes": {
+                    "type": "integer"
+                },
+                "opens_before_minutes": {
+                    "description": "Check-in window of each occurrence, in minutes relative to its start",
+                    "type": "integer",
+                    "example": 15
+                },
+                "room_id": {
+                    "description": "Copied to every session",
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20251220T235959Z"
                 },
                 "sessions": {
+                    "description": "Relationships",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/models.AttendanceSession"
                     }
                 },
-                "start_date": {
+                "starts_at": {
+                    "description": "First occurrence; its time of day is kept for every occurrence in Timezone",
                     "type": "string"
                 },
+                "teacher_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Ho_Chi_Minh"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "models.SessionSeriesChanges": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "Overlapping sessions, when the change was forced through",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SessionConflict"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "kept": {
+                    "description": "Upcoming sessions that already have attendances are never removed",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "removed": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.Student": {
             "type": "object",
             "properties": {
@@ -1410,6 +6756,7 @@ const docTemplate = `{
                     ]
                 },
                 "class_id": {
+                    "description": "Main class, kept in sync by transfers and withdrawals",
                     "type": "integer"
                 },
                 "created_at": {
@@ -1421,6 +6768,12 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "enrollments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Enrollment"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -1479,6 +6832,149 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.TransferStudentRequest": {
+            "type": "object",
+            "required": [
+                "to_class_id"
+            ],
+            "properties": {
+                "effective_date": {
+                    "description": "First day in the new class; defaults to today",
+                    "type": "string",
+                    "example": "2025-10-01"
+                },
+                "from_class_id": {
+                    "description": "Defaults to the student's main class",
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_class_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.UpdateClassRequest": {
+            "type": "object",
+            "properties": {
+                "class_code": {
+                    "type": "string",
+                    "example": "LOP001"
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "Lớp Khoa học máy tính K65"
+                }
+            }
+        },
+        "models.UpdateEventStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "draft, published, ongoing, closed or archived",
+                    "type": "string",
+                    "example": "published"
+                }
+            }
+        },
+        "models.UpdateSessionSeriesRequest": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "class_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "closes_after_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "exdates": {
+                    "description": "Replaces the skipped days",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-09-02"
+                    ]
+                },
+                "force": {
+                    "description": "Apply the change even when occurrences overlap other sessions of the same teacher, class or room",
+                    "type": "boolean",
+                    "example": false
+                },
+                "late_after_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Room A101"
+                },
+                "min_duration_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "opens_before_minutes": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "room_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20251220T235959Z"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-09-01T09:00:00+07:00"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Ho_Chi_Minh"
+                }
+            }
+        },
+        "models.WithdrawStudentRequest": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "description": "Defaults to the student's main class",
+                    "type": "integer",
+                    "example": 1
+                },
+                "effective_date": {
+                    "description": "First day no longer enrolled; defaults to today",
+                    "type": "string",
+                    "example": "2025-10-01"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Type \"Bearer\" followed by a space and the access token from /auth/login.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"hello-gin/internal/middleware"
+	"hello-gin/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// teacherScope returns the teacher ID the current user is restricted to.
+// Only teacher accounts are scoped; a teacher account without a linked
+// teacher is scoped to ID 0 so it matches nothing.
+func teacherScope(c *gin.Context) (uint, bool) {
+	claims, ok := middleware.CurrentClaims(c)
+	if !ok || claims.Role != models.RoleTeacher {
+		return 0, false
+	}
+	if claims.TeacherID == nil {
+		return 0, true
+	}
+	return *claims.TeacherID, true
+}
+
+// authorizeSession writes a 403 response and returns false when a teacher
+// tries to access a session that is not their own
+func authorizeSession(c *gin.Context, session *models.AttendanceSession) bool {
+	teacherID, scoped := teacherScope(c)
+	if !scoped {
+		return true
+	}
+	if session != nil && session.TeacherID != nil && *session.TeacherID == teacherID {
+		return true
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":   "Forbidden",
+		"message": "Teachers can only access their own attendance sessions",
+	})
+	return false
+}
+
+// authorizeSeries writes a 403 response and returns false when a teacher
+// tries to manage a session series they do not teach
+func authorizeSeries(c *gin.Context, series *models.SessionSeries) bool {
+	teacherID, scoped := teacherScope(c)
+	if !scoped {
+		return true
+	}
+	if series != nil && series.TeacherID != nil && *series.TeacherID == teacherID {
+		return true
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":   "Forbidden",
+		"message": "Teachers can only manage their own session series",
+	})
+	return false
+}
+
+// canSeeAttendees reports whether the current user may see who attended a
+// session. Kiosks list sessions to show their QR codes but get no attendee
+// names, emails or phones.
+func canSeeAttendees(c *gin.Context) bool {
+	claims, ok := middleware.CurrentClaims(c)
+	return !ok || claims.Role != models.RoleKiosk
+}
+
+// hideAttendees drops the attendances of sessions the current user may not see
+func hideAttendees(c *gin.Context, sessions []models.AttendanceSession) {
+	if canSeeAttendees(c) {
+		return
+	}
+	for i := range sessions {
+		sessions[i].Attendances = nil
+	}
+}
+
+// studentScope returns the student ID the current user is restricted to.
+// Only student accounts are scoped; a student account without a linked
+// student is scoped to ID 0 so it matches nothing.
+func studentScope(c *gin.Context) (uint, bool) {
+	claims, ok := middleware.CurrentClaims(c)
+	if !ok || claims.Role != models.RoleStudent {
+		return 0, false
+	}
+	if claims.StudentID == nil {
+		return 0, true
+	}
+	return *claims.StudentID, true
+}
+
+// currentUserID returns the ID of the authenticated user, if any
+func currentUserID(c *gin.Context) *uint {
+	claims, ok := middleware.CurrentClaims(c)
+	if !ok {
+		return nil
+	}
+	return &claims.UserID
+}
@@ -1,15 +1,30 @@
 package controllers
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hello-gin/internal/interfaces"
 	"hello-gin/internal/models"
 	"hello-gin/internal/services"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+type AttendanceController struct {
+	checkInService interfaces.CheckInServiceInterface
+}
+
+func NewAttendanceController(checkInService interfaces.CheckInServiceInterface) *AttendanceController {
+	return &AttendanceController{
+		checkInService: checkInService,
+	}
+}
+
 // GetAttendances godoc
 // @Summary Get all attendances
 // @Description Get all attendance records with session information
@@ -17,18 +32,25 @@ import (
 // @Produce json
 // @Success 200 {array} models.Attendance
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /attendances [get]
-func GetAttendances(c *gin.Context) {
-	attendances, err := services.GetAttendances()
+func (c *AttendanceController) GetAttendances(ctx *gin.Context) {
+	var attendances []models.Attendance
+	var err error
+	if teacherID, scoped := teacherScope(ctx); scoped {
+		attendances, err = services.GetAttendancesByTeacherID(teacherID)
+	} else {
+		attendances, err = services.GetAttendances()
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch attendances",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    attendances,
 		"count":   len(attendances),
@@ -43,14 +65,16 @@ func GetAttendances(c *gin.Context) {
 // @Param id path int true "Attendance ID"
 // @Success 200 {object} models.Attendance
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /attendances/{id} [get]
-func GetAttendanceByID(c *gin.Context) {
-	idParam := c.Param("id")
+func (c *AttendanceController) GetAttendanceByID(ctx *gin.Context) {
+	idParam := ctx.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid attendance ID",
 			"message": "Attendance ID must be a number",
 		})
@@ -59,14 +83,18 @@ func GetAttendanceByID(c *gin.Context) {
 
 	attendance, err := services.GetAttendanceByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
+		ctx.JSON(http.StatusNotFound, gin.H{
 			"error":   "Attendance not found",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	if !authorizeSession(ctx, attendance.Session) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    attendance,
 	})
@@ -78,34 +106,58 @@ func GetAttendanceByID(c *gin.Context) {
 // @Tags attendances
 // @Produce json
 // @Param sessionId path int true "Session ID"
+// @Param status query string false "Filter by status (present, late)"
 // @Success 200 {array} models.Attendance
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /sessions/{sessionId}/attendances [get]
-func GetAttendancesBySessionID(c *gin.Context) {
-	sessionIdParam := c.Param("sessionId")
+func (c *AttendanceController) GetAttendancesBySessionID(ctx *gin.Context) {
+	sessionIdParam := ctx.Param("sessionId")
 	sessionId, err := strconv.Atoi(sessionIdParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid session ID",
 			"message": "Session ID must be a number",
 		})
 		return
 	}
 
+	if _, scoped := teacherScope(ctx); scoped {
+		session, err := services.GetAttendanceSessionByID(sessionId)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Attendance session not found",
+				"message": err.Error(),
+			})
+			return
+		}
+		if !authorizeSession(ctx, session) {
+			return
+		}
+	}
+
 	attendances, err := services.GetAttendancesBySessionID(sessionId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch attendances",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	summary := services.SummarizeAttendanceStatuses(attendances)
+	if status := ctx.Query("status"); status != "" {
+		attendances = services.FilterAttendancesByStatus(attendances, status)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    attendances,
 		"count":   len(attendances),
+		"summary": summary,
 	})
 }
 
@@ -115,81 +167,498 @@ func GetAttendancesBySessionID(c *gin.Context) {
 // @Tags attendances
 // @Produce json
 // @Param id path int true "Event ID"
+// @Param status query string false "Filter by status (present, late)"
 
 // @Failure 500 {object} map[string]interface{}// @Failure 400 {object} map[string]interface{}// @Success 200 {array} models.Attendance
 
+// @Security BearerAuth
 // @Router /events/{id}/attendances [get]
-func GetAttendancesByEventID(c *gin.Context) {
-	eventIdParam := c.Param("id")
+func (c *AttendanceController) GetAttendancesByEventID(ctx *gin.Context) {
+	eventIdParam := ctx.Param("id")
 	eventId, err := strconv.ParseUint(eventIdParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid event ID",
 			"message": "Event ID must be a number",
 		})
 		return
 	}
 
-	attendances, err := services.GetAttendancesByEventID(uint(eventId))
+	var attendances []models.Attendance
+	if teacherID, scoped := teacherScope(ctx); scoped {
+		attendances, err = services.GetAttendancesByEventIDAndTeacherID(uint(eventId), teacherID)
+	} else {
+		attendances, err = services.GetAttendancesByEventID(uint(eventId))
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch attendances",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	summary := services.SummarizeAttendanceStatuses(attendances)
+	if status := ctx.Query("status"); status != "" {
+		attendances = services.FilterAttendancesByStatus(attendances, status)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    attendances,
 		"count":   len(attendances),
+		"summary": summary,
 	})
 }
 
 // CreateAttendance godoc
 // @Summary Create a new attendance
-// @Description Create a new attendance record
+// @Description Self check-in to a session. Requires the rotating check-in token shown as a QR code for the session.
+// @Description Check-ins after the session's late_after time are marked late; check-ins outside opens_at/closes_at are rejected.
+// @Description Repeat check-ins with the same email (or phone, when the event dedupes by phone) return the existing record with 200.
+// @Description Events with a form_schema take the custom fields in answers; other events require work_unit and work_unit_address.
 // @Tags attendances
 // @Accept json
 // @Produce json
 // @Param attendance body models.CreateAttendanceRequest true "Attendance data"
+// @Success 200 {object} models.Attendance "Already checked in"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success 201 {object} models.Attendance
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{} "Outside the session's check-in window"
 // @Failure 500 {object} map[string]interface{}
 // @Router /attendances [post]
-func CreateAttendance(c *gin.Context) {
+func (c *AttendanceController) CreateAttendance(ctx *gin.Context) {
 	var req models.CreateAttendanceRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	// Create attendance from DTO
-	now := time.Now()
-	attendance := models.Attendance{
-		SessionID:       &req.SessionID,
-		CheckedInAt:     &now,
-		StudentName:     &req.StudentName,
-		Email:           &req.Email,
-		Phone:           &req.Phone,
-		WorkUnit:        &req.WorkUnit,
-		WorkUnitAddress: &req.WorkUnitAddress,
+	attendance, duplicate, err := c.checkInService.CheckIn(&req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSessionNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Attendance session not found",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrInvalidCheckinToken), errors.Is(err, services.ErrExpiredCheckinToken):
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"error":   "Invalid check-in token",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrSessionNotOpen), errors.Is(err, services.ErrSessionClosed), errors.Is(err, services.ErrEventNotOngoing):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":   "Check-in rejected",
+				"message": err.Error(),
+				"status":  models.AttendanceStatusRejected,
+			})
+		case errors.Is(err, services.ErrNotRegistered), errors.Is(err, services.ErrRegistrationWaitlisted):
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"error":   "Not registered",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrSessionFull):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":   "Session full",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrInvalidFormAnswers):
+			ctx.JSON(http.StatusBadRequest, formAnswersError(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to create attendance",
+				"message": err.Error(),
+			})
+		}
+		return
 	}
 
-	if err := services.CreateAttendance(&attendance); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create attendance",
+	if duplicate {
+		ctx.JSON(http.StatusOK, gin.H{
+			"success":   true,
+			"data":      attendance,
+			"duplicate": true,
+			"message":   "Already checked in to this session",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success":   true,
+		"data":      attendance,
+		"duplicate": false,
+		"message":   "Attendance created successfully",
+	})
+}
+
+// ResolveAttendanceStudent godoc
+// @Summary Link a check-in to a student
+// @Description Manually resolve a check-in that could not be matched to a student
+// @Tags attendances
+// @Accept json
+// @Produce json
+// @Param id path int true "Attendance ID"
+// @Param student body models.ResolveAttendanceStudentRequest true "Student to link"
+// @Success 200 {object} models.Attendance
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendances/{id}/student [put]
+func (c *AttendanceController) ResolveAttendanceStudent(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid attendance ID",
+			"message": "Attendance ID must be a number",
+		})
+		return
+	}
+
+	var req models.ResolveAttendanceStudentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	attendance, err := services.GetAttendanceByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance not found",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	if !authorizeSession(ctx, attendance.Session) {
+		return
+	}
+
+	if err := services.ResolveAttendanceStudent(attendance, req.StudentID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrStudentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Student not found",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrStudentAlreadyCheckedIn):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":   "Student already checked in",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to link attendance to student",
+				"message": err.Error(),
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    attendance,
+		"message": "Attendance linked to student successfully",
+	})
+}
+
+// SelfCheckOut godoc
+// @Summary Check out of a session
+// @Description Record the attendee's check-out time and attended duration. Requires the session's current check-in token.
+// @Tags attendances
+// @Accept json
+// @Produce json
+// @Param checkout body models.CheckoutRequest true "Check-out data"
+// @Success 200 {object} models.Attendance
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /attendances/checkout [post]
+func (c *AttendanceController) SelfCheckOut(ctx *gin.Context) {
+	var req models.CheckoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	attendance, err := services.SelfCheckOut(&req)
+	if err != nil {
+		respondCheckoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    attendance,
-		"message": "Attendance created successfully",
+		"message": "Checked out successfully",
 	})
 }
+
+// CheckOutAttendance godoc
+// @Summary Check out an attendance
+// @Description Record the check-out time and attended duration of an attendance on behalf of the attendee
+// @Tags attendances
+// @Produce json
+// @Param id path int true "Attendance ID"
+// @Success 200 {object} models.Attendance
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendances/{id}/checkout [post]
+func (c *AttendanceController) CheckOutAttendance(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid attendance ID",
+			"message": "Attendance ID must be a number",
+		})
+		return
+	}
+
+	attendance, err := services.GetAttendanceByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance not found",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if !authorizeSession(ctx, attendance.Session) {
+		return
+	}
+
+	if err := services.CheckOutAttendance(attendance); err != nil {
+		respondCheckoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    attendance,
+		"message": "Checked out successfully",
+	})
+}
+
+// GetEventAttendanceReport godoc
+// @Summary Get attended time per person for an event
+// @Description Total minutes attended, sessions attended and partial sessions per person across all sessions of an event
+// @Tags attendances
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {array} models.AttendeeReport
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /events/{id}/attendance-report [get]
+func (c *AttendanceController) GetEventAttendanceReport(ctx *gin.Context) {
+	eventId, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event ID",
+			"message": "Event ID must be a number",
+		})
+		return
+	}
+
+	var attendances []models.Attendance
+	if teacherID, scoped := teacherScope(ctx); scoped {
+		attendances, err = services.GetAttendancesByEventIDAndTeacherID(uint(eventId), teacherID)
+	} else {
+		attendances, err = services.GetAttendancesByEventID(uint(eventId))
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch attendances",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	report := services.BuildAttendanceReport(attendances)
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+		"count":   len(report),
+	})
+}
+
+func respondCheckoutError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSessionNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance session not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrAttendanceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance not found",
+			"message": "No check-in found for this email in the session",
+		})
+	case errors.Is(err, services.ErrInvalidCheckinToken), errors.Is(err, services.ErrExpiredCheckinToken):
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":   "Invalid check-in token",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotCheckedIn):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Not checked in",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to check out",
+			"message": err.Error(),
+		})
+	}
+}
+
+// SyncAttendances godoc
+// @Summary Upload kiosk check-ins in a batch
+// @Description Apply check-ins recorded by a kiosk, possibly while offline. Each item carries a client-generated UUID and the device's check-in time; uploading an item again reports it as a duplicate. Each item gets its own result: created, duplicate, or rejected with a reason.
+// @Tags attendances
+// @Accept json
+// @Produce json
+// @Param batch body models.BatchAttendanceRequest true "Check-ins to upload"
+// @Success 200 {array} models.BatchItemResult
+// @Failure 400 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendances/batch [post]
+func (c *AttendanceController) SyncAttendances(ctx *gin.Context) {
+	var req models.BatchAttendanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	// Validate items one by one so that one bad item only rejects itself
+	results := make([]models.BatchItemResult, len(req.Items))
+	valid := make([]models.BatchAttendanceItem, 0, len(req.Items))
+	validIndexes := make([]int, 0, len(req.Items))
+	for i, item := range req.Items {
+		if err := binding.Validator.ValidateStruct(&item); err != nil {
+			results[i] = models.BatchItemResult{
+				ClientID: item.ClientID,
+				Result:   models.BatchResultRejected,
+				Reason:   err.Error(),
+			}
+			continue
+		}
+		valid = append(valid, item)
+		validIndexes = append(validIndexes, i)
+	}
+
+	for i, result := range c.checkInService.SyncKioskAttendances(valid) {
+		results[validIndexes[i]] = result
+	}
+
+	summary := map[string]int{
+		models.BatchResultCreated:   0,
+		models.BatchResultDuplicate: 0,
+		models.BatchResultRejected:  0,
+	}
+	for _, result := range results {
+		summary[result.Result]++
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    results,
+		"count":   len(results),
+		"summary": summary,
+	})
+}
+
+// formAnswersError describes invalid form answers, with the problem per field
+func formAnswersError(err error) gin.H {
+	response := gin.H{
+		"error":   "Invalid form answers",
+		"message": err.Error(),
+	}
+	var formErr *services.FormValidationError
+	if errors.As(err, &formErr) {
+		response["fields"] = formErr.Fields
+	}
+	return response
+}
+
+// ExportEventAttendances godoc
+// @Summary Export event attendances as CSV
+// @Description Download the attendances of an event as a CSV file, with one column per custom form field of the event.
+// @Description Teachers only get the attendances of their own sessions.
+// @Tags attendances
+// @Produce text/csv
+// @Param id path int true "Event ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /events/{id}/attendances/export [get]
+func (c *AttendanceController) ExportEventAttendances(ctx *gin.Context) {
+	eventId, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event ID",
+			"message": "Event ID must be a number",
+		})
+		return
+	}
+
+	var teacherID *uint
+	if id, scoped := teacherScope(ctx); scoped {
+		teacherID = &id
+	}
+
+	rows, err := services.GetEventAttendanceExport(uint(eventId), teacherID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Event not found",
+				"message": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to export attendances",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\uFEFF") // Byte order mark so spreadsheet apps read the file as UTF-8
+	if err := csv.NewWriter(&buf).WriteAll(rows); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to export attendances",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%d-attendances.csv"`, eventId))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
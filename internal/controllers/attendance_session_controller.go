@@ -1,6 +1,10 @@
 package controllers
 
 import (
+	"errors"
+	"hello-gin/config"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/middleware"
 	"hello-gin/internal/models"
 	"hello-gin/internal/services"
 	"net/http"
@@ -10,26 +14,46 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type AttendanceSessionController struct {
+	sessionService interfaces.AttendanceSessionServiceInterface
+	rosterService  interfaces.RosterServiceInterface
+}
+
+func NewAttendanceSessionController(sessionService interfaces.AttendanceSessionServiceInterface, rosterService interfaces.RosterServiceInterface) *AttendanceSessionController {
+	return &AttendanceSessionController{
+		sessionService: sessionService,
+		rosterService:  rosterService,
+	}
+}
+
 // GetAttendanceSessions godoc
 // @Summary Get all attendance sessions
-// @Description Get all attendance sessions with class and teacher information
+// @Description Get all attendance sessions with class and teacher information. Teachers only get their own sessions; kiosks get them without attendances.
 // @Tags attendance-sessions
 // @Produce json
 // @Param event_id query int false "Filter by Event ID"
 // @Success 200 {array} models.AttendanceSession
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /attendance-sessions [get]
-func GetAttendanceSessions(c *gin.Context) {
-	sessions, err := services.GetAttendanceSessions()
+func (c *AttendanceSessionController) GetAttendanceSessions(ctx *gin.Context) {
+	var sessions []models.AttendanceSession
+	var err error
+	if teacherID, scoped := teacherScope(ctx); scoped {
+		sessions, err = services.GetAttendanceSessionsByTeacherID(teacherID)
+	} else {
+		sessions, err = services.GetAttendanceSessions()
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch attendance sessions",
 			"message": err.Error(),
 		})
 		return
 	}
+	hideAttendees(ctx, sessions)
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    sessions,
 		"count":   len(sessions),
@@ -38,20 +62,22 @@ func GetAttendanceSessions(c *gin.Context) {
 
 // GetAttendanceSessionByID godoc
 // @Summary Get attendance session by ID
-// @Description Get a specific attendance session with class, teacher, and attendances
+// @Description Get a specific attendance session with class, teacher, and attendances. Teachers can only get their own sessions; kiosks get the session without attendances.
 // @Tags attendance-sessions
 // @Produce json
 // @Param id path int true "Attendance Session ID"
 // @Success 200 {object} models.AttendanceSession
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /attendance-sessions/{id} [get]
-func GetAttendanceSessionByID(c *gin.Context) {
-	idParam := c.Param("id")
+func (c *AttendanceSessionController) GetAttendanceSessionByID(ctx *gin.Context) {
+	idParam := ctx.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid attendance session ID",
 			"message": "Attendance session ID must be a number",
 		})
@@ -60,14 +86,21 @@ func GetAttendanceSessionByID(c *gin.Context) {
 
 	session, err := services.GetAttendanceSessionByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
+		ctx.JSON(http.StatusNotFound, gin.H{
 			"error":   "Attendance session not found",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	if !authorizeSession(ctx, session) {
+		return
+	}
+	if !canSeeAttendees(ctx) {
+		session.Attendances = nil
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    session,
 	})
@@ -75,19 +108,23 @@ func GetAttendanceSessionByID(c *gin.Context) {
 
 // CreateAttendanceSession godoc
 // @Summary Create a new attendance session
-// @Description Create a new attendance session in the database
+// @Description Create a new attendance session in the database. Sessions that overlap another session of the same teacher, class or room are rejected with 409 and the list of conflicts; set force to create them anyway, in which case the conflicts are returned as a warning.
 // @Tags attendance-sessions
 // @Accept json
 // @Produce json
 // @Param session body models.CreateAttendanceSessionRequest true "Attendance session data"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success 201 {object} models.AttendanceSession
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /attendance-sessions [post]
-func CreateAttendanceSession(c *gin.Context) {
+func (c *AttendanceSessionController) CreateAttendanceSession(ctx *gin.Context) {
 	var req models.CreateAttendanceSessionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
@@ -104,18 +141,26 @@ func CreateAttendanceSession(c *gin.Context) {
 		}
 	}
 
-	// Parse session date
-	var sessionDate *time.Time
-	if req.SessionDate != nil && *req.SessionDate != "" {
-		if parsedTime, err := time.Parse(time.RFC3339, *req.SessionDate); err == nil {
-			sessionDate = &parsedTime
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
+	// Parse session date and check-in window
+	var sessionDate, opensAt, lateAfter, closesAt *time.Time
+	for _, field := range []struct {
+		value  *string
+		target **time.Time
+	}{
+		{req.SessionDate, &sessionDate},
+		{req.OpensAt, &opensAt},
+		{req.LateAfter, &lateAfter},
+		{req.ClosesAt, &closesAt},
+	} {
+		parsed, err := parseOptionalTime(field.value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
 				"error":   "Invalid session date format",
 				"message": "Please use RFC3339 format (2006-01-02T15:04:05Z07:00)",
 			})
 			return
 		}
+		*field.target = parsed
 	}
 
 	session := models.AttendanceSession{
@@ -123,19 +168,377 @@ func CreateAttendanceSession(c *gin.Context) {
 		ClassID:     req.ClassID,
 		TeacherID:   teacherID,
 		SessionDate: sessionDate,
+		RoomID:      req.RoomID,
+		Location:    req.Location,
+		OpensAt:     opensAt,
+		LateAfter:   lateAfter,
+		ClosesAt:    closesAt,
+
+		DurationMinutes:    req.DurationMinutes,
+		MinDurationMinutes: req.MinDurationMinutes,
+		Capacity:           req.Capacity,
 	}
 
-	if err := services.CreateAttendanceSession(&session); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create attendance session",
-			"message": err.Error(),
-		})
+	// Teachers can only create sessions they teach themselves
+	if ownTeacherID, scoped := teacherScope(ctx); scoped {
+		if session.TeacherID == nil {
+			session.TeacherID = &ownTeacherID
+		}
+		if !authorizeSession(ctx, &session) {
+			return
+		}
+	}
+
+	conflicts, err := c.sessionService.CreateAttendanceSession(&session, req.Force)
+	if err != nil {
+		var conflictErr *services.SessionConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":     "Session conflict",
+				"message":   services.ErrSessionConflict.Error() + "; set force to create it anyway",
+				"conflicts": conflictErr.Conflicts,
+			})
+		case errors.Is(err, services.ErrInvalidCheckinWindow):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid check-in window",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrRoomNotFound):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid room",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrInvalidDuration):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid duration",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to create attendance session",
+				"message": err.Error(),
+			})
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	response := gin.H{
 		"success": true,
 		"data":    session,
 		"message": "Attendance session created successfully",
+	}
+	if len(conflicts) > 0 {
+		response["conflicts"] = conflicts
+		response["message"] = "Attendance session created despite overlapping sessions"
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetAttendanceSessionConflicts godoc
+// @Summary Report overlapping attendance sessions
+// @Description List pairs of sessions that overlap between from and to and share a teacher, class or room. Dates may be RFC3339 timestamps or YYYY-MM-DD days, where to includes the whole day; the range is at most 92 days. Teachers only see conflicts involving their own sessions, listed first as session_id; other_session_id is left out when the other session belongs to another teacher.
+// @Tags attendance-sessions
+// @Produce json
+// @Param from query string true "Start of the range"
+// @Param to query string true "End of the range"
+// @Success 200 {array} models.SessionConflictPair
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendance-sessions/conflicts [get]
+func (c *AttendanceSessionController) GetAttendanceSessionConflicts(ctx *gin.Context) {
+	from, errFrom := parseRangeBound(ctx.Query("from"), false)
+	to, errTo := parseRangeBound(ctx.Query("to"), true)
+	if errFrom != nil || errTo != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid date range",
+			"message": "from and to are required, as RFC3339 timestamps or YYYY-MM-DD dates",
+		})
+		return
+	}
+	if !to.After(from) || to.Sub(from) > maxConflictRange {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid date range",
+			"message": "to must be after from and the range at most 92 days",
+		})
+		return
+	}
+
+	var teacherID *uint
+	if ownTeacherID, scoped := teacherScope(ctx); scoped {
+		teacherID = &ownTeacherID
+	}
+
+	conflicts, err := services.GetSessionConflictsReport(from, to, teacherID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch session conflicts",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    conflicts,
+		"count":   len(conflicts),
+	})
+}
+
+// GetAttendanceSessionCheckinToken godoc
+// @Summary Get the current check-in token of a session
+// @Description Get the rotating check-in token that attendees must submit with POST /attendances, and when it expires
+// @Tags attendance-sessions
+// @Produce json
+// @Param id path int true "Attendance Session ID"
+// @Success 200 {object} models.CheckinTokenResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendance-sessions/{id}/checkin-token [get]
+func (c *AttendanceSessionController) GetAttendanceSessionCheckinToken(ctx *gin.Context) {
+	session, ok := loadAuthorizedSession(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    services.SessionCheckinToken(session, time.Now()),
 	})
 }
+
+// GetAttendanceSessionQRCode godoc
+// @Summary Get the check-in QR code of a session
+// @Description Render the current check-in link as a QR code for the projector. The code rotates with the token, so clients should refresh it before expires_at.
+// @Tags attendance-sessions
+// @Produce png
+// @Produce image/svg+xml
+// @Param id path int true "Attendance Session ID"
+// @Param format query string false "Image format: png (default) or svg"
+// @Param size query int false "Image size in pixels (default 512)"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendance-sessions/{id}/qr [get]
+func (c *AttendanceSessionController) GetAttendanceSessionQRCode(ctx *gin.Context) {
+	size := 512
+	if sizeParam := ctx.Query("size"); sizeParam != "" {
+		parsed, err := strconv.Atoi(sizeParam)
+		if err != nil || parsed < 64 || parsed > 2048 {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid size",
+				"message": "Size must be a number between 64 and 2048",
+			})
+			return
+		}
+		size = parsed
+	}
+
+	format := ctx.DefaultQuery("format", "png")
+	if format != "png" && format != "svg" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid format",
+			"message": "Format must be 'png' or 'svg'",
+		})
+		return
+	}
+
+	session, ok := loadAuthorizedSession(ctx)
+	if !ok {
+		return
+	}
+
+	token := services.SessionCheckinToken(session, time.Now())
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("X-Checkin-Token-Expires-At", token.ExpiresAt.Format(time.RFC3339))
+
+	if format == "svg" {
+		svg, err := services.RenderQRCodeSVG(token.CheckinURL, size)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to render QR code",
+				"message": err.Error(),
+			})
+			return
+		}
+		ctx.Data(http.StatusOK, "image/svg+xml", []byte(svg))
+		return
+	}
+
+	png, err := services.RenderQRCodePNG(token.CheckinURL, size)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to render QR code",
+			"message": err.Error(),
+		})
+		return
+	}
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// GetAttendanceSessionRoster godoc
+// @Summary Get the class roster of a session
+// @Description List every student in the session's class as present, late, absent or excused, plus check-ins that could not be matched to a student
+// @Tags attendance-sessions
+// @Produce json
+// @Param id path int true "Attendance Session ID"
+// @Success 200 {object} models.SessionRoster
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendance-sessions/{id}/roster [get]
+func (c *AttendanceSessionController) GetAttendanceSessionRoster(ctx *gin.Context) {
+	session, ok := loadAuthorizedSession(ctx)
+	if !ok {
+		return
+	}
+
+	roster, err := c.rosterService.GetSessionRoster(session.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build roster",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    roster,
+	})
+}
+
+// MarkAttendanceSession godoc
+// @Summary Mark attendance for a session's class
+// @Description Mark students of the session's class as present, absent, excused or late in one transaction. Marking a student again updates their existing mark.
+// @Tags attendance-sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Attendance Session ID"
+// @Param marks body models.BulkMarkAttendanceRequest true "Marks"
+// @Success 200 {object} models.SessionRoster
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /attendance-sessions/{id}/marks [post]
+func (c *AttendanceSessionController) MarkAttendanceSession(ctx *gin.Context) {
+	var req models.BulkMarkAttendanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	session, ok := loadAuthorizedSession(ctx)
+	if !ok {
+		return
+	}
+
+	claims, _ := middleware.CurrentClaims(ctx)
+	marks, err := services.MarkAttendances(session.ID, &req, claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrStudentNotInClass), errors.Is(err, services.ErrSessionHasNoClass):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":   "Invalid marks",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to save marks",
+				"message": err.Error(),
+			})
+		}
+		return
+	}
+
+	roster, err := c.rosterService.GetSessionRoster(session.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build roster",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    roster,
+		"count":   len(marks),
+		"message": "Attendance marked successfully",
+	})
+}
+
+// loadAuthorizedSession loads the session in the :id param with its event,
+// writing an error response and returning false when it is missing or
+// belongs to another teacher
+func loadAuthorizedSession(ctx *gin.Context) (*models.AttendanceSession, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid attendance session ID",
+			"message": "Attendance session ID must be a number",
+		})
+		return nil, false
+	}
+
+	session, err := services.GetAttendanceSessionWithEvent(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance session not found",
+			"message": err.Error(),
+		})
+		return nil, false
+	}
+
+	if !authorizeSession(ctx, session) {
+		return nil, false
+	}
+
+	return session, true
+}
+
+// maxConflictRange bounds the date range of the conflicts report
+const maxConflictRange = 92 * 24 * time.Hour
+
+// parseRangeBound parses an RFC3339 timestamp or a YYYY-MM-DD date in the
+// default time zone; an end date covers the whole day
+func parseRangeBound(value string, end bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, config.DefaultLocation())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+// parseOptionalTime parses an optional RFC3339 timestamp; nil or empty values yield nil
+func parseOptionalTime(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
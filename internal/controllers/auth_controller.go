@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/middleware"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	authService interfaces.AuthServiceInterface
+}
+
+func NewAuthController(authService interfaces.AuthServiceInterface) *AuthController {
+	return &AuthController{
+		authService: authService,
+	}
+}
+
+// Login authenticates a user and issues tokens
+// @Summary Log in
+// @Description Exchange a username and password for an access token and a refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body models.LoginRequest true "Login credentials"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 401 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Router /auth/login [post]
+func (c *AuthController) Login(ctx *gin.Context) {
+	var req models.LoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	tokens, err := c.authService.Login(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Login failed",
+				"message": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to log in",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Logged in successfully",
+		"data":    tokens,
+	})
+}
+
+// RefreshToken issues a new token pair from a refresh token
+// @Summary Refresh tokens
+// @Description Exchange a valid refresh token for a new access token and refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body models.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 401 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Router /auth/refresh [post]
+func (c *AuthController) RefreshToken(ctx *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	tokens, err := c.authService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidToken) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Token refresh failed",
+				"message": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to refresh token",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Token refreshed successfully",
+		"data":    tokens,
+	})
+}
+
+// Me returns the currently authenticated user
+// @Summary Get current user
+// @Description Get the account the access token was issued to
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 401 {object} map[string]interface{} "error"
+// @Router /auth/me [get]
+func (c *AuthController) Me(ctx *gin.Context) {
+	claims, ok := middleware.CurrentClaims(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Unauthorized",
+			"message": "No authenticated user",
+		})
+		return
+	}
+
+	user, err := c.authService.GetUserByID(claims.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Unauthorized",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "User retrieved successfully",
+		"data":    user,
+	})
+}
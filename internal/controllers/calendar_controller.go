@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController struct {
+	calendarService interfaces.CalendarServiceInterface
+}
+
+func NewCalendarController(calendarService interfaces.CalendarServiceInterface) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// GetEventCalendarLink godoc
+// @Summary Get the calendar feed link of an event
+// @Description Get a subscribable .ics link with every session of the event. The link works without an access token, so share it only with the event's attendees.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {object} models.CalendarFeedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /events/{id}/calendar [get]
+func (c *CalendarController) GetEventCalendarLink(ctx *gin.Context) {
+	c.respondCalendarLink(ctx, services.CalendarScopeEvents)
+}
+
+// GetClassCalendarLink godoc
+// @Summary Get the calendar feed link of a class
+// @Description Get a subscribable .ics link with every session of the class. The link works without an access token.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "Class ID"
+// @Success 200 {object} models.CalendarFeedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /classes/{id}/calendar [get]
+func (c *CalendarController) GetClassCalendarLink(ctx *gin.Context) {
+	c.respondCalendarLink(ctx, services.CalendarScopeClasses)
+}
+
+// GetTeacherCalendarLink godoc
+// @Summary Get the calendar feed link of a teacher
+// @Description Get a subscribable .ics link with every session the teacher teaches. Teachers can only get their own link.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "Teacher ID"
+// @Success 200 {object} models.CalendarFeedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /teachers/{id}/calendar [get]
+func (c *CalendarController) GetTeacherCalendarLink(ctx *gin.Context) {
+	c.respondCalendarLink(ctx, services.CalendarScopeTeachers)
+}
+
+// GetCalendarFeed godoc
+// @Summary Get a calendar feed
+// @Description Get the sessions of an event, class or teacher as an iCalendar (.ics) feed for calendar apps. Requires the token of the feed link instead of an access token.
+// @Tags calendar
+// @Produce text/calendar
+// @Param scope path string true "Feed scope" Enums(events, classes, teachers)
+// @Param file path string true "ID followed by .ics, e.g. 1.ics"
+// @Param token query string true "Feed token from the feed link"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /calendar/{scope}/{file} [get]
+func (c *CalendarController) GetCalendarFeed(ctx *gin.Context) {
+	id, err := strconv.ParseUint(strings.TrimSuffix(ctx.Param("file"), ".ics"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Calendar not found",
+			"message": "Feed path must look like /calendar/events/1.ics",
+		})
+		return
+	}
+
+	calendar, err := c.calendarService.GetCalendarFeed(ctx.Param("scope"), uint(id), ctx.Query("token"))
+	if err != nil {
+		respondCalendarError(ctx, err, "Failed to build calendar feed")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+func (c *CalendarController) respondCalendarLink(ctx *gin.Context, scope string) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid ID",
+			"message": "ID must be a number",
+		})
+		return
+	}
+
+	if scope == services.CalendarScopeTeachers {
+		if teacherID, scoped := teacherScope(ctx); scoped && teacherID != uint(id) {
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": "Teachers can only subscribe to their own calendar",
+			})
+			return
+		}
+	}
+
+	link, err := c.calendarService.GetCalendarFeedLink(scope, uint(id))
+	if err != nil {
+		respondCalendarError(ctx, err, "Failed to create calendar feed link")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    link,
+	})
+}
+
+func respondCalendarError(ctx *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrCalendarNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Calendar not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidCalendarToken):
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":   "Invalid calendar token",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   failure,
+			"message": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+	"net/url"
+	"strconv"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CertificateController struct {
+	certificateService interfaces.CertificateServiceInterface
+}
+
+func NewCertificateController(certificateService interfaces.CertificateServiceInterface) *CertificateController {
+	return &CertificateController{
+		certificateService: certificateService,
+	}
+}
+
+// GetAttendanceCertificate renders the certificate of one attendee
+// @Summary Download an attendee's certificate
+// @Description Render the certificate of attendance of the person behind an attendance as PDF. The certificate covers all their sessions of the event and keeps its verification code when downloaded again.
+// @Tags certificates
+// @Produce application/pdf
+// @Param id path int true "Attendance ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 422 {object} map[string]interface{} "Not eligible"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /attendances/{id}/certificate [get]
+func (c *CertificateController) GetAttendanceCertificate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid attendance ID",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	certificate, pdf, err := c.certificateService.GetAttendanceCertificate(uint(id))
+	if err != nil {
+		respondCertificateError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", attachmentDisposition(services.CertificateFileName(certificate)))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetEventCertificates renders the certificates of all eligible attendees
+// @Summary Download all certificates of an event
+// @Description Render the certificates of every eligible attendee of an event as a ZIP of PDFs. Attendees who left a session early or did not reach the event's certificate_min_hours are left out.
+// @Tags certificates
+// @Produce application/zip
+// @Param id path int true "Event ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /events/{id}/certificates [get]
+func (c *CertificateController) GetEventCertificates(ctx *gin.Context) {
+	eventID, ok := parseEventID(ctx)
+	if !ok {
+		return
+	}
+
+	archive, count, err := c.certificateService.GetEventCertificates(eventID)
+	if err != nil {
+		respondCertificateError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%d-certificates.zip"`, eventID))
+	ctx.Header("X-Certificate-Count", strconv.Itoa(count))
+	ctx.Data(http.StatusOK, "application/zip", archive)
+}
+
+// VerifyCertificate checks a certificate's verification code
+// @Summary Verify a certificate
+// @Description Check that a certificate of attendance is genuine using the code printed on it
+// @Tags certificates
+// @Produce json
+// @Param code path string true "Verification code"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Router /certificates/verify/{code} [get]
+func (c *CertificateController) VerifyCertificate(ctx *gin.Context) {
+	certificate, err := c.certificateService.VerifyCertificate(ctx.Param("code"))
+	if err != nil {
+		respondCertificateError(ctx, err)
+		return
+	}
+
+	verification := models.CertificateVerification{
+		Code:          certificate.Code,
+		RecipientName: certificate.RecipientName,
+		Dates:         certificate.Dates,
+		Hours:         certificate.Hours,
+		IssuedAt:      certificate.IssuedAt,
+	}
+	if certificate.Event != nil && certificate.Event.EventName != nil {
+		verification.EventName = *certificate.Event.EventName
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Certificate is valid",
+		"data":    verification,
+	})
+}
+
+func respondCertificateError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAttendanceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrEventNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Event not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrCertificateNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Certificate not found",
+			"message": "No certificate has this verification code",
+		})
+	case errors.Is(err, services.ErrNoEligibleAttendees):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "No certificates",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotEligibleForCertificate):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "Not eligible for a certificate",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to generate certificate",
+			"message": err.Error(),
+		})
+	}
+}
+
+// attachmentDisposition builds a Content-Disposition header, escaping
+// non-ASCII file names (such as Vietnamese recipient names) as in RFC 6266
+func attachmentDisposition(filename string) string {
+	for _, r := range filename {
+		if r > unicode.MaxASCII || r == '"' {
+			return "attachment; filename*=UTF-8''" + url.PathEscape(filename)
+		}
+	}
+	return fmt.Sprintf(`attachment; filename="%s"`, filename)
+}
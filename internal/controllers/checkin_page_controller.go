@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkinPage is the data rendered by the check-in page templates
+type checkinPage struct {
+	Title       string
+	EventName   string
+	ClassName   string
+	SessionDate string
+	SessionID   uint
+	Ticket      string
+	Fields      models.FormSchema // Custom form fields of the event
+	Form        models.CreateAttendanceRequest
+	Error       string
+	FieldErrors map[string]string
+	Retry       bool // The link expired; the attendee has to scan the QR code again
+	Attendance  *models.Attendance
+	Duplicate   bool
+}
+
+type CheckinPageController struct {
+	checkInService interfaces.CheckInServiceInterface
+}
+
+func NewCheckinPageController(checkInService interfaces.CheckInServiceInterface) *CheckinPageController {
+	return &CheckinPageController{
+		checkInService: checkInService,
+	}
+}
+
+// ShowCheckinPage renders the self check-in form opened from a session's QR code.
+// The rotating token in the link is exchanged for a single-use form ticket so
+// that the form can still be submitted after the QR code has rotated.
+func (c *CheckinPageController) ShowCheckinPage(ctx *gin.Context) {
+	session, page, ok := loadCheckinPage(ctx)
+	if !ok {
+		return
+	}
+
+	ticket, err := services.OpenCheckinForm(session, ctx.Query("token"), time.Now())
+	if err != nil {
+		renderCheckinError(ctx, page, err)
+		return
+	}
+
+	page.Ticket = ticket
+	ctx.HTML(http.StatusOK, "checkin_form.html", page)
+}
+
+// SubmitCheckinPage checks the attendee in from the submitted form and renders
+// the confirmation, or the form again with the entered values when it is invalid
+func (c *CheckinPageController) SubmitCheckinPage(ctx *gin.Context) {
+	session, page, ok := loadCheckinPage(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAttendanceRequest
+	err := ctx.ShouldBind(&req)
+	req.SessionID = session.ID
+	req.Answers = formAnswersFromPost(ctx, page.Fields)
+	if err != nil {
+		page.Ticket = req.CheckinToken
+		page.Form = req
+		page.Error = "Please fill in all required fields."
+		ctx.HTML(http.StatusBadRequest, "checkin_form.html", page)
+		return
+	}
+
+	attendance, duplicate, err := c.checkInService.CheckInFromPage(&req)
+	if err != nil {
+		var formErr *services.FormValidationError
+		if errors.As(err, &formErr) {
+			page.Ticket = req.CheckinToken
+			page.Form = req
+			page.Error = "Please check the highlighted fields."
+			page.FieldErrors = formErr.Fields
+			ctx.HTML(http.StatusBadRequest, "checkin_form.html", page)
+			return
+		}
+		renderCheckinError(ctx, page, err)
+		return
+	}
+
+	page.Title = "Checked in"
+	page.Attendance = attendance
+	page.Duplicate = duplicate
+	status := http.StatusCreated
+	if duplicate {
+		status = http.StatusOK
+	}
+	ctx.HTML(status, "checkin_result.html", page)
+}
+
+// loadCheckinPage loads the session named in the URL and fills in its header
+func loadCheckinPage(ctx *gin.Context) (*models.AttendanceSession, *checkinPage, bool) {
+	page := &checkinPage{Title: "Check in"}
+
+	id, err := strconv.ParseUint(ctx.Param("sessionId"), 10, 32)
+	if err != nil {
+		renderCheckinError(ctx, page, services.ErrSessionNotFound)
+		return nil, nil, false
+	}
+
+	session, err := services.GetCheckinPageSession(uint(id))
+	if err != nil {
+		renderCheckinError(ctx, page, err)
+		return nil, nil, false
+	}
+
+	page.SessionID = session.ID
+	if session.Event != nil {
+		page.Fields = session.Event.FormSchema
+		if session.Event.EventName != nil {
+			page.EventName = *session.Event.EventName
+			page.Title = *session.Event.EventName
+		}
+	}
+	if session.Class != nil && session.Class.ClassName != nil {
+		page.ClassName = *session.Class.ClassName
+	}
+	if session.SessionDate != nil {
+		page.SessionDate = session.SessionDate.Local().Format("02/01/2006")
+	}
+	return session, page, true
+}
+
+func renderCheckinError(ctx *gin.Context, page *checkinPage, err error) {
+	page.Title = "Check-in failed"
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrSessionNotFound):
+		status = http.StatusNotFound
+		page.Error = "This check-in link does not match any session."
+	case errors.Is(err, services.ErrInvalidCheckinToken), errors.Is(err, services.ErrExpiredCheckinToken):
+		status = http.StatusForbidden
+		page.Error = "This check-in link has expired."
+		page.Retry = true
+	case errors.Is(err, services.ErrCheckinTicketUsed):
+		status = http.StatusForbidden
+		page.Error = "This check-in form was already used."
+		page.Retry = true
+	case errors.Is(err, services.ErrSessionNotOpen):
+		status = http.StatusUnprocessableEntity
+		page.Error = "Check-in for this session has not opened yet."
+	case errors.Is(err, services.ErrSessionClosed):
+		status = http.StatusUnprocessableEntity
+		page.Error = "Check-in for this session has closed."
+	case errors.Is(err, services.ErrEventNotOngoing):
+		status = http.StatusUnprocessableEntity
+		page.Error = "This event is not open for check-in."
+	case errors.Is(err, services.ErrNotRegistered):
+		status = http.StatusForbidden
+		page.Error = "This event requires registration before check-in."
+	case errors.Is(err, services.ErrRegistrationWaitlisted):
+		status = http.StatusForbidden
+		page.Error = "Your registration is still on the waitlist."
+	case errors.Is(err, services.ErrSessionFull):
+		status = http.StatusConflict
+		page.Error = "This session is full."
+	default:
+		page.Error = "Something went wrong, please try again."
+	}
+	ctx.HTML(status, "checkin_result.html", page)
+}
+
+// formAnswersFromPost reads the custom field answers posted as "answers.<key>";
+// multi-selects post one value per ticked choice
+func formAnswersFromPost(ctx *gin.Context, fields models.FormSchema) models.FormAnswers {
+	if len(fields) == 0 {
+		return nil
+	}
+	answers := make(models.FormAnswers)
+	for _, field := range fields {
+		values, posted := ctx.GetPostFormArray("answers." + field.Key)
+		if !posted {
+			continue
+		}
+		if field.Type == models.FormFieldMultiSelect {
+			answers[field.Key] = values
+		} else {
+			answers[field.Key] = values[0]
+		}
+	}
+	return answers
+}
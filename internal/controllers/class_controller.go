@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+	"hello-gin/internal/interfaces"
 	"hello-gin/internal/models"
 	"hello-gin/internal/services"
 	"net/http"
@@ -9,6 +11,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type ClassController struct {
+	classService interfaces.ClassServiceInterface
+}
+
+func NewClassController(classService interfaces.ClassServiceInterface) *ClassController {
+	return &ClassController{
+		classService: classService,
+	}
+}
+
 // GetClasses godoc
 // @Summary Get all classes
 // @Description Get all classes from the database
@@ -16,18 +28,19 @@ import (
 // @Produce json
 // @Success 200 {array} models.Class
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /classes [get]
-func GetClasses(c *gin.Context) {
-	classes, err := services.GetClasses()
+func (c *ClassController) GetClasses(ctx *gin.Context) {
+	classes, err := c.classService.GetClasses()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch classes",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    classes,
 		"count":   len(classes),
@@ -44,28 +57,21 @@ func GetClasses(c *gin.Context) {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /classes/{id} [get]
-func GetClassByID(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid class ID",
-			"message": "Class ID must be a number",
-		})
+func (c *ClassController) GetClassByID(ctx *gin.Context) {
+	id, ok := parseClassID(ctx)
+	if !ok {
 		return
 	}
 
-	class, err := services.GetClassByID(id)
+	class, err := c.classService.GetClassByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Class not found",
-			"message": err.Error(),
-		})
+		respondClassError(ctx, err, "Failed to fetch class")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    class,
 	})
@@ -73,42 +79,163 @@ func GetClassByID(c *gin.Context) {
 
 // CreateClass godoc
 // @Summary Create a new class
-// @Description Create a new class in the database
+// @Description Create a new class in the database. The class code must not be used by another class.
 // @Tags classes
 // @Accept json
 // @Produce json
 // @Param class body models.CreateClassRequest true "Class data"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success 201 {object} models.Class
 // @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /classes [post]
-func CreateClass(c *gin.Context) {
+func (c *ClassController) CreateClass(ctx *gin.Context) {
 	var request models.CreateClassRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	// Create class from DTO
-	class := models.Class{
-		ClassCode: &request.ClassCode,
-		ClassName: &request.ClassName,
+	class, err := c.classService.CreateClass(&request)
+	if err != nil {
+		respondClassError(ctx, err, "Failed to create class")
+		return
 	}
 
-	if err := services.CreateClass(&class); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create class",
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    class,
+		"message": "Class created successfully",
+	})
+}
+
+// UpdateClass godoc
+// @Summary Update a class
+// @Description Change the code or name of a class; omitted fields are kept
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path int true "Class ID"
+// @Param class body models.UpdateClassRequest true "Fields to change"
+// @Success 200 {object} models.Class
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /classes/{id} [patch]
+func (c *ClassController) UpdateClass(ctx *gin.Context) {
+	id, ok := parseClassID(ctx)
+	if !ok {
+		return
+	}
+
+	var request models.UpdateClassRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	class, err := c.classService.UpdateClass(id, &request)
+	if err != nil {
+		respondClassError(ctx, err, "Failed to update class")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    class,
-		"message": "Class created successfully",
+		"message": "Class updated successfully",
 	})
 }
+
+// DeleteClass godoc
+// @Summary Delete a class
+// @Description Delete a class together with its enrollments. A class that still has students is only deleted with cascade=true, which deletes its students too; otherwise the request fails with 409. Students whose main class is another one stay, no longer enrolled in this class. Restoring the class from the trash brings its enrollments and students back. Sessions keep their class ID.
+// @Tags classes
+// @Produce json
+// @Param id path int true "Class ID"
+// @Param cascade query bool false "Also delete the students of the class"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /classes/{id} [delete]
+func (c *ClassController) DeleteClass(ctx *gin.Context) {
+	id, ok := parseClassID(ctx)
+	if !ok {
+		return
+	}
+
+	cascade := false
+	if cascadeParam := ctx.Query("cascade"); cascadeParam != "" {
+		parsed, err := strconv.ParseBool(cascadeParam)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid cascade",
+				"message": "cascade must be true or false",
+			})
+			return
+		}
+		cascade = parsed
+	}
+
+	removed, err := c.classService.DeleteClass(id, cascade)
+	if err != nil {
+		respondClassError(ctx, err, "Failed to delete class")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"deleted_students": removed,
+		"message":          "Class deleted successfully",
+	})
+}
+
+func parseClassID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid class ID",
+			"message": "Class ID must be a number",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondClassError(ctx *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrClassNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Class not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrClassCodeTaken):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Class code taken",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrClassHasStudents):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Class has students",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   failure,
+			"message": err.Error(),
+		})
+	}
+}
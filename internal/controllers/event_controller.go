@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"hello-gin/internal/interfaces"
 	"hello-gin/internal/models"
+	"hello-gin/internal/services"
 	"net/http"
 	"strconv"
 
@@ -27,6 +29,7 @@ func NewEventController(eventService interfaces.EventServiceInterface) *EventCon
 // @Produce json
 // @Success 200 {object} map[string]interface{} "success"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events [get]
 func (c *EventController) GetEvents(ctx *gin.Context) {
 	events, err := c.eventService.GetAllEvents()
@@ -56,6 +59,7 @@ func (c *EventController) GetEvents(ctx *gin.Context) {
 // @Failure 400 {object} map[string]interface{} "error"
 // @Failure 404 {object} map[string]interface{} "error"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events/{id} [get]
 func (c *EventController) GetEventByID(ctx *gin.Context) {
 	idParam := ctx.Param("id")
@@ -85,7 +89,7 @@ func (c *EventController) GetEventByID(ctx *gin.Context) {
 
 // GetEventWithSessions retrieves an event by ID with its sessions
 // @Summary Get event with sessions
-// @Description Get a single event by its ID including all attendance sessions
+// @Description Get a single event by its ID including its attendance sessions; teachers only get the sessions they teach
 // @Tags events
 // @Accept json
 // @Produce json
@@ -94,6 +98,7 @@ func (c *EventController) GetEventByID(ctx *gin.Context) {
 // @Failure 400 {object} map[string]interface{} "error"
 // @Failure 404 {object} map[string]interface{} "error"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events/{id}/sessions [get]
 func (c *EventController) GetEventWithSessions(ctx *gin.Context) {
 	idParam := ctx.Param("id")
@@ -115,6 +120,18 @@ func (c *EventController) GetEventWithSessions(ctx *gin.Context) {
 		return
 	}
 
+	// Teachers only see the sessions they teach
+	if teacherID, scoped := teacherScope(ctx); scoped {
+		own := make([]models.AttendanceSession, 0, len(event.Sessions))
+		for _, session := range event.Sessions {
+			if session.TeacherID != nil && *session.TeacherID == teacherID {
+				own = append(own, session)
+			}
+		}
+		event.Sessions = own
+	}
+	hideAttendees(ctx, event.Sessions)
+
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "Event with sessions retrieved successfully",
 		"data":    event,
@@ -127,10 +144,13 @@ func (c *EventController) GetEventWithSessions(ctx *gin.Context) {
 // @Tags events
 // @Accept json
 // @Produce json
-// @Param event body models.CreateEventRequest true "Event data"
+// @Param event body models.CreateEventRequest true "Event data. form_schema defines custom check-in form fields (types: text, textarea, number, email, phone, date, checkbox, select, multiselect)"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success 201 {object} map[string]interface{} "success"
 // @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events [post]
 func (c *EventController) CreateEvent(ctx *gin.Context) {
 	var req models.CreateEventRequest
@@ -144,6 +164,9 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 
 	event, err := c.eventService.CreateEvent(&req)
 	if err != nil {
+		if respondInvalidEvent(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to create event",
 			"message": err.Error(),
@@ -157,6 +180,84 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 	})
 }
 
+// CloneEvent copies an event and its sessions to new dates
+// @Summary Clone an event
+// @Description Copy an event and its sessions as a new draft event. Give either start_date, to move the event's start (or first session) there, or offset_days, to move every date by that many days keeping its time of day. Sessions keep their class, teacher and location; attendances and registrations are not copied. Moved sessions that overlap another session of the same teacher, class or room are rejected with 409 and the list of conflicts; set force to clone anyway, in which case the conflicts are returned as a warning.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param clone body models.CloneEventRequest true "New dates"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success 201 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /events/{id}/clone [post]
+func (c *EventController) CloneEvent(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event ID",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var req models.CloneEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	event, conflicts, err := c.eventService.CloneEvent(uint(id), &req)
+	if err != nil {
+		var conflictErr *services.SessionConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":     "Session conflict",
+				"message":   services.ErrSessionConflict.Error() + "; set force to clone anyway",
+				"conflicts": conflictErr.Conflicts,
+			})
+		case errors.Is(err, services.ErrEventNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Event not found",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrInvalidCloneRequest):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid clone request",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to clone event",
+				"message": err.Error(),
+			})
+		}
+		return
+	}
+
+	response := gin.H{
+		"message": "Event cloned successfully",
+		"data":    event,
+		"count":   len(event.Sessions),
+	}
+	if len(conflicts) > 0 {
+		response["conflicts"] = conflicts
+		response["message"] = "Event cloned despite overlapping sessions"
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
 // UpdateEvent updates an existing event
 // @Summary Update an event
 // @Description Update an existing event with the provided information
@@ -167,8 +268,10 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 // @Param event body models.CreateEventRequest true "Event data"
 // @Success 200 {object} map[string]interface{} "success"
 // @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
 // @Failure 404 {object} map[string]interface{} "error"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events/{id} [put]
 func (c *EventController) UpdateEvent(ctx *gin.Context) {
 	idParam := ctx.Param("id")
@@ -192,6 +295,9 @@ func (c *EventController) UpdateEvent(ctx *gin.Context) {
 
 	event, err := c.eventService.UpdateEvent(uint(id), &req)
 	if err != nil {
+		if respondInvalidEvent(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to update event",
 			"message": err.Error(),
@@ -207,14 +313,16 @@ func (c *EventController) UpdateEvent(ctx *gin.Context) {
 
 // DeleteEvent deletes an event
 // @Summary Delete an event
-// @Description Delete an event by its ID
+// @Description Move an event to the trash together with its session series, sessions, their attendances, its registrations and its certificates, which stop verifying until it is restored; POST /trash/events/{id}/restore brings them back
 // @Tags events
 // @Accept json
 // @Produce json
 // @Param id path int true "Event ID"
 // @Success 200 {object} map[string]interface{} "success"
 // @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events/{id} [delete]
 func (c *EventController) DeleteEvent(ctx *gin.Context) {
 	idParam := ctx.Param("id")
@@ -249,6 +357,7 @@ func (c *EventController) DeleteEvent(ctx *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{} "success"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
 // @Router /events/active [get]
 func (c *EventController) GetActiveEvents(ctx *gin.Context) {
 	events, err := c.eventService.GetActiveEvents()
@@ -267,18 +376,116 @@ func (c *EventController) GetActiveEvents(ctx *gin.Context) {
 	})
 }
 
-// ToggleEventActive sets the active status of an event
+// SetEventStatus moves an event through its lifecycle
+// @Summary Change event status
+// @Description Move an event to another status. Allowed transitions: draft -> published|archived, published -> draft|ongoing|closed, ongoing -> closed, closed -> ongoing|archived. Attendees can only check in while the event is ongoing.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param status body models.UpdateEventStatusRequest true "New status"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /events/{id}/status [put]
+func (c *EventController) SetEventStatus(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event ID",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var req models.UpdateEventStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	event, err := c.eventService.SetEventStatus(uint(id), req.Status)
+	if err != nil {
+		respondEventStatusError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Event is now " + event.Status,
+		"data":    event,
+	})
+}
+
+// GetEventStatusLog retrieves the status changes of an event
+// @Summary Get event status history
+// @Description Get every status change of an event, oldest first, including the changes made automatically at its start and end dates
+// @Tags events
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {array} models.EventStatusLog
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /events/{id}/status-log [get]
+func (c *EventController) GetEventStatusLog(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event ID",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	logs, err := c.eventService.GetEventStatusLog(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Event not found",
+				"message": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve event status log",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Event status log retrieved successfully",
+		"data":    logs,
+		"count":   len(logs),
+	})
+}
+
+// EventActive sets an event ongoing or closed
 // @Summary Set event active status
-// @Description Set the active status of an event by its ID (1 = active, 0 = inactive)
+// @Description Deprecated, use PUT /events/{id}/status. 1 moves the event to ongoing, publishing a draft first; 0 closes a published or ongoing event and leaves other events as they are. Archived events cannot become active (409).
 // @Tags events
 // @Accept json
 // @Produce json
 // @Param id path int true "Event ID"
-// @Param active body object{active=int} true "Active status (1 = active, 0 = inactive)"
+// @Param active body object{active=int} true "Active status (1 = ongoing, 0 = closed)"
 // @Success 200 {object} map[string]interface{} "success"
 // @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
 // @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
 // @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Deprecated
 // @Router /events/{id}/active [put]
 func (c *EventController) EventActive(ctx *gin.Context) {
 	idParam := ctx.Param("id")
@@ -322,25 +529,64 @@ func (c *EventController) EventActive(ctx *gin.Context) {
 		return
 	}
 
-	// Convert to boolean
-	isActive := *request.Active == 1
-
-	event, err := c.eventService.SetEventActive(uint(id), isActive)
+	event, err := c.eventService.SetEventActive(uint(id), *request.Active == 1)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{
-			"error":   "Event not found or failed to update active status",
-			"message": err.Error(),
-		})
+		respondEventStatusError(ctx, err)
 		return
 	}
 
-	status := "deactivated"
-	if event.IsActive != nil && *event.IsActive {
-		status = "activated"
-	}
-
 	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Event " + status + " successfully",
+		"message": "Event is now " + event.Status,
 		"data":    event,
 	})
 }
+
+// respondEventStatusError maps status change errors to HTTP responses
+func respondEventStatusError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrEventNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Event not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidEventStatus):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event status",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidEventTransition):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Status change not allowed",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update event status",
+			"message": err.Error(),
+		})
+	}
+}
+
+// respondInvalidEvent answers 400 for event settings rejected by the service
+func respondInvalidEvent(ctx *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrInvalidFormSchema):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid form schema",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidCertificateTemplate):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid certificate template",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidEventDates):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event dates",
+			"message": err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,403 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExcuseController struct {
+	excuseService interfaces.ExcuseServiceInterface
+}
+
+func NewExcuseController(excuseService interfaces.ExcuseServiceInterface) *ExcuseController {
+	return &ExcuseController{
+		excuseService: excuseService,
+	}
+}
+
+// GetExcuses retrieves excuse requests
+// @Summary Get excuse requests
+// @Description Students see their own requests, teachers see requests for their sessions
+// @Tags excuses
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status" Enums(pending, approved, rejected)
+// @Param session_id query int false "Filter by attendance session"
+// @Param student_id query int false "Filter by student"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses [get]
+func (c *ExcuseController) GetExcuses(ctx *gin.Context) {
+	filter := models.ExcuseFilter{Status: ctx.Query("status")}
+	switch filter.Status {
+	case "", models.ExcuseStatusPending, models.ExcuseStatusApproved, models.ExcuseStatusRejected:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid status",
+			"message": "Status must be one of pending, approved, rejected",
+		})
+		return
+	}
+
+	for param, target := range map[string]**uint{"session_id": &filter.SessionID, "student_id": &filter.StudentID} {
+		value := ctx.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid " + param,
+				"message": err.Error(),
+			})
+			return
+		}
+		idUint := uint(id)
+		*target = &idUint
+	}
+
+	if studentID, scoped := studentScope(ctx); scoped {
+		filter.StudentID = &studentID
+	}
+	if teacherID, scoped := teacherScope(ctx); scoped {
+		filter.TeacherID = &teacherID
+	}
+
+	excuses, err := c.excuseService.GetExcuses(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve excuse requests",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Excuse requests retrieved successfully",
+		"data":    excuses,
+		"count":   len(excuses),
+	})
+}
+
+// GetExcuseByID retrieves an excuse request by ID
+// @Summary Get excuse request by ID
+// @Description Get a single excuse request including its history
+// @Tags excuses
+// @Accept json
+// @Produce json
+// @Param id path int true "Excuse request ID"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses/{id} [get]
+func (c *ExcuseController) GetExcuseByID(ctx *gin.Context) {
+	excuse, ok := c.loadAuthorizedExcuse(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Excuse request retrieved successfully",
+		"data":    excuse,
+	})
+}
+
+// GetExcuseHistory retrieves the history of an excuse request
+// @Summary Get excuse request history
+// @Description Get every submission and review of an excuse request, oldest first
+// @Tags excuses
+// @Accept json
+// @Produce json
+// @Param id path int true "Excuse request ID"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses/{id}/history [get]
+func (c *ExcuseController) GetExcuseHistory(ctx *gin.Context) {
+	excuse, ok := c.loadAuthorizedExcuse(ctx)
+	if !ok {
+		return
+	}
+
+	history, err := c.excuseService.GetExcuseHistory(excuse.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve excuse request history",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Excuse request history retrieved successfully",
+		"data":    history,
+		"count":   len(history),
+	})
+}
+
+// CreateExcuse submits an excuse request
+// @Summary Submit an excuse request
+// @Description Explain an absence from an attendance session. Student accounts submit for themselves; managers must pass student_id.
+// @Tags excuses
+// @Accept multipart/form-data
+// @Produce json
+// @Param session_id formData int true "Attendance session ID"
+// @Param student_id formData int false "Student ID (ignored for student accounts)"
+// @Param reason formData string true "Reason for the absence"
+// @Param attachment formData file false "Supporting document (PDF, JPG or PNG, max 5 MB)"
+// @Success 201 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 422 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses [post]
+func (c *ExcuseController) CreateExcuse(ctx *gin.Context) {
+	var req models.CreateExcuseRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if studentID, scoped := studentScope(ctx); scoped {
+		req.StudentID = studentID
+	}
+	if req.StudentID == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": "student_id is required",
+		})
+		return
+	}
+
+	attachment, err := ctx.FormFile("attachment")
+	if err != nil {
+		if !errors.Is(err, http.ErrMissingFile) && !errors.Is(err, http.ErrNotMultipart) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid attachment",
+				"message": err.Error(),
+			})
+			return
+		}
+		attachment = nil
+	}
+
+	excuse, err := c.excuseService.SubmitExcuse(&req, attachment, currentUserID(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSessionNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Attendance session not found",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrStudentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Student not found",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrExcuseAlreadyExists):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":   "Excuse request already exists",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrStudentNotInClass), errors.Is(err, services.ErrInvalidAttachment):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":   "Invalid excuse request",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to submit excuse request",
+				"message": err.Error(),
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Excuse request submitted successfully",
+		"data":    excuse,
+	})
+}
+
+// ApproveExcuse approves a pending excuse request
+// @Summary Approve an excuse request
+// @Description Approve a pending excuse request; the student is shown as excused on the session roster. Teachers can only review requests for their own sessions.
+// @Tags excuses
+// @Accept json
+// @Produce json
+// @Param id path int true "Excuse request ID"
+// @Param review body models.ReviewExcuseRequest false "Review note"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses/{id}/approve [put]
+func (c *ExcuseController) ApproveExcuse(ctx *gin.Context) {
+	c.reviewExcuse(ctx, true)
+}
+
+// RejectExcuse rejects a pending excuse request
+// @Summary Reject an excuse request
+// @Description Reject a pending excuse request. Teachers can only review requests for their own sessions.
+// @Tags excuses
+// @Accept json
+// @Produce json
+// @Param id path int true "Excuse request ID"
+// @Param review body models.ReviewExcuseRequest false "Review note"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses/{id}/reject [put]
+func (c *ExcuseController) RejectExcuse(ctx *gin.Context) {
+	c.reviewExcuse(ctx, false)
+}
+
+// DownloadExcuseAttachment downloads the attachment of an excuse request
+// @Summary Download excuse attachment
+// @Description Download the supporting document of an excuse request
+// @Tags excuses
+// @Produce octet-stream
+// @Param id path int true "Excuse request ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /excuses/{id}/attachment [get]
+func (c *ExcuseController) DownloadExcuseAttachment(ctx *gin.Context) {
+	excuse, ok := c.loadAuthorizedExcuse(ctx)
+	if !ok {
+		return
+	}
+
+	if excuse.AttachmentPath == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attachment not found",
+			"message": "This excuse request has no attachment",
+		})
+		return
+	}
+
+	name := "attachment"
+	if excuse.AttachmentName != nil {
+		name = *excuse.AttachmentName
+	}
+	ctx.FileAttachment(*excuse.AttachmentPath, name)
+}
+
+func (c *ExcuseController) reviewExcuse(ctx *gin.Context, approve bool) {
+	excuse, ok := c.loadAuthorizedExcuse(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewExcuseRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request data",
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+
+	excuse, err := c.excuseService.ReviewExcuse(excuse.ID, approve, &req, currentUserID(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrExcuseNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Excuse request not found",
+				"message": err.Error(),
+			})
+		case errors.Is(err, services.ErrExcuseAlreadyReviewed):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":   "Excuse request already reviewed",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to review excuse request",
+				"message": err.Error(),
+			})
+		}
+		return
+	}
+
+	message := "Excuse request rejected"
+	if approve {
+		message = "Excuse request approved"
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    excuse,
+	})
+}
+
+// loadAuthorizedExcuse loads the excuse request from the :id parameter and
+// writes an error response unless the current user may access it: students
+// only their own requests, teachers only requests for their own sessions
+func (c *ExcuseController) loadAuthorizedExcuse(ctx *gin.Context) (*models.ExcuseRequest, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid excuse request ID",
+			"message": err.Error(),
+		})
+		return nil, false
+	}
+
+	excuse, err := c.excuseService.GetExcuseByID(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrExcuseNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Excuse request not found",
+				"message": err.Error(),
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve excuse request",
+				"message": err.Error(),
+			})
+		}
+		return nil, false
+	}
+
+	if studentID, scoped := studentScope(ctx); scoped && excuse.StudentID != studentID {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": "Students can only access their own excuse requests",
+		})
+		return nil, false
+	}
+	if !authorizeSession(ctx, excuse.Session) {
+		return nil, false
+	}
+
+	return excuse, true
+}
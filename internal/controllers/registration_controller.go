@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RegistrationController struct {
+	registrationService interfaces.RegistrationServiceInterface
+}
+
+func NewRegistrationController(registrationService interfaces.RegistrationServiceInterface) *RegistrationController {
+	return &RegistrationController{
+		registrationService: registrationService,
+	}
+}
+
+// Register registers a person for an event
+// @Summary Register for an event
+// @Description Register for an event, or for one of its sessions when session_id is set. Only published and ongoing events take registrations; others fail with 409. Registrations beyond the capacity are waitlisted and promoted automatically when someone cancels. Keep the returned cancel_token to cancel later.
+// @Tags registrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param registration body models.CreateRegistrationRequest true "Registration data"
+// @Success 201 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Router /events/{id}/registrations [post]
+func (c *RegistrationController) Register(ctx *gin.Context) {
+	eventID, ok := parseEventID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateRegistrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	registration, err := c.registrationService.Register(eventID, &req)
+	if err != nil {
+		respondRegistrationError(ctx, err, "Failed to register")
+		return
+	}
+
+	message := "Registered successfully"
+	if registration.Status == models.RegistrationStatusWaitlisted {
+		message = "Event is full, added to the waitlist"
+	}
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message":      message,
+		"data":         registration,
+		"cancel_token": registration.CancelToken,
+	})
+}
+
+// CancelRegistration cancels a registration with its cancel token
+// @Summary Cancel a registration
+// @Description Cancel a registration with the token received when registering. The freed place goes to the next person on the waitlist; promoted is the number of waitlisted registrations that got a place.
+// @Tags registrations
+// @Accept json
+// @Produce json
+// @Param cancel body models.CancelRegistrationRequest true "Cancel token"
+// @Success 200 {object} map[string]interface{} "success: message, data (the cancelled registration), promoted (count)"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Router /registrations/cancel [post]
+func (c *RegistrationController) CancelRegistration(ctx *gin.Context) {
+	var req models.CancelRegistrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	registration, promoted, err := c.registrationService.CancelRegistration(req.CancelToken)
+	if err != nil {
+		respondRegistrationError(ctx, err, "Failed to cancel registration")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":  "Registration cancelled successfully",
+		"data":     registration,
+		"promoted": len(promoted),
+	})
+}
+
+// DeleteRegistration cancels a registration on behalf of the registrant
+// @Summary Cancel a registration by ID
+// @Description Cancel a registration as an organizer. The freed place goes to the next person on the waitlist; promoted is the number of waitlisted registrations that got a place.
+// @Tags registrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Registration ID"
+// @Success 200 {object} map[string]interface{} "success: message, data (the cancelled registration), promoted (count)"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /registrations/{id} [delete]
+func (c *RegistrationController) DeleteRegistration(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid registration ID",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	registration, promoted, err := c.registrationService.CancelRegistrationByID(uint(id))
+	if err != nil {
+		respondRegistrationError(ctx, err, "Failed to cancel registration")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":  "Registration cancelled successfully",
+		"data":     registration,
+		"promoted": len(promoted),
+	})
+}
+
+// GetRegistrations lists an event's registrations
+// @Summary Get event registrations
+// @Description Get the registrations of an event in registration order
+// @Tags registrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param session_id query int false "Only registrations for this session"
+// @Param status query string false "Filter by status" Enums(registered, waitlisted, cancelled)
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /events/{id}/registrations [get]
+func (c *RegistrationController) GetRegistrations(ctx *gin.Context) {
+	eventID, ok := parseEventID(ctx)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseOptionalSessionID(ctx)
+	if !ok {
+		return
+	}
+
+	status := ctx.Query("status")
+	switch status {
+	case "", models.RegistrationStatusRegistered, models.RegistrationStatusWaitlisted, models.RegistrationStatusCancelled:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid status",
+			"message": "Status must be one of registered, waitlisted, cancelled",
+		})
+		return
+	}
+
+	registrations, err := c.registrationService.GetRegistrations(eventID, sessionID, status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve registrations",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Registrations retrieved successfully",
+		"data":    registrations,
+		"count":   len(registrations),
+	})
+}
+
+// GetNoShows lists registered people who did not check in
+// @Summary Get event no-shows
+// @Description Get the registered people of an event who have no check-in. Registrations for a session need a check-in for that session; event registrations are satisfied by any session of the event.
+// @Tags registrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param session_id query int false "Only registrations for this session"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /events/{id}/no-shows [get]
+func (c *RegistrationController) GetNoShows(ctx *gin.Context) {
+	eventID, ok := parseEventID(ctx)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseOptionalSessionID(ctx)
+	if !ok {
+		return
+	}
+
+	noShows, err := c.registrationService.GetNoShows(eventID, sessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve no-shows",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "No-shows retrieved successfully",
+		"data":    noShows,
+		"count":   len(noShows),
+	})
+}
+
+func respondRegistrationError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrEventNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Event not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrSessionNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Attendance session not found",
+			"message": "Session not found in this event",
+		})
+	case errors.Is(err, services.ErrRegistrationNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Registration not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrAlreadyRegistered), errors.Is(err, services.ErrRegistrationCancelled):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Registration conflict",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrEventNotOpenForRegistration):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Registration closed",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   fallback,
+			"message": err.Error(),
+		})
+	}
+}
+
+func parseEventID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event ID",
+			"message": err.Error(),
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func parseOptionalSessionID(ctx *gin.Context) (*uint, bool) {
+	value := ctx.Query("session_id")
+	if value == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid session_id",
+			"message": err.Error(),
+		})
+		return nil, false
+	}
+	sessionID := uint(id)
+	return &sessionID, true
+}
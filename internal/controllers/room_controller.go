@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomController struct {
+	roomService interfaces.RoomServiceInterface
+}
+
+func NewRoomController(roomService interfaces.RoomServiceInterface) *RoomController {
+	return &RoomController{
+		roomService: roomService,
+	}
+}
+
+// GetRooms retrieves all rooms
+// @Summary Get all rooms
+// @Description Get a list of all rooms, ordered by building and name
+// @Tags rooms
+// @Produce json
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /rooms [get]
+func (c *RoomController) GetRooms(ctx *gin.Context) {
+	rooms, err := c.roomService.GetRooms()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve rooms",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Rooms retrieved successfully",
+		"data":    rooms,
+		"count":   len(rooms),
+	})
+}
+
+// GetAvailableRooms retrieves the rooms that are free during a time range
+// @Summary Get free rooms
+// @Description Get the rooms without any session overlapping the range, e.g. from=2025-09-02T08:00:00+07:00&to=2025-09-02T10:00:00+07:00. Dates may also be YYYY-MM-DD days, where to includes the whole day.
+// @Tags rooms
+// @Produce json
+// @Param from query string true "Start of the range"
+// @Param to query string true "End of the range"
+// @Param min_capacity query int false "Only rooms holding at least this many people"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /rooms/available [get]
+func (c *RoomController) GetAvailableRooms(ctx *gin.Context) {
+	from, errFrom := parseRangeBound(ctx.Query("from"), false)
+	to, errTo := parseRangeBound(ctx.Query("to"), true)
+	if errFrom != nil || errTo != nil || !to.After(from) || to.Sub(from) > maxConflictRange {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid date range",
+			"message": "from and to are required, as RFC3339 timestamps or YYYY-MM-DD dates, with to after from and at most 92 days apart",
+		})
+		return
+	}
+
+	minCapacity := 0
+	if capacityParam := ctx.Query("min_capacity"); capacityParam != "" {
+		parsed, err := strconv.Atoi(capacityParam)
+		if err != nil || parsed < 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid minimum capacity",
+				"message": "min_capacity must be a non-negative number",
+			})
+			return
+		}
+		minCapacity = parsed
+	}
+
+	rooms, err := c.roomService.GetAvailableRooms(from, to, minCapacity)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve available rooms",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Available rooms retrieved successfully",
+		"data":    rooms,
+		"count":   len(rooms),
+	})
+}
+
+// GetRoomByID retrieves a room by ID
+// @Summary Get room by ID
+// @Description Get a single room by its ID
+// @Tags rooms
+// @Produce json
+// @Param id path int true "Room ID"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /rooms/{id} [get]
+func (c *RoomController) GetRoomByID(ctx *gin.Context) {
+	id, ok := parseRoomID(ctx)
+	if !ok {
+		return
+	}
+
+	room, err := c.roomService.GetRoomByID(id)
+	if err != nil {
+		respondRoomError(ctx, err, "Failed to retrieve room")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Room retrieved successfully",
+		"data":    room,
+	})
+}
+
+// CreateRoom creates a new room
+// @Summary Create a new room
+// @Description Create a room that sessions can be booked in. Its capacity also caps registrations and check-ins of those sessions.
+// @Tags rooms
+// @Accept json
+// @Produce json
+// @Param room body models.RoomRequest true "Room data"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success 201 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /rooms [post]
+func (c *RoomController) CreateRoom(ctx *gin.Context) {
+	var req models.RoomRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	room, err := c.roomService.CreateRoom(&req)
+	if err != nil {
+		respondRoomError(ctx, err, "Failed to create room")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Room created successfully",
+		"data":    room,
+	})
+}
+
+// UpdateRoom updates an existing room
+// @Summary Update a room
+// @Description Change the fields of a room that are given
+// @Tags rooms
+// @Accept json
+// @Produce json
+// @Param id path int true "Room ID"
+// @Param room body models.RoomRequest true "Fields to change"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /rooms/{id} [put]
+func (c *RoomController) UpdateRoom(ctx *gin.Context) {
+	id, ok := parseRoomID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.RoomRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	room, err := c.roomService.UpdateRoom(id, &req)
+	if err != nil {
+		respondRoomError(ctx, err, "Failed to update room")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Room updated successfully",
+		"data":    room,
+	})
+}
+
+// DeleteRoom deletes a room
+// @Summary Delete a room
+// @Description Delete a room that has no upcoming sessions; past sessions keep their room ID
+// @Tags rooms
+// @Produce json
+// @Param id path int true "Room ID"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /rooms/{id} [delete]
+func (c *RoomController) DeleteRoom(ctx *gin.Context) {
+	id, ok := parseRoomID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.roomService.DeleteRoom(id); err != nil {
+		respondRoomError(ctx, err, "Failed to delete room")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Room deleted successfully",
+	})
+}
+
+func parseRoomID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid room ID",
+			"message": err.Error(),
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondRoomError(ctx *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrRoomNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Room not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidRoom):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid room",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrRoomNameTaken):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Room name taken",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrRoomInUse):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Room in use",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   failure,
+			"message": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionSeriesController struct {
+	seriesService interfaces.SessionSeriesServiceInterface
+}
+
+func NewSessionSeriesController(seriesService interfaces.SessionSeriesServiceInterface) *SessionSeriesController {
+	return &SessionSeriesController{
+		seriesService: seriesService,
+	}
+}
+
+// GetSessionSeries godoc
+// @Summary Get recurring session series
+// @Description Get recurring session series; teachers only see the series they teach
+// @Tags session-series
+// @Produce json
+// @Param event_id query int false "Filter by Event ID"
+// @Success 200 {array} models.SessionSeries
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /session-series [get]
+func (c *SessionSeriesController) GetSessionSeries(ctx *gin.Context) {
+	var eventID *uint
+	if eventParam := ctx.Query("event_id"); eventParam != "" {
+		id, err := strconv.ParseUint(eventParam, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid event ID",
+				"message": "Event ID must be a number",
+			})
+			return
+		}
+		eventIDUint := uint(id)
+		eventID = &eventIDUint
+	}
+
+	var teacherID *uint
+	if ownTeacherID, scoped := teacherScope(ctx); scoped {
+		teacherID = &ownTeacherID
+	}
+
+	series, err := services.GetSessionSeries(eventID, teacherID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch session series",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+		"count":   len(series),
+	})
+}
+
+// GetSessionSeriesByID godoc
+// @Summary Get a recurring session series
+// @Description Get a session series with its generated sessions
+// @Tags session-series
+// @Produce json
+// @Param id path int true "Session Series ID"
+// @Success 200 {object} models.SessionSeries
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /session-series/{id} [get]
+func (c *SessionSeriesController) GetSessionSeriesByID(ctx *gin.Context) {
+	series, ok := loadSessionSeries(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+	})
+}
+
+// CreateSessionSeries godoc
+// @Summary Create a recurring session series
+// @Description Create a series from an RFC 5545 RRULE (FREQ=DAILY, WEEKLY, MONTHLY or YEARLY, ending with COUNT or UNTIL) and generate one attendance session per occurrence. Occurrences keep the time of day of starts_at in the series time zone; exdates skip whole days. Check-in windows are given in minutes relative to each occurrence. Series whose occurrences overlap another session of the same teacher, class or room, or each other, are rejected with 409 and the list of conflicts; set force to create them anyway, in which case the conflicts are returned as a warning.
+// @Tags session-series
+// @Accept json
+// @Produce json
+// @Param series body models.CreateSessionSeriesRequest true "Session series data"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success 201 {object} models.SessionSeries
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /session-series [post]
+func (c *SessionSeriesController) CreateSessionSeries(ctx *gin.Context) {
+	var req models.CreateSessionSeriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	series := models.SessionSeries{
+		EventID:   req.EventID,
+		ClassID:   req.ClassID,
+		TeacherID: req.TeacherID,
+		StartsAt:  req.StartsAt,
+		Timezone:  req.Timezone,
+		RRule:     req.RRule,
+		ExDates:   models.StringList(req.ExDates),
+
+		OpensBeforeMinutes: req.OpensBeforeMinutes,
+		LateAfterMinutes:   req.LateAfterMinutes,
+		ClosesAfterMinutes: req.ClosesAfterMinutes,
+		DurationMinutes:    req.DurationMinutes,
+		MinDurationMinutes: req.MinDurationMinutes,
+		Capacity:           req.Capacity,
+		RoomID:             req.RoomID,
+		Location:           req.Location,
+	}
+
+	// Teachers can only create series they teach themselves
+	if ownTeacherID, scoped := teacherScope(ctx); scoped {
+		if series.TeacherID == nil {
+			series.TeacherID = &ownTeacherID
+		}
+		if !authorizeSeries(ctx, &series) {
+			return
+		}
+	}
+
+	conflicts, err := c.seriesService.CreateSessionSeries(&series, req.Force)
+	if err != nil {
+		if respondInvalidSeries(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create session series",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	response := gin.H{
+		"success": true,
+		"data":    series,
+		"count":   len(series.Sessions),
+		"message": "Session series created successfully",
+	}
+	if len(conflicts) > 0 {
+		response["conflicts"] = conflicts
+		response["message"] = "Session series created despite overlapping sessions"
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateSessionSeries godoc
+// @Summary Update a recurring session series
+// @Description Change a series and regenerate its sessions that have not started yet. Past sessions are never changed, and upcoming sessions that already have attendances are kept even when they no longer occur. Changes that make occurrences overlap another session of the same teacher, class or room are rejected with 409 unless force is set.
+// @Tags session-series
+// @Accept json
+// @Produce json
+// @Param id path int true "Session Series ID"
+// @Param series body models.UpdateSessionSeriesRequest true "Fields to change"
+// @Success 200 {object} models.SessionSeriesChanges
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /session-series/{id} [put]
+func (c *SessionSeriesController) UpdateSessionSeries(ctx *gin.Context) {
+	existing, ok := loadSessionSeries(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSessionSeriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	// Teachers cannot hand their series over to someone else
+	if _, scoped := teacherScope(ctx); scoped && req.TeacherID != nil {
+		if !authorizeSeries(ctx, &models.SessionSeries{TeacherID: req.TeacherID}) {
+			return
+		}
+	}
+
+	series, changes, err := c.seriesService.UpdateSessionSeries(existing.ID, req, time.Now())
+	if err != nil {
+		if respondInvalidSeries(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update session series",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+		"changes": changes,
+		"message": "Session series updated successfully",
+	})
+}
+
+// CancelSessionSeries godoc
+// @Summary Cancel a recurring session series
+// @Description Cancel a series and remove its sessions that have not started yet. Past sessions and upcoming sessions that already have attendances are kept.
+// @Tags session-series
+// @Produce json
+// @Param id path int true "Session Series ID"
+// @Success 200 {object} models.SessionSeriesChanges
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /session-series/{id} [delete]
+func (c *SessionSeriesController) CancelSessionSeries(ctx *gin.Context) {
+	existing, ok := loadSessionSeries(ctx)
+	if !ok {
+		return
+	}
+
+	series, changes, err := services.CancelSessionSeries(existing.ID, time.Now())
+	if err != nil {
+		if respondInvalidSeries(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to cancel session series",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+		"changes": changes,
+		"message": "Session series cancelled successfully",
+	})
+}
+
+// loadSessionSeries resolves the :id series and checks teacher access, writing
+// the error response when it fails
+func loadSessionSeries(ctx *gin.Context) (*models.SessionSeries, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid session series ID",
+			"message": "Session series ID must be a number",
+		})
+		return nil, false
+	}
+
+	series, err := services.GetSessionSeriesByID(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrSeriesNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"error":   "Session series not found",
+				"message": err.Error(),
+			})
+			return nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch session series",
+			"message": err.Error(),
+		})
+		return nil, false
+	}
+
+	if !authorizeSeries(ctx, series) {
+		return nil, false
+	}
+	return series, true
+}
+
+// respondInvalidSeries writes the response for series validation, conflict and
+// state errors, returning false for any other error
+func respondInvalidSeries(ctx *gin.Context, err error) bool {
+	var conflictErr *services.SessionConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":     "Session conflict",
+			"message":   services.ErrSessionConflict.Error() + "; set force to save the series anyway",
+			"conflicts": conflictErr.Conflicts,
+		})
+	case errors.Is(err, services.ErrInvalidRecurrence):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid recurrence",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidCheckinWindow):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid check-in window",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrRoomNotFound):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid room",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidDuration):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid duration",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrSeriesCancelled):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Session series cancelled",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrSeriesNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Session series not found",
+			"message": err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
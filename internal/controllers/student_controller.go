@@ -1,14 +1,27 @@
 package controllers
 
 import (
+	"errors"
 	"hello-gin/config"
+	"hello-gin/internal/interfaces"
 	"hello-gin/internal/models"
 	"hello-gin/internal/services"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+type StudentController struct {
+	studentService interfaces.StudentServiceInterface
+}
+
+func NewStudentController(studentService interfaces.StudentServiceInterface) *StudentController {
+	return &StudentController{
+		studentService: studentService,
+	}
+}
+
 // GetStudents godoc
 // @Summary      Get all students
 // @Description  Get a list of all students
@@ -17,63 +30,394 @@ import (
 // @Produce      json
 // @Success      200  {array}   models.Student
 // @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
 // @Router       /students [get]
-func GetStudents(c *gin.Context) {
-	students, err := services.GetStudents()
+func (c *StudentController) GetStudents(ctx *gin.Context) {
+	students, err := c.studentService.GetStudents()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch students",
+			"message": err.Error(),
+		})
 		return
 	}
-	c.JSON(http.StatusOK, students)
+	ctx.JSON(http.StatusOK, students)
+}
+
+// GetStudentByID godoc
+// @Summary      Get student by ID
+// @Description  Get a student with their class. Students can only get themselves.
+// @Tags         students
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Success      200  {object}  models.Student
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id} [get]
+func (c *StudentController) GetStudentByID(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	if studentID, scoped := studentScope(ctx); scoped && studentID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": "Students can only view their own profile",
+		})
+		return
+	}
+
+	student, err := c.studentService.GetStudentByID(id)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to fetch student")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    student,
+	})
 }
 
 // CreateStudent godoc
 // @Summary      Create a new student
-// @Description  Create a new student in the database
+// @Description  Create a new student in the database. The class must exist and the student code must not be used by another student. The student is enrolled in the class from today.
 // @Tags         students
 // @Accept       json
 // @Produce      json
 // @Param        student body models.CreateStudentRequest true "Student data"
+// @Param        Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success      201  {object}  models.Student
 // @Failure      400  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
 // @Router       /students [post]
-func CreateStudent(c *gin.Context) {
+func (c *StudentController) CreateStudent(ctx *gin.Context) {
 	var req models.CreateStudentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	// Convert DTO to Student model
-	student := models.Student{
-		StudentCode: req.StudentCode,
-		StudentName: req.StudentName,
-		ClassID:     req.ClassID,
-		Phone:       req.Phone,
-		Email:       req.Email,
-		WorkUnit:    req.WorkUnit,
-		DateOfBirth: req.DateOfBirth,
+	student, err := c.studentService.CreateStudent(&req)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to create student")
+		return
 	}
 
-	if err := services.CreateStudent(&student); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create student",
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    student,
+		"message": "Student created successfully",
+	})
+}
+
+// UpdateStudent godoc
+// @Summary      Update a student
+// @Description  Change the fields of a student that are given; omitted fields are kept. A new class_id transfers the student from today; use POST /students/{id}/transfer for another date.
+// @Tags         students
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Param        student body models.CreateStudentRequest true "Fields to change"
+// @Success      200  {object}  models.Student
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id} [patch]
+func (c *StudentController) UpdateStudent(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateStudentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	student, err := c.studentService.UpdateStudent(id, &req)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to update student")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    student,
-		"message": "Student created successfully",
+		"message": "Student updated successfully",
+	})
+}
+
+// DeleteStudent godoc
+// @Summary      Delete a student
+// @Description  Delete a student; their attendances and excuse requests are kept
+// @Tags         students
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id} [delete]
+func (c *StudentController) DeleteStudent(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.studentService.DeleteStudent(id); err != nil {
+		respondStudentError(ctx, err, "Failed to delete student")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Student deleted successfully",
+	})
+}
+
+// GetStudentEnrollments godoc
+// @Summary      Get a student's enrollments
+// @Description  Get the classes a student is or was enrolled in, oldest first. enrolled_to is the first day no longer enrolled, null while still enrolled. Students can only get their own.
+// @Tags         students
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Success      200  {array}   models.Enrollment
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id}/enrollments [get]
+func (c *StudentController) GetStudentEnrollments(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	if studentID, scoped := studentScope(ctx); scoped && studentID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": "Students can only view their own enrollments",
+		})
+		return
+	}
+
+	enrollments, err := c.studentService.GetStudentEnrollments(id)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to fetch enrollments")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    enrollments,
+		"count":   len(enrollments),
 	})
 }
 
+// EnrollStudent godoc
+// @Summary      Enroll a student in a class
+// @Description  Enroll a student in another class, e.g. a second course section, as a student, auditor or assistant. The class becomes the student's main class when they have none.
+// @Tags         students
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Param        enrollment body models.EnrollStudentRequest true "Class and role"
+// @Param        Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success      201  {object}  models.Enrollment
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id}/enrollments [post]
+func (c *StudentController) EnrollStudent(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.EnrollStudentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	enrollment, err := c.studentService.EnrollStudent(id, &req)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to enroll student")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    enrollment,
+		"message": "Student enrolled successfully",
+	})
+}
+
+// TransferStudent godoc
+// @Summary      Transfer a student to another class
+// @Description  End the student's enrollment in from_class_id (their main class by default) and enroll them in to_class_id from effective_date, which may be in the past. Rosters of sessions before that date keep listing the student in the old class.
+// @Tags         students
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Param        transfer body models.TransferStudentRequest true "Classes and effective date"
+// @Param        Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success      200  {object}  models.Enrollment
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id}/transfer [post]
+func (c *StudentController) TransferStudent(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.TransferStudentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	enrollment, err := c.studentService.TransferStudent(id, &req)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to transfer student")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    enrollment,
+		"message": "Student transferred successfully",
+	})
+}
+
+// WithdrawStudent godoc
+// @Summary      Withdraw a student from a class
+// @Description  End the student's enrollment in class_id (their main class by default) from effective_date. The student record is kept.
+// @Tags         students
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Param        withdrawal body models.WithdrawStudentRequest false "Class and effective date"
+// @Param        Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success      200  {object}  models.Enrollment
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /students/{id}/withdraw [post]
+func (c *StudentController) WithdrawStudent(ctx *gin.Context) {
+	id, ok := parseStudentID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.WithdrawStudentRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request data",
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+
+	enrollment, err := c.studentService.WithdrawStudent(id, &req)
+	if err != nil {
+		respondStudentError(ctx, err, "Failed to withdraw student")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    enrollment,
+		"message": "Student withdrawn successfully",
+	})
+}
+
+func parseStudentID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid student ID",
+			"message": "Student ID must be a number",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondStudentError(ctx *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrStudentNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Student not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrClassNotFound):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid class",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrStudentCodeTaken):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Student code taken",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidEnrollmentRole), errors.Is(err, services.ErrInvalidEnrollmentDate):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid enrollment",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotEnrolled):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Not enrolled",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrAlreadyEnrolled):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Already enrolled",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   failure,
+			"message": err.Error(),
+		})
+	}
+}
+
 // HealthCheck godoc
 // @Summary      Health check
 // @Description  Check if the application and database are running
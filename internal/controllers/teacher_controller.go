@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+	"hello-gin/internal/interfaces"
 	"hello-gin/internal/models"
 	"hello-gin/internal/services"
 	"net/http"
@@ -9,6 +11,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type TeacherController struct {
+	teacherService interfaces.TeacherServiceInterface
+}
+
+func NewTeacherController(teacherService interfaces.TeacherServiceInterface) *TeacherController {
+	return &TeacherController{
+		teacherService: teacherService,
+	}
+}
+
 // GetTeachers godoc
 // @Summary Get all teachers
 // @Description Get all teachers from the database
@@ -16,18 +28,19 @@ import (
 // @Produce json
 // @Success 200 {array} models.Teacher
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /teachers [get]
-func GetTeachers(c *gin.Context) {
-	teachers, err := services.GetTeachers()
+func (c *TeacherController) GetTeachers(ctx *gin.Context) {
+	teachers, err := c.teacherService.GetTeachers()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch teachers",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    teachers,
 		"count":   len(teachers),
@@ -44,28 +57,21 @@ func GetTeachers(c *gin.Context) {
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /teachers/{id} [get]
-func GetTeacherByID(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid teacher ID",
-			"message": "Teacher ID must be a number",
-		})
+func (c *TeacherController) GetTeacherByID(ctx *gin.Context) {
+	id, ok := parseTeacherID(ctx)
+	if !ok {
 		return
 	}
 
-	teacher, err := services.GetTeacherByID(id)
+	teacher, err := c.teacherService.GetTeacherByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Teacher not found",
-			"message": err.Error(),
-		})
+		respondTeacherError(ctx, err, "Failed to fetch teacher")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    teacher,
 	})
@@ -73,46 +79,147 @@ func GetTeacherByID(c *gin.Context) {
 
 // CreateTeacher godoc
 // @Summary Create a new teacher
-// @Description Create a new teacher in the database
+// @Description Create a new teacher in the database. The teacher code must not be used by another teacher.
 // @Tags teachers
 // @Accept json
 // @Produce json
 // @Param teacher body models.CreateTeacherRequest true "Teacher data"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success 201 {object} models.Teacher
 // @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
 // @Router /teachers [post]
-func CreateTeacher(c *gin.Context) {
+func (c *TeacherController) CreateTeacher(ctx *gin.Context) {
 	var req models.CreateTeacherRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	// Convert DTO to Teacher model
-	teacher := models.Teacher{
-		TeacherCode: req.TeacherCode,
-		TeacherName: req.TeacherName,
-		Phone:       req.Phone,
-		Email:       req.Email,
-		WorkUnit:    req.WorkUnit,
-		DateOfBirth: req.DateOfBirth,
+	teacher, err := c.teacherService.CreateTeacher(&req)
+	if err != nil {
+		respondTeacherError(ctx, err, "Failed to create teacher")
+		return
 	}
 
-	if err := services.CreateTeacher(&teacher); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create teacher",
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    teacher,
+		"message": "Teacher created successfully",
+	})
+}
+
+// UpdateTeacher godoc
+// @Summary Update a teacher
+// @Description Change the fields of a teacher that are given; omitted fields are kept
+// @Tags teachers
+// @Accept json
+// @Produce json
+// @Param id path int true "Teacher ID"
+// @Param teacher body models.CreateTeacherRequest true "Fields to change"
+// @Success 200 {object} models.Teacher
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /teachers/{id} [patch]
+func (c *TeacherController) UpdateTeacher(ctx *gin.Context) {
+	id, ok := parseTeacherID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateTeacherRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
 			"message": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	teacher, err := c.teacherService.UpdateTeacher(id, &req)
+	if err != nil {
+		respondTeacherError(ctx, err, "Failed to update teacher")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    teacher,
-		"message": "Teacher created successfully",
+		"message": "Teacher updated successfully",
+	})
+}
+
+// DeleteTeacher godoc
+// @Summary Delete a teacher
+// @Description Delete a teacher who has no upcoming sessions; past sessions keep their teacher ID
+// @Tags teachers
+// @Produce json
+// @Param id path int true "Teacher ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /teachers/{id} [delete]
+func (c *TeacherController) DeleteTeacher(ctx *gin.Context) {
+	id, ok := parseTeacherID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.teacherService.DeleteTeacher(id); err != nil {
+		respondTeacherError(ctx, err, "Failed to delete teacher")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Teacher deleted successfully",
 	})
 }
+
+func parseTeacherID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid teacher ID",
+			"message": "Teacher ID must be a number",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondTeacherError(ctx *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrTeacherNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Teacher not found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrTeacherCodeTaken):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Teacher code taken",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrTeacherHasSessions):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Teacher has upcoming sessions",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   failure,
+			"message": err.Error(),
+		})
+	}
+}
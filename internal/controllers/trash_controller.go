@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"errors"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashController struct {
+	trashService interfaces.TrashServiceInterface
+}
+
+func NewTrashController(trashService interfaces.TrashServiceInterface) *TrashController {
+	return &TrashController{
+		trashService: trashService,
+	}
+}
+
+// GetTrash lists the deleted records of a resource
+// @Summary List deleted records
+// @Description List the deleted events, students, classes, teachers or rooms, most recently deleted first. purge_at is when the automatic purge removes a record (TRASH_RETENTION_DAYS after deletion), null when it is disabled.
+// @Tags trash
+// @Produce json
+// @Param resource path string true "Resource" Enums(events, students, classes, teachers, rooms)
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /trash/{resource} [get]
+func (c *TrashController) GetTrash(ctx *gin.Context) {
+	items, err := c.trashService.GetTrash(ctx.Param("resource"))
+	if err != nil {
+		respondTrashError(ctx, err, "Failed to retrieve trash")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Trash retrieved successfully",
+		"data":    items,
+		"count":   len(items),
+	})
+}
+
+// RestoreFromTrash restores a deleted record
+// @Summary Restore a deleted record
+// @Description Restore a deleted record together with the records deleted with it: an event's session series, sessions, attendances, registrations and certificates, or the students deleted with a class. Records deleted on their own stay in the trash. restored counts the records brought back per table.
+// @Tags trash
+// @Produce json
+// @Param resource path string true "Resource" Enums(events, students, classes, teachers, rooms)
+// @Param id path int true "Record ID"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /trash/{resource}/{id}/restore [post]
+func (c *TrashController) RestoreFromTrash(ctx *gin.Context) {
+	id, ok := parseTrashID(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.trashService.RestoreFromTrash(ctx.Param("resource"), id)
+	if err != nil {
+		respondTrashError(ctx, err, "Failed to restore record")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Record restored successfully",
+		"data":    result,
+	})
+}
+
+// PurgeFromTrash permanently deletes a deleted record
+// @Summary Purge a deleted record
+// @Description Permanently delete a record from the trash. Purging an event also removes its session series, sessions, attendances, registrations, excuse requests and certificates; purging a class removes its deleted students. Records still used elsewhere, such as a teacher with past sessions, cannot be purged. Admins only.
+// @Tags trash
+// @Produce json
+// @Param resource path string true "Resource" Enums(events, students, classes, teachers, rooms)
+// @Param id path int true "Record ID"
+// @Success 200 {object} map[string]interface{} "success"
+// @Failure 400 {object} map[string]interface{} "error"
+// @Failure 403 {object} map[string]interface{} "error"
+// @Failure 404 {object} map[string]interface{} "error"
+// @Failure 409 {object} map[string]interface{} "error"
+// @Failure 500 {object} map[string]interface{} "error"
+// @Security BearerAuth
+// @Router /trash/{resource}/{id} [delete]
+func (c *TrashController) PurgeFromTrash(ctx *gin.Context) {
+	id, ok := parseTrashID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.trashService.PurgeFromTrash(ctx.Param("resource"), id); err != nil {
+		respondTrashError(ctx, err, "Failed to purge record")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Record purged successfully",
+	})
+}
+
+func parseTrashID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid record ID",
+			"message": err.Error(),
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondTrashError(ctx *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrTrashResourceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Unknown trash resource",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotInTrash):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error":   "Record not in trash",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrTrashRestoreConflict):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Code or name taken",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrTrashParentDeleted):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Parent record deleted",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrTrashInUse):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error":   "Record in use",
+			"message": err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":   failure,
+			"message": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type AttendanceSessionServiceInterface interface {
+	CreateAttendanceSession(session *models.AttendanceSession, force bool) ([]models.SessionConflict, error)
+}
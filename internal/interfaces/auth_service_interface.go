@@ -0,0 +1,10 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type AuthServiceInterface interface {
+	Login(req *models.LoginRequest) (*models.TokenResponse, error)
+	RefreshToken(refreshToken string) (*models.TokenResponse, error)
+	ParseAccessToken(accessToken string) (*models.AuthClaims, error)
+	GetUserByID(id uint) (*models.User, error)
+}
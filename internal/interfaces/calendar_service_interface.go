@@ -0,0 +1,8 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type CalendarServiceInterface interface {
+	GetCalendarFeedLink(scope string, id uint) (*models.CalendarFeedResponse, error)
+	GetCalendarFeed(scope string, id uint, token string) ([]byte, error)
+}
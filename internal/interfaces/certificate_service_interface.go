@@ -0,0 +1,9 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type CertificateServiceInterface interface {
+	GetAttendanceCertificate(attendanceID uint) (*models.Certificate, []byte, error)
+	GetEventCertificates(eventID uint) ([]byte, int, error)
+	VerifyCertificate(code string) (*models.Certificate, error)
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type CheckInServiceInterface interface {
+	CheckIn(req *models.CreateAttendanceRequest) (*models.Attendance, bool, error)
+	CheckInFromPage(req *models.CreateAttendanceRequest) (*models.Attendance, bool, error)
+	SyncKioskAttendances(items []models.BatchAttendanceItem) []models.BatchItemResult
+}
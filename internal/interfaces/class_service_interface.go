@@ -0,0 +1,11 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type ClassServiceInterface interface {
+	GetClasses() ([]models.Class, error)
+	GetClassByID(id uint) (*models.Class, error)
+	CreateClass(req *models.CreateClassRequest) (*models.Class, error)
+	UpdateClass(id uint, req *models.UpdateClassRequest) (*models.Class, error)
+	DeleteClass(id uint, cascade bool) (int64, error)
+}
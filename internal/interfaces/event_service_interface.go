@@ -9,7 +9,10 @@ type EventServiceInterface interface {
 	CreateEvent(req *models.CreateEventRequest) (*models.Event, error)
 	UpdateEvent(id uint, req *models.CreateEventRequest) (*models.Event, error)
 	DeleteEvent(id uint) error
+	CloneEvent(id uint, req *models.CloneEventRequest) (*models.Event, []models.SessionConflict, error)
 
 	GetActiveEvents() ([]models.Event, error)
-	SetEventActive(id uint, isActive bool) (*models.Event, error)
+	SetEventStatus(id uint, status string) (*models.Event, error)
+	SetEventActive(id uint, active bool) (*models.Event, error)
+	GetEventStatusLog(id uint) ([]models.EventStatusLog, error)
 }
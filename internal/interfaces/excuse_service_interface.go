@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"hello-gin/internal/models"
+	"mime/multipart"
+)
+
+type ExcuseServiceInterface interface {
+	GetExcuses(filter models.ExcuseFilter) ([]models.ExcuseRequest, error)
+	GetExcuseByID(id uint) (*models.ExcuseRequest, error)
+	GetExcuseHistory(id uint) ([]models.ExcuseRequestEvent, error)
+	SubmitExcuse(req *models.CreateExcuseRequest, attachment *multipart.FileHeader, submittedBy *uint) (*models.ExcuseRequest, error)
+	ReviewExcuse(id uint, approve bool, req *models.ReviewExcuseRequest, reviewerID *uint) (*models.ExcuseRequest, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"hello-gin/internal/models"
+	"time"
+)
+
+type IdempotencyStoreInterface interface {
+	// Begin reserves scope and key for a request. If an unexpired record
+	// already exists it is returned with created set to false.
+	Begin(scope, key, requestHash string, ttl time.Duration) (record *models.IdempotencyRecord, created bool, err error)
+	// Complete stores the response of a reserved request
+	Complete(record *models.IdempotencyRecord, statusCode int, contentType string, body []byte) error
+	// Release drops a reservation so that the request can be retried
+	Release(record *models.IdempotencyRecord) error
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type RegistrationServiceInterface interface {
+	Register(eventID uint, req *models.CreateRegistrationRequest) (*models.Registration, error)
+	CancelRegistration(cancelToken string) (*models.Registration, []models.Registration, error)
+	CancelRegistrationByID(id uint) (*models.Registration, []models.Registration, error)
+	GetRegistrations(eventID uint, sessionID *uint, status string) ([]models.Registration, error)
+	GetNoShows(eventID uint, sessionID *uint) ([]models.Registration, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"hello-gin/internal/models"
+	"time"
+)
+
+type RoomServiceInterface interface {
+	GetRooms() ([]models.Room, error)
+	GetRoomByID(id uint) (*models.Room, error)
+	CreateRoom(req *models.RoomRequest) (*models.Room, error)
+	UpdateRoom(id uint, req *models.RoomRequest) (*models.Room, error)
+	DeleteRoom(id uint) error
+
+	GetAvailableRooms(from, to time.Time, minCapacity int) ([]models.Room, error)
+}
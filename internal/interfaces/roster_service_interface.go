@@ -0,0 +1,7 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type RosterServiceInterface interface {
+	GetSessionRoster(sessionID uint) (*models.SessionRoster, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"hello-gin/internal/models"
+	"time"
+)
+
+type SessionSeriesServiceInterface interface {
+	CreateSessionSeries(series *models.SessionSeries, force bool) ([]models.SessionConflict, error)
+	UpdateSessionSeries(id uint, req models.UpdateSessionSeriesRequest, now time.Time) (*models.SessionSeries, *models.SessionSeriesChanges, error)
+}
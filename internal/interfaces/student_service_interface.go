@@ -0,0 +1,16 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type StudentServiceInterface interface {
+	GetStudents() ([]models.Student, error)
+	GetStudentByID(id uint) (*models.Student, error)
+	CreateStudent(req *models.CreateStudentRequest) (*models.Student, error)
+	UpdateStudent(id uint, req *models.CreateStudentRequest) (*models.Student, error)
+	DeleteStudent(id uint) error
+
+	GetStudentEnrollments(id uint) ([]models.Enrollment, error)
+	EnrollStudent(id uint, req *models.EnrollStudentRequest) (*models.Enrollment, error)
+	TransferStudent(id uint, req *models.TransferStudentRequest) (*models.Enrollment, error)
+	WithdrawStudent(id uint, req *models.WithdrawStudentRequest) (*models.Enrollment, error)
+}
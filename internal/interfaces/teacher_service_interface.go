@@ -0,0 +1,11 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type TeacherServiceInterface interface {
+	GetTeachers() ([]models.Teacher, error)
+	GetTeacherByID(id uint) (*models.Teacher, error)
+	CreateTeacher(req *models.CreateTeacherRequest) (*models.Teacher, error)
+	UpdateTeacher(id uint, req *models.CreateTeacherRequest) (*models.Teacher, error)
+	DeleteTeacher(id uint) error
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "hello-gin/internal/models"
+
+type TrashServiceInterface interface {
+	GetTrash(resource string) ([]models.TrashItem, error)
+	RestoreFromTrash(resource string, id uint) (*models.TrashRestoreResponse, error)
+	PurgeFromTrash(resource string, id uint) error
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextClaimsKey is the gin context key holding the authenticated user's claims
+const ContextClaimsKey = "authClaims"
+
+// AuthRequired rejects requests without a valid Bearer access token.
+// Routes listed in publicRoutes ("METHOD /full/path", e.g. "GET /api/health")
+// are let through without a token.
+func AuthRequired(authService interfaces.AuthServiceInterface, publicRoutes ...string) gin.HandlerFunc {
+	public := make(map[string]bool, len(publicRoutes))
+	for _, route := range publicRoutes {
+		public[route] = true
+	}
+
+	return func(c *gin.Context) {
+		if public[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Missing or malformed Authorization header, expected 'Bearer <token>'",
+			})
+			return
+		}
+
+		claims, err := authService.ParseAccessToken(strings.TrimSpace(token))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		c.Set(ContextClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// CurrentClaims returns the claims of the authenticated user, if any
+func CurrentClaims(c *gin.Context) (*models.AuthClaims, bool) {
+	value, exists := c.Get(ContextClaimsKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*models.AuthClaims)
+	return claims, ok
+}
+
+// RequireRoles rejects authenticated users whose role is not in roles
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := CurrentClaims(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "No authenticated user",
+			})
+			return
+		}
+
+		if !claims.HasRole(roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": "Role '" + claims.Role + "' is not allowed to perform this action",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hello-gin/internal/interfaces"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client's idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyReplayedHeader is set on responses replayed from the store
+const IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes POST requests that carry an Idempotency-Key header safe to
+// retry. The first response is stored for ttl and replayed for later requests
+// with the same key; reusing a key with a different body is rejected with 422.
+// Keys are scoped per user and endpoint. Server errors and panics are not
+// stored so that the request can be retried.
+func Idempotency(store interfaces.IdempotencyStoreInterface, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid Idempotency-Key",
+				"message": "Idempotency-Key must be at most " + strconv.Itoa(maxIdempotencyKeyLength) + " characters",
+			})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		io.WriteString(hash, c.Request.Method+" "+c.Request.URL.RequestURI()+"\n")
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		record, created, err := store.Begin(idempotencyScope(c), key, requestHash, ttl)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to check Idempotency-Key",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		if !created {
+			switch {
+			case record.RequestHash != requestHash:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+					"error":   "Idempotency-Key reused",
+					"message": "This Idempotency-Key was already used with a different request",
+				})
+			case !record.Completed:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"error":   "Request in progress",
+					"message": "A request with this Idempotency-Key is still being processed",
+				})
+			default:
+				c.Header(IdempotencyReplayedHeader, "true")
+				c.Data(record.StatusCode, record.ContentType, record.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		// A handler that panics never completes the request; release the key
+		// on the way up to the recovery middleware
+		completed := false
+		defer func() {
+			if !completed {
+				store.Release(record)
+			}
+		}()
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		if err := store.Complete(record, writer.Status(), writer.Header().Get("Content-Type"), writer.body.Bytes()); err == nil {
+			completed = true
+		}
+	}
+}
+
+// idempotencyScope keeps keys of different users and endpoints apart
+func idempotencyScope(c *gin.Context) string {
+	scope := "anonymous"
+	if claims, ok := CurrentClaims(c); ok {
+		scope = "user:" + strconv.FormatUint(uint64(claims.UserID), 10)
+	}
+	return scope + " " + c.Request.Method + " " + c.Request.URL.Path
+}
+
+// recordingWriter keeps a copy of the response body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,160 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// Partial unique indexes on attendances; rows without the column value
+// (e.g. unmatched check-ins without a student) are not constrained
+var attendanceUniqueIndexes = []struct {
+	name   string
+	column string
+}{
+	{"idx_attendances_session_email", "email_normalized"},
+	{"idx_attendances_session_phone_dedupe", "phone_dedupe_key"},
+	{"idx_attendances_session_student", "student_id"},
+}
+
+// backfillAttendanceContacts fills the normalized email/phone columns of
+// attendances recorded before duplicate detection existed, and the phone
+// dedupe key of those recorded for events that dedupe by phone.
+// The phone expression mirrors services.NormalizePhone.
+func backfillAttendanceContacts(db *gorm.DB) error {
+	err := db.Exec(`
+		UPDATE attendances
+		SET email_normalized = LOWER(TRIM(email))
+		WHERE email_normalized IS NULL AND email IS NOT NULL AND TRIM(email) <> ''`).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Exec(`
+		UPDATE attendances
+		SET phone_normalized = NULLIF(regexp_replace(regexp_replace(TRIM(phone), '^(\+84|0084)', '0'), '[^0-9]', '', 'g'), '')
+		WHERE phone_normalized IS NULL AND phone IS NOT NULL`).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`
+		UPDATE attendances a
+		SET phone_dedupe_key = a.phone_normalized
+		FROM attendance_sessions s, events e
+		WHERE s.id = a.session_id AND e.id = s.event_id AND e.dedupe_by_phone
+			AND a.phone_dedupe_key IS NULL AND a.phone_normalized IS NOT NULL`).Error
+}
+
+// ensureAttendanceUniqueIndexes creates the per-session unique indexes.
+// If duplicates are still present an index is skipped with a warning, since
+// creating it would fail; run the dedupe command first.
+func ensureAttendanceUniqueIndexes(db *gorm.DB) error {
+	for _, index := range attendanceUniqueIndexes {
+		var duplicates int64
+		err := db.Raw(fmt.Sprintf(`
+			SELECT COUNT(*) FROM (
+				SELECT session_id, %[1]s
+				FROM attendances
+				WHERE deleted_at IS NULL AND %[1]s IS NOT NULL
+				GROUP BY session_id, %[1]s
+				HAVING COUNT(*) > 1
+			) AS duplicates`, index.column)).Scan(&duplicates).Error
+		if err != nil {
+			return err
+		}
+
+		if duplicates > 0 {
+			log.Printf("⚠️  Found %d duplicated check-ins by %s, skipping %s. Run: go run cmd/migrate/main.go dedupe", duplicates, index.column, index.name)
+			continue
+		}
+
+		err = db.Exec(fmt.Sprintf(`
+			CREATE UNIQUE INDEX IF NOT EXISTS %[1]s
+			ON attendances (session_id, %[2]s)
+			WHERE deleted_at IS NULL AND %[2]s IS NOT NULL`, index.name, index.column)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DedupeAttendances soft-deletes repeated check-ins, keeping the earliest
+// record per session and normalized email, per session and student, and per
+// normalized phone for events that dedupe by phone, then creates the unique indexes
+func DedupeAttendances(db *gorm.DB) (int64, error) {
+	log.Println("🧹 Removing duplicated check-ins...")
+
+	if err := backfillAttendanceContacts(db); err != nil {
+		return 0, fmt.Errorf("failed to normalize attendances: %v", err)
+	}
+
+	var removed int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		byEmail := tx.Exec(`
+			UPDATE attendances SET deleted_at = NOW()
+			WHERE id IN (
+				SELECT id FROM (
+					SELECT id, ROW_NUMBER() OVER (
+						PARTITION BY session_id, email_normalized
+						ORDER BY checked_in_at NULLS LAST, id
+					) AS position
+					FROM attendances
+					WHERE deleted_at IS NULL AND email_normalized IS NOT NULL
+				) ranked
+				WHERE ranked.position > 1
+			)`)
+		if byEmail.Error != nil {
+			return byEmail.Error
+		}
+
+		byPhone := tx.Exec(`
+			UPDATE attendances SET deleted_at = NOW()
+			WHERE id IN (
+				SELECT id FROM (
+					SELECT id, ROW_NUMBER() OVER (
+						PARTITION BY session_id, phone_dedupe_key
+						ORDER BY checked_in_at NULLS LAST, id
+					) AS position
+					FROM attendances
+					WHERE deleted_at IS NULL AND phone_dedupe_key IS NOT NULL
+				) ranked
+				WHERE ranked.position > 1
+			)`)
+		if byPhone.Error != nil {
+			return byPhone.Error
+		}
+
+		byStudent := tx.Exec(`
+			UPDATE attendances SET deleted_at = NOW()
+			WHERE id IN (
+				SELECT id FROM (
+					SELECT id, ROW_NUMBER() OVER (
+						PARTITION BY session_id, student_id
+						ORDER BY checked_in_at NULLS LAST, id
+					) AS position
+					FROM attendances
+					WHERE deleted_at IS NULL AND student_id IS NOT NULL
+				) ranked
+				WHERE ranked.position > 1
+			)`)
+		if byStudent.Error != nil {
+			return byStudent.Error
+		}
+
+		removed = byEmail.RowsAffected + byPhone.RowsAffected + byStudent.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to dedupe attendances: %v", err)
+	}
+
+	if err := ensureAttendanceUniqueIndexes(db); err != nil {
+		return removed, fmt.Errorf("failed to create unique indexes: %v", err)
+	}
+
+	log.Printf("✅ Removed %d duplicated check-ins", removed)
+	return removed, nil
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"hello-gin/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// backfillEnrollments gives students created before enrollments existed an
+// open enrollment in their class. It starts when the class was created so
+// that rosters of past sessions keep listing them.
+func backfillEnrollments(db *gorm.DB) error {
+	result := db.Exec(`
+		INSERT INTO enrollments (created_at, updated_at, student_id, class_id, role, enrolled_from)
+		SELECT NOW(), NOW(), s.id, s.class_id, ?, LEAST(s.created_at, c.created_at)
+		FROM students s
+		JOIN classes c ON c.id = s.class_id
+		WHERE s.class_id IS NOT NULL
+		  AND NOT EXISTS (SELECT 1 FROM enrollments e WHERE e.student_id = s.id)`,
+		models.EnrollmentRoleStudent)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("🔄 Created enrollments for %d students", result.RowsAffected)
+	}
+	return nil
+}
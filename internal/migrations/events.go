@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"hello-gin/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// migrateEventActiveToStatus replaces the is_active flag of events created
+// before the status lifecycle existed: active events become ongoing and
+// inactive ones closed. The column is dropped afterwards so this runs once.
+func migrateEventActiveToStatus(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Event{}, "is_active") {
+		return nil
+	}
+
+	log.Println("🔄 Converting events.is_active to events.status...")
+	err := db.Exec(`
+		UPDATE events
+		SET status = CASE WHEN is_active IS FALSE THEN ? ELSE ? END`,
+		models.EventStatusClosed, models.EventStatusOngoing).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Migrator().DropColumn(&models.Event{}, "is_active")
+}
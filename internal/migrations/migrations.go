@@ -16,16 +16,47 @@ func RunMigrations(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&models.Class{},
 		&models.Student{},
+		&models.Enrollment{},
 		&models.Teacher{},
+		&models.Room{},
 		&models.Event{},
 		&models.AttendanceSession{},
 		&models.Attendance{},
+		&models.User{},
+		&models.ExcuseRequest{},
+		&models.ExcuseRequestEvent{},
+		&models.Registration{},
+		&models.IdempotencyRecord{},
+		&models.CheckinFormTicket{},
+		&models.Certificate{},
+		&models.SessionSeries{},
+		&models.EventStatusLog{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %v", err)
 	}
 
+	if err := migrateEventActiveToStatus(db); err != nil {
+		return fmt.Errorf("failed to migrate event status: %v", err)
+	}
+
+	// Duplicate check-in detection
+	if err := backfillAttendanceContacts(db); err != nil {
+		return fmt.Errorf("failed to normalize attendances: %v", err)
+	}
+	if err := ensureAttendanceUniqueIndexes(db); err != nil {
+		return fmt.Errorf("failed to create attendance unique indexes: %v", err)
+	}
+
+	if err := ensureRegistrationUniqueIndex(db); err != nil {
+		return fmt.Errorf("failed to create registration unique index: %v", err)
+	}
+
+	if err := backfillEnrollments(db); err != nil {
+		return fmt.Errorf("failed to backfill enrollments: %v", err)
+	}
+
 	log.Println("✅ Database migrations completed successfully!")
 	return nil
 }
@@ -35,9 +66,20 @@ func DropAllTables(db *gorm.DB) error {
 	log.Println("⚠️  Dropping all tables...")
 
 	err := db.Migrator().DropTable(
+		&models.EventStatusLog{},
+		&models.SessionSeries{},
+		&models.Certificate{},
+		&models.CheckinFormTicket{},
+		&models.IdempotencyRecord{},
+		&models.Registration{},
+		&models.ExcuseRequestEvent{},
+		&models.ExcuseRequest{},
+		&models.User{},
 		&models.Attendance{},
 		&models.AttendanceSession{},
 		&models.Event{},
+		&models.Room{},
+		&models.Enrollment{},
 		&models.Student{},
 		&models.Teacher{},
 		&models.Class{},
@@ -0,0 +1,13 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// ensureRegistrationUniqueIndex allows one active registration per email for
+// the whole event and per session. session_id is coalesced because NULLs are
+// never equal in a unique index.
+func ensureRegistrationUniqueIndex(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_active_email
+		ON registrations (event_id, COALESCE(session_id, 0), email_normalized)
+		WHERE deleted_at IS NULL AND status IN ('registered', 'waitlisted')`).Error
+}
@@ -6,6 +6,30 @@ import (
 	"gorm.io/gorm"
 )
 
+// Attendance statuses
+const (
+	AttendanceStatusPresent  = "present"
+	AttendanceStatusLate     = "late"
+	AttendanceStatusAbsent   = "absent"
+	AttendanceStatusExcused  = "excused"
+	AttendanceStatusRejected = "rejected" // Outside the check-in window, never stored
+)
+
+// Where an attendance record came from
+const (
+	AttendanceSourceSelf    = "self"
+	AttendanceSourceTeacher = "teacher"
+	AttendanceSourceKiosk   = "kiosk" // Uploaded by a check-in tablet, possibly after being offline
+)
+
+// How an attendance was linked to a student
+const (
+	MatchedByStudentCode = "student_code"
+	MatchedByEmail       = "email"
+	MatchedByPhone       = "phone"
+	MatchedByManual      = "manual"
+)
+
 // Attendance represents an attendance record in the system
 type Attendance struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
@@ -13,19 +37,78 @@ type Attendance struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	SessionID       *uint      `json:"session_id"`
-	CheckedInAt     *time.Time `json:"checked_in_at"`
-	StudentName     *string    `json:"student_name"`
-	Email           *string    `json:"email"`
-	Phone           *string    `json:"phone"`
-	WorkUnit        *string    `json:"work_unit"`
-	WorkUnitAddress *string    `json:"work_unit_address"`
+	SessionID       *uint       `json:"session_id" gorm:"index:idx_attendances_session_phone,priority:1"`
+	StudentID       *uint       `json:"student_id" gorm:"index"`
+	CheckedInAt     *time.Time  `json:"checked_in_at"`
+	CheckedOutAt    *time.Time  `json:"checked_out_at"`
+	DurationMinutes *int        `json:"duration_minutes"`
+	IsPartial       *bool       `json:"is_partial"` // Stayed less than the session's minimum duration
+	StudentName     *string     `json:"student_name"`
+	Email           *string     `json:"email"`
+	Phone           *string     `json:"phone"`
+	WorkUnit        *string     `json:"work_unit"`
+	WorkUnitAddress *string     `json:"work_unit_address"`
+	Answers         FormAnswers `json:"answers" gorm:"type:jsonb"` // Answers to the event's custom form fields
+	Status          *string     `json:"status" gorm:"type:varchar(20);default:'present'"`
+	Source          *string     `json:"source" gorm:"type:varchar(20);default:'self'"`
+	Note            *string     `json:"note"`
+	MarkedByUserID  *uint       `json:"marked_by_user_id"`
+	MarkedAt        *time.Time  `json:"marked_at"`
+
+	// Student matching: NeedsReview is set when a check-in could not be
+	// linked to exactly one student and must be resolved manually
+	MatchedBy   *string `json:"matched_by" gorm:"type:varchar(20)"`
+	NeedsReview *bool   `json:"needs_review" gorm:"default:false"`
+
+	// Normalized contact details used to detect duplicate check-ins per session.
+	// Unique indexes on (session_id, email_normalized) and (session_id,
+	// phone_dedupe_key) are created in migrations. PhoneDedupeKey holds the
+	// normalized phone only when the event dedupes by phone at check-in time.
+	EmailNormalized *string `json:"-"`
+	PhoneNormalized *string `json:"-" gorm:"index:idx_attendances_session_phone,priority:2"`
+	PhoneDedupeKey  *string `json:"-"`
+
+	// Kiosk sync: ClientID is generated by the tablet so that replayed uploads
+	// are recognized; CheckedInAt then holds the device time and SyncedAt the upload time
+	ClientID *string    `json:"client_id" gorm:"type:varchar(64);uniqueIndex"`
+	SyncedAt *time.Time `json:"synced_at"`
 
 	// Relationships
 	Session *AttendanceSession `json:"session,omitempty"`
+	Student *Student           `json:"student,omitempty"`
 }
 
 // TableName overrides the table name used by Attendance
 func (Attendance) TableName() string {
 	return "attendances"
 }
+
+// IsAttending reports whether the attendance counts the person as having
+// come to the session
+func (a *Attendance) IsAttending() bool {
+	return a.Status == nil || *a.Status == AttendanceStatusPresent || *a.Status == AttendanceStatusLate
+}
+
+// ApplyMark updates an existing attendance with a teacher's mark, linking it
+// to the marked student. A student marked absent or excused loses their
+// check-in and check-out times; one marked present keeps the time they
+// actually checked in.
+func (a *Attendance) ApplyMark(mark *Attendance) {
+	a.StudentID = mark.StudentID
+	a.MatchedBy = mark.MatchedBy
+	a.NeedsReview = mark.NeedsReview
+	a.Status = mark.Status
+	a.Note = mark.Note
+	a.MarkedByUserID = mark.MarkedByUserID
+	a.MarkedAt = mark.MarkedAt
+	if !a.IsAttending() {
+		a.CheckedInAt = nil
+		a.CheckedOutAt = nil
+		a.DurationMinutes = nil
+		a.IsPartial = nil
+		return
+	}
+	if a.CheckedInAt == nil {
+		a.CheckedInAt = mark.CheckedInAt
+	}
+}
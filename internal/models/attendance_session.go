@@ -17,11 +17,34 @@ type AttendanceSession struct {
 	ClassID     *uint      `json:"class_id"`
 	TeacherID   *uint      `json:"teacher_id"`
 	SessionDate *time.Time `json:"session_date"`
+	RoomID      *uint      `gorm:"index" json:"room_id"`
+	Location    *string    `json:"location"` // Address or room outside the room list, shown in calendar feeds
+
+	// Length of the session, used for calendar feeds and double-booking checks.
+	// Sessions without one last until ClosesAt, else MinDurationMinutes, else an hour.
+	DurationMinutes *int `json:"duration_minutes"`
+
+	// Check-in window: check-ins before OpensAt or after ClosesAt are rejected,
+	// check-ins after LateAfter are marked late. Nil bounds are not enforced.
+	OpensAt   *time.Time `json:"opens_at"`
+	LateAfter *time.Time `json:"late_after"`
+	ClosesAt  *time.Time `json:"closes_at"`
+
+	// Attendances checked out before this many minutes are flagged as partial
+	MinDurationMinutes *int `json:"min_duration_minutes"`
+
+	// Limits registrations, or check-ins for events without registration
+	// (nil = unlimited). The room's capacity applies as well.
+	Capacity *int `json:"capacity"`
+
+	// Set for sessions generated by a recurring series
+	SeriesID *uint `gorm:"index" json:"series_id"`
 
 	// Relationships
 	Event       *Event       `json:"event,omitempty"`
 	Class       *Class       `json:"class,omitempty"`
 	Teacher     *Teacher     `json:"teacher,omitempty"`
+	Room        *Room        `json:"room,omitempty"`
 	Attendances []Attendance `gorm:"foreignKey:SessionID" json:"attendances,omitempty"`
 }
 
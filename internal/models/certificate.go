@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Certificate is a certificate of attendance issued to one attendee of an
+// event. Rendering it again keeps the verification code and refreshes the
+// recipient details and hours.
+type Certificate struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	EventID uint `gorm:"not null;uniqueIndex:idx_certificates_event_attendee,priority:1" json:"event_id"`
+	// Identifies the attendee within the event: "student:<id>" or "email:<normalized email>"
+	AttendeeKey  string `gorm:"not null;uniqueIndex:idx_certificates_event_attendee,priority:2" json:"-"`
+	AttendanceID uint   `gorm:"not null" json:"attendance_id"` // First check-in of the attendee
+	StudentID    *uint  `json:"student_id"`
+
+	RecipientName string  `gorm:"not null" json:"recipient_name"`
+	Email         string  `json:"email"`
+	Dates         string  `json:"dates" example:"20/08/2025, 21/08/2025"`
+	Hours         float64 `json:"hours" example:"6.5"`
+
+	Code     string    `gorm:"type:varchar(32);uniqueIndex;not null" json:"code" example:"7KQ2M-XR4PD"`
+	IssuedAt time.Time `json:"issued_at"`
+
+	// Relationships
+	Event *Event `json:"event,omitempty"`
+}
+
+// TableName sets the table name for Certificate model
+func (Certificate) TableName() string {
+	return "certificates"
+}
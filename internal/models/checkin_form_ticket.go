@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// CheckinFormTicket records a form ticket handed out by the check-in page so
+// that it can be used for a single check-in
+type CheckinFormTicket struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	SessionID uint       `gorm:"not null;index" json:"session_id"`
+	Nonce     string     `gorm:"type:varchar(32);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"` // Set by the check-in the ticket was used for
+}
+
+// TableName sets the table name for CheckinFormTicket model
+func (CheckinFormTicket) TableName() string {
+	return "checkin_form_tickets"
+}
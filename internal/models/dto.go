@@ -2,7 +2,8 @@ package models
 
 import "time"
 
-// CreateStudentRequest represents the data needed to create a new student
+// CreateStudentRequest represents the data needed to create a new student;
+// updates use it too and keep the fields that are omitted
 type CreateStudentRequest struct {
 	StudentCode *string    `json:"student_code" example:"SV001"`
 	StudentName *string    `json:"student_name" example:"Nguyen Van A"`
@@ -13,7 +14,29 @@ type CreateStudentRequest struct {
 	DateOfBirth *time.Time `json:"date_of_birth" example:"2000-01-01T00:00:00Z"`
 }
 
-// CreateTeacherRequest represents the data needed to create a new teacher
+// EnrollStudentRequest enrolls a student in another class, e.g. a second
+// course section
+type EnrollStudentRequest struct {
+	ClassID      uint    `json:"class_id" binding:"required" example:"2"`
+	Role         string  `json:"role" example:"student"`             // student (default), auditor or assistant
+	EnrolledFrom *string `json:"enrolled_from" example:"2025-09-01"` // YYYY-MM-DD or RFC3339; defaults to today
+}
+
+// TransferStudentRequest moves a student from one class to another
+type TransferStudentRequest struct {
+	FromClassID   *uint   `json:"from_class_id" example:"1"` // Defaults to the student's main class
+	ToClassID     uint    `json:"to_class_id" binding:"required" example:"2"`
+	EffectiveDate *string `json:"effective_date" example:"2025-10-01"` // First day in the new class; defaults to today
+}
+
+// WithdrawStudentRequest ends a student's enrollment in a class
+type WithdrawStudentRequest struct {
+	ClassID       *uint   `json:"class_id" example:"1"`                // Defaults to the student's main class
+	EffectiveDate *string `json:"effective_date" example:"2025-10-01"` // First day no longer enrolled; defaults to today
+}
+
+// CreateTeacherRequest represents the data needed to create a new teacher;
+// updates use it too and keep the fields that are omitted
 type CreateTeacherRequest struct {
 	TeacherCode *string    `json:"teacher_code" example:"GV001"`
 	TeacherName *string    `json:"teacher_name" example:"Nguyen Thi B"`
@@ -29,6 +52,114 @@ type CreateAttendanceSessionRequest struct {
 	ClassID     *uint   `json:"class_id" example:"1"`
 	TeacherID   *string `json:"teacher_id,omitempty" example:"1"`
 	SessionDate *string `json:"session_date" example:"2025-08-20T08:31:46.121Z"`
+	RoomID      *uint   `json:"room_id,omitempty" example:"1"`
+	Location    *string `json:"location,omitempty" example:"Room A101"`
+
+	DurationMinutes *int `json:"duration_minutes,omitempty" example:"120"`
+	// Create the session even when it overlaps another session of the same teacher, class or room
+	Force bool `json:"force,omitempty" example:"false"`
+
+	OpensAt   *string `json:"opens_at,omitempty" example:"2025-08-20T08:15:00Z"`
+	LateAfter *string `json:"late_after,omitempty" example:"2025-08-20T08:40:00Z"`
+	ClosesAt  *string `json:"closes_at,omitempty" example:"2025-08-20T10:00:00Z"`
+
+	MinDurationMinutes *int `json:"min_duration_minutes,omitempty" example:"90"`
+	Capacity           *int `json:"capacity,omitempty" example:"40"`
+}
+
+// RoomRequest creates or changes a room; omitted fields are kept on update
+type RoomRequest struct {
+	Name     *string `json:"name" example:"A101"` // Required when creating a room
+	Building *string `json:"building,omitempty" example:"Building B"`
+	Capacity *int    `json:"capacity,omitempty" example:"60"`
+
+	Latitude  *float64 `json:"latitude,omitempty" example:"10.7769"`
+	Longitude *float64 `json:"longitude,omitempty" example:"106.7009"`
+}
+
+// SessionConflict describes an existing session that overlaps a session
+type SessionConflict struct {
+	SessionID   uint      `json:"session_id" example:"12"`
+	SessionDate time.Time `json:"session_date"`
+	EndsAt      time.Time `json:"ends_at"`
+	// What the sessions share: "teacher", "class" and/or "room"
+	Reasons []string `json:"reasons" example:"teacher"`
+	// When several sessions are planned at once (a series, a cloned event),
+	// the start of the planned session that overlaps this one
+	Occurrence *time.Time `json:"occurrence,omitempty"`
+}
+
+// SessionConflictPair is an entry of the conflicts report: two sessions that
+// overlap and share a teacher, class or room. In a teacher's report the
+// other session's ID is omitted when it belongs to another teacher.
+type SessionConflictPair struct {
+	SessionID      uint      `json:"session_id" example:"12"`
+	OtherSessionID uint      `json:"other_session_id,omitempty" example:"15"`
+	OverlapStart   time.Time `json:"overlap_start"`
+	OverlapEnd     time.Time `json:"overlap_end"`
+	Reasons        []string  `json:"reasons" example:"teacher"`
+}
+
+// CreateSessionSeriesRequest represents a recurring series of attendance sessions
+type CreateSessionSeriesRequest struct {
+	EventID   *uint `json:"event_id" example:"1"`
+	ClassID   *uint `json:"class_id" example:"1"`
+	TeacherID *uint `json:"teacher_id,omitempty" example:"1"`
+
+	StartsAt time.Time `json:"starts_at" binding:"required" example:"2025-09-01T08:00:00+07:00"` // First occurrence
+	Timezone string    `json:"timezone,omitempty" example:"Asia/Ho_Chi_Minh"`                    // Defaults to APP_TIMEZONE
+	RRule    string    `json:"rrule" binding:"required" example:"FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20251220T235959Z"`
+	ExDates  []string  `json:"exdates,omitempty" example:"2025-09-02"` // Days to skip (YYYY-MM-DD)
+
+	OpensBeforeMinutes *int `json:"opens_before_minutes,omitempty" example:"15"`
+	LateAfterMinutes   *int `json:"late_after_minutes,omitempty" example:"10"`
+	ClosesAfterMinutes *int `json:"closes_after_minutes,omitempty" example:"90"`
+	DurationMinutes    *int `json:"duration_minutes,omitempty" example:"120"`
+	MinDurationMinutes *int `json:"min_duration_minutes,omitempty" example:"90"`
+	Capacity           *int `json:"capacity,omitempty" example:"40"`
+
+	RoomID   *uint   `json:"room_id,omitempty" example:"1"`
+	Location *string `json:"location,omitempty" example:"Room A101"`
+
+	// Create the series even when occurrences overlap other sessions of the same teacher, class or room
+	Force bool `json:"force,omitempty" example:"false"`
+}
+
+// UpdateSessionSeriesRequest changes a series; omitted fields are kept.
+// Changes apply to occurrences that have not started yet.
+type UpdateSessionSeriesRequest struct {
+	ClassID   *uint `json:"class_id,omitempty" example:"1"`
+	TeacherID *uint `json:"teacher_id,omitempty" example:"1"`
+
+	StartsAt *time.Time `json:"starts_at,omitempty" example:"2025-09-01T09:00:00+07:00"`
+	Timezone *string    `json:"timezone,omitempty" example:"Asia/Ho_Chi_Minh"`
+	RRule    *string    `json:"rrule,omitempty" example:"FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20251220T235959Z"`
+	ExDates  *[]string  `json:"exdates,omitempty" example:"2025-09-02"` // Replaces the skipped days
+
+	OpensBeforeMinutes *int `json:"opens_before_minutes,omitempty" example:"15"`
+	LateAfterMinutes   *int `json:"late_after_minutes,omitempty" example:"10"`
+	ClosesAfterMinutes *int `json:"closes_after_minutes,omitempty" example:"90"`
+	DurationMinutes    *int `json:"duration_minutes,omitempty" example:"120"`
+	MinDurationMinutes *int `json:"min_duration_minutes,omitempty" example:"90"`
+	Capacity           *int `json:"capacity,omitempty" example:"40"`
+
+	RoomID   *uint   `json:"room_id,omitempty" example:"1"`
+	Location *string `json:"location,omitempty" example:"Room A101"`
+
+	// Apply the change even when occurrences overlap other sessions of the same teacher, class or room
+	Force bool `json:"force,omitempty" example:"false"`
+}
+
+// SessionSeriesChanges summarizes what an edit or cancellation did to the
+// upcoming sessions of a series
+type SessionSeriesChanges struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+	// Upcoming sessions that already have attendances are never removed
+	Kept []uint `json:"kept"`
+	// Overlapping sessions, when the change was forced through
+	Conflicts []SessionConflict `json:"conflicts,omitempty"`
 }
 
 // CreateEventRequest represents the data needed to create a new event
@@ -36,6 +167,36 @@ type CreateEventRequest struct {
 	EventName   *string    `json:"event_name" example:"Workshop AI"`
 	Description *string    `json:"description" example:"Workshop về trí tuệ nhân tạo"`
 	StartDate   *time.Time `json:"start_date" example:"2023-01-01T00:00:00Z"`
+	EndDate     *time.Time `json:"end_date,omitempty" example:"2023-01-03T00:00:00Z"`
+
+	CheckinTokenTTLSeconds  *int `json:"checkin_token_ttl_seconds,omitempty" example:"30"`
+	CheckinTokenSkewSeconds *int `json:"checkin_token_skew_seconds,omitempty" example:"5"`
+
+	DedupeByPhone *bool `json:"dedupe_by_phone,omitempty" example:"false"`
+
+	Capacity            *int  `json:"capacity,omitempty" example:"100"`
+	RequireRegistration *bool `json:"require_registration,omitempty" example:"false"`
+
+	// Replaces the event's custom check-in form fields; an empty list restores the default work unit fields
+	FormSchema *FormSchema `json:"form_schema,omitempty"`
+
+	CertificateTemplate *string  `json:"certificate_template,omitempty" example:"This is to certify that {name} attended {event} on {dates}, for a total of {hours} hours."`
+	CertificateMinHours *float64 `json:"certificate_min_hours,omitempty" example:"4"`
+}
+
+// CloneEventRequest copies an event and its sessions to new dates. Give either
+// start_date, to move the event's start there, or offset_days.
+type CloneEventRequest struct {
+	EventName  *string    `json:"event_name,omitempty" example:"Workshop AI - Spring 2026"` // Defaults to the original name with " (copy)"
+	StartDate  *time.Time `json:"start_date,omitempty" example:"2026-02-02T08:00:00+07:00"`
+	OffsetDays *int       `json:"offset_days,omitempty" example:"182"`
+	// Clone even when the moved sessions overlap other sessions of the same teacher, class or room
+	Force bool `json:"force,omitempty" example:"false"`
+}
+
+// UpdateEventStatusRequest moves an event to another lifecycle status
+type UpdateEventStatusRequest struct {
+	Status string `json:"status" binding:"required" example:"published"` // draft, published, ongoing, closed or archived
 }
 
 // CreateClassRequest represents the data needed to create a new class
@@ -44,12 +205,188 @@ type CreateClassRequest struct {
 	ClassName string `json:"class_name" binding:"required" example:"Lớp Khoa học máy tính K65"`
 }
 
-// CreateAttendanceRequest represents the data needed to create a new attendance
+// UpdateClassRequest changes a class; omitted fields are kept
+type UpdateClassRequest struct {
+	ClassCode *string `json:"class_code,omitempty" example:"LOP001"`
+	ClassName *string `json:"class_name,omitempty" example:"Lớp Khoa học máy tính K65"`
+}
+
+// CreateAttendanceRequest represents the data needed to create a new attendance;
+// the form tags are used by the server-rendered check-in page
 type CreateAttendanceRequest struct {
-	SessionID       uint   `json:"session_id" binding:"required" example:"1"`
-	StudentName     string `json:"student_name" binding:"required" example:"Nguyen Van A"`
-	Email           string `json:"email" binding:"required" example:"student@example.com"`
-	Phone           string `json:"phone" binding:"required" example:"0123456789"`
-	WorkUnit        string `json:"work_unit" binding:"required" example:"Công ty ABC"`
-	WorkUnitAddress string `json:"work_unit_address" binding:"required" example:"123 Đường ABC, Quận 1, TP.HCM"`
+	SessionID       uint   `json:"session_id" form:"session_id" binding:"required" example:"1"`
+	CheckinToken    string `json:"checkin_token" form:"checkin_token" binding:"required" example:"57812345.q8Xk3v0dUe9pQm1sT2aZ4w"`
+	StudentCode     string `json:"student_code,omitempty" form:"student_code" example:"SV001"`
+	StudentName     string `json:"student_name" form:"student_name" binding:"required" example:"Nguyen Van A"`
+	Email           string `json:"email" form:"email" binding:"required" example:"student@example.com"`
+	Phone           string `json:"phone" form:"phone" binding:"required" example:"0123456789"`
+	WorkUnit        string `json:"work_unit,omitempty" form:"work_unit" example:"Công ty ABC"`                                   // Required when the event has no form schema
+	WorkUnitAddress string `json:"work_unit_address,omitempty" form:"work_unit_address" example:"123 Đường ABC, Quận 1, TP.HCM"` // Required when the event has no form schema
+
+	// Answers to the event's custom form fields by field key
+	Answers FormAnswers `json:"answers,omitempty" form:"-"`
+}
+
+// LoginRequest represents the credentials used to obtain API tokens
+type LoginRequest struct {
+	Username string `json:"username" binding:"required" example:"admin"`
+	Password string `json:"password" binding:"required" example:"secret"`
+}
+
+// RefreshTokenRequest represents the data needed to refresh an access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// TokenResponse represents a pair of issued API tokens
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"`
+}
+
+// CheckinTokenResponse represents the current rotating check-in token of a session
+type CheckinTokenResponse struct {
+	SessionID  uint      `json:"session_id" example:"1"`
+	Token      string    `json:"token" example:"57812345.q8Xk3v0dUe9pQm1sT2aZ4w"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	TTLSeconds int       `json:"ttl_seconds" example:"30"`
+	CheckinURL string    `json:"checkin_url" example:"http://localhost:8080/checkin/1?token=57812345.q8Xk3v0dUe9pQm1sT2aZ4w"`
+}
+
+// CalendarFeedResponse represents the subscription link of an .ics calendar feed
+type CalendarFeedResponse struct {
+	Scope     string `json:"scope" example:"events"`
+	ID        uint   `json:"id" example:"1"`
+	URL       string `json:"url" example:"http://localhost:8080/api/calendar/events/1.ics?token=q8Xk3v0dUe9pQm1sT2aZ4w"`
+	WebcalURL string `json:"webcal_url" example:"webcal://localhost:8080/api/calendar/events/1.ics?token=q8Xk3v0dUe9pQm1sT2aZ4w"`
+}
+
+// ResolveAttendanceStudentRequest represents a manual link between a check-in and a student
+type ResolveAttendanceStudentRequest struct {
+	StudentID uint `json:"student_id" binding:"required" example:"1"`
+}
+
+// AttendanceMark represents a teacher's mark for one student in a session
+type AttendanceMark struct {
+	StudentID uint    `json:"student_id" binding:"required" example:"1"`
+	Status    string  `json:"status" binding:"required,oneof=present absent excused late" example:"present"`
+	Note      *string `json:"note,omitempty" example:"Đến muộn do kẹt xe"`
+}
+
+// BulkMarkAttendanceRequest represents the marks for a session's class roster
+type BulkMarkAttendanceRequest struct {
+	Marks []AttendanceMark `json:"marks" binding:"required,min=1,dive"`
+}
+
+// CheckoutRequest represents an attendee checking themselves out of a session
+type CheckoutRequest struct {
+	SessionID    uint   `json:"session_id" binding:"required" example:"1"`
+	Email        string `json:"email" binding:"required" example:"student@example.com"`
+	CheckinToken string `json:"checkin_token" binding:"required" example:"57812345.q8Xk3v0dUe9pQm1sT2aZ4w"`
+}
+
+// AttendeeReport is the attended time of one person across an event's sessions
+type AttendeeReport struct {
+	StudentID        *uint  `json:"student_id"`
+	StudentName      string `json:"student_name"`
+	Email            string `json:"email"`
+	SessionsAttended int    `json:"sessions_attended"`
+	TotalMinutes     int    `json:"total_minutes"`
+	PartialSessions  int    `json:"partial_sessions"`
+	NotCheckedOut    int    `json:"not_checked_out"`
+}
+
+// CertificateAttendee is one person's attendance across an event's sessions,
+// used to decide who gets a certificate and what it says
+type CertificateAttendee struct {
+	Key             string // "student:<id>" or "email:<normalized email>"
+	AttendanceID    uint   // First check-in
+	StudentID       *uint
+	Name            string
+	Email           string
+	Dates           []time.Time // Attended session days, in order
+	TotalMinutes    int
+	PartialSessions int
+}
+
+// CertificateVerification is the public view of a certificate, without contact details
+type CertificateVerification struct {
+	Code          string    `json:"code" example:"7KQ2M-XR4PD"`
+	RecipientName string    `json:"recipient_name" example:"Nguyen Van A"`
+	EventName     string    `json:"event_name" example:"Workshop AI"`
+	Dates         string    `json:"dates" example:"20/08/2025"`
+	Hours         float64   `json:"hours" example:"6.5"`
+	IssuedAt      time.Time `json:"issued_at"`
+}
+
+// CreateExcuseRequest represents a student's excuse for missing a session.
+// It is sent as multipart/form-data so that an attachment can be included.
+type CreateExcuseRequest struct {
+	SessionID uint   `form:"session_id" json:"session_id" binding:"required" example:"1"`
+	StudentID uint   `form:"student_id" json:"student_id" example:"1"` // Ignored for student accounts
+	Reason    string `form:"reason" json:"reason" binding:"required" example:"Nghỉ ốm, có giấy khám bệnh"`
+}
+
+// ReviewExcuseRequest represents a teacher's decision on an excuse request
+type ReviewExcuseRequest struct {
+	Note *string `json:"note,omitempty" example:"Đã xem giấy khám bệnh"`
+}
+
+// ExcuseFilter narrows down an excuse request listing
+type ExcuseFilter struct {
+	Status    string
+	SessionID *uint
+	StudentID *uint
+	TeacherID *uint // Only requests for this teacher's sessions
+}
+
+// CreateRegistrationRequest represents a person registering for an event or one of its sessions
+type CreateRegistrationRequest struct {
+	SessionID *uint  `json:"session_id,omitempty" example:"1"` // Omit to register for the whole event
+	FullName  string `json:"full_name" binding:"required" example:"Nguyen Van A"`
+	Email     string `json:"email" binding:"required,email" example:"student@example.com"`
+	Phone     string `json:"phone,omitempty" example:"0123456789"`
+}
+
+// CancelRegistrationRequest represents a registrant cancelling with the token they received
+type CancelRegistrationRequest struct {
+	CancelToken string `json:"cancel_token" binding:"required" example:"3f2a9c0e8b7d4f1a6c5e2d9b8a7f6e5d"`
+}
+
+// Kiosk batch sync item results
+const (
+	BatchResultCreated   = "created"
+	BatchResultDuplicate = "duplicate"
+	BatchResultRejected  = "rejected"
+)
+
+// BatchAttendanceItem is one check-in recorded by a kiosk, possibly while offline
+type BatchAttendanceItem struct {
+	ClientID        string    `json:"client_id" binding:"required,uuid" example:"6f1c2a9e-4b7d-4e8a-9c3f-1d2e3f4a5b6c"`
+	CheckedInAt     time.Time `json:"checked_in_at" binding:"required" example:"2025-08-20T08:31:46Z"` // Device time of the check-in
+	SessionID       uint      `json:"session_id" binding:"required" example:"1"`
+	StudentCode     string    `json:"student_code,omitempty" example:"SV001"`
+	StudentName     string    `json:"student_name" binding:"required" example:"Nguyen Van A"`
+	Email           string    `json:"email" binding:"required" example:"student@example.com"`
+	Phone           string    `json:"phone" binding:"required" example:"0123456789"`
+	WorkUnit        string    `json:"work_unit,omitempty" example:"Công ty ABC"`
+	WorkUnitAddress string    `json:"work_unit_address,omitempty" example:"123 Đường ABC, Quận 1, TP.HCM"`
+
+	Answers FormAnswers `json:"answers,omitempty"`
+}
+
+// BatchAttendanceRequest represents the check-ins uploaded by a kiosk when it reconnects.
+// Items are validated one by one so that a bad item does not fail the whole batch.
+type BatchAttendanceRequest struct {
+	Items []BatchAttendanceItem `json:"items" binding:"required,min=1,max=500"`
+}
+
+// BatchItemResult is the outcome of one uploaded check-in
+type BatchItemResult struct {
+	ClientID   string      `json:"client_id"`
+	Result     string      `json:"result" example:"created"`
+	Reason     string      `json:"reason,omitempty"`
+	Attendance *Attendance `json:"attendance,omitempty"`
 }
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Enrollment roles
+const (
+	EnrollmentRoleStudent   = "student"
+	EnrollmentRoleAuditor   = "auditor"
+	EnrollmentRoleAssistant = "assistant"
+)
+
+// Why an enrollment ended
+const (
+	EnrollmentEndTransferred = "transferred"
+	EnrollmentEndWithdrawn   = "withdrawn"
+)
+
+// Enrollment places a student in a class from EnrolledFrom until EnrolledTo
+// (exclusive; nil while still enrolled). A student may be enrolled in several
+// classes at once, and rosters of past sessions use the enrollments that were
+// open on the session date.
+type Enrollment struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	StudentID    uint       `gorm:"not null;index" json:"student_id"`
+	ClassID      uint       `gorm:"not null;index" json:"class_id"`
+	Role         string     `gorm:"type:varchar(20);not null;default:'student'" json:"role" example:"student"`
+	EnrolledFrom time.Time  `gorm:"not null" json:"enrolled_from"`
+	EnrolledTo   *time.Time `json:"enrolled_to"`
+	EndReason    *string    `gorm:"type:varchar(20)" json:"end_reason" example:"transferred"`
+
+	// Relationships
+	Student *Student `json:"student,omitempty"`
+	Class   *Class   `json:"class,omitempty"`
+}
+
+// TableName sets the table name for Enrollment model
+func (Enrollment) TableName() string {
+	return "enrollments"
+}
+
+// IsEnrollmentRole reports whether role is a known enrollment role
+func IsEnrollmentRole(role string) bool {
+	switch role {
+	case EnrollmentRoleStudent, EnrollmentRoleAuditor, EnrollmentRoleAssistant:
+		return true
+	}
+	return false
+}
+
+// ActiveOn reports whether the enrollment was open at t
+func (e *Enrollment) ActiveOn(t time.Time) bool {
+	return !e.EnrolledFrom.After(t) && (e.EnrolledTo == nil || e.EnrolledTo.After(t))
+}
@@ -16,11 +16,46 @@ type Event struct {
 	EventName   *string    `json:"event_name"`
 	Description *string    `json:"description"`
 	StartDate   *time.Time `json:"start_date"`
-	IsActive    *bool      `json:"is_active" gorm:"default:true"`
+	EndDate     *time.Time `json:"end_date"`
+
+	// Lifecycle: draft -> published -> ongoing -> closed -> archived (see EventService.SetEventStatus).
+	// Attendees can only check in while the event is ongoing. Published events
+	// become ongoing at StartDate and are closed at EndDate by the scheduler.
+	Status          string     `json:"status" gorm:"type:varchar(20);not null;default:'draft';index" example:"draft"`
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+
+	// Rotating QR check-in token settings, in seconds (nil = server default)
+	CheckinTokenTTLSeconds  *int `json:"checkin_token_ttl_seconds"`
+	CheckinTokenSkewSeconds *int `json:"checkin_token_skew_seconds"`
+
+	// Also treat check-ins with the same phone number as duplicates (email always is)
+	DedupeByPhone *bool `json:"dedupe_by_phone" gorm:"default:false"`
+
+	// Registration: Capacity limits event-wide registrations (nil = unlimited),
+	// RequireRegistration rejects check-ins from people who did not register
+	Capacity            *int  `json:"capacity"`
+	RequireRegistration *bool `json:"require_registration" gorm:"default:false"`
+
+	// Custom check-in form fields; when empty the form asks for the work unit instead
+	FormSchema FormSchema `json:"form_schema" gorm:"type:jsonb"`
+
+	// Certificates of attendance: the template text takes the placeholders {name},
+	// {event}, {dates} and {hours}; attendees need CertificateMinHours when set
+	CertificateTemplate *string  `json:"certificate_template" gorm:"type:text"`
+	CertificateMinHours *float64 `json:"certificate_min_hours"`
 
 	Sessions []AttendanceSession `gorm:"foreignKey:EventID" json:"sessions,omitempty"`
 }
 
+// Event statuses
+const (
+	EventStatusDraft     = "draft"     // Being prepared, not visible to attendees yet
+	EventStatusPublished = "published" // Announced; registrations are open
+	EventStatusOngoing   = "ongoing"   // Taking place; check-ins are accepted
+	EventStatusClosed    = "closed"    // Finished; no more check-ins
+	EventStatusArchived  = "archived"  // Kept for records only, cannot change anymore
+)
+
 func (Event) TableName() string {
 	return "events"
 }
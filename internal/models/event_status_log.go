@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Sources of event status changes
+const (
+	EventStatusSourceManual    = "manual"    // Changed through the API
+	EventStatusSourceScheduler = "scheduler" // Changed automatically at the event's start or end date
+)
+
+// EventStatusLog records a change of an event's status
+type EventStatusLog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	EventID    uint   `gorm:"not null;index" json:"event_id"`
+	FromStatus string `gorm:"type:varchar(20);not null" json:"from_status" example:"published"`
+	ToStatus   string `gorm:"type:varchar(20);not null" json:"to_status" example:"ongoing"`
+	Source     string `gorm:"type:varchar(20);not null" json:"source" example:"scheduler"`
+	Reason     string `json:"reason" example:"start_date reached"`
+}
+
+// TableName sets the table name for EventStatusLog model
+func (EventStatusLog) TableName() string {
+	return "event_status_logs"
+}
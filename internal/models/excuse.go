@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Excuse request statuses
+const (
+	ExcuseStatusPending  = "pending"
+	ExcuseStatusApproved = "approved"
+	ExcuseStatusRejected = "rejected"
+)
+
+// Actions recorded in an excuse request's history
+const (
+	ExcuseActionSubmitted = "submitted"
+	ExcuseActionApproved  = "approved"
+	ExcuseActionRejected  = "rejected"
+)
+
+// ExcuseRequest is a student's explanation for missing an attendance session.
+// It is reviewed by the session's teacher; an approved excuse shows the
+// student as excused on the session roster.
+type ExcuseRequest struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	SessionID uint   `gorm:"not null;index" json:"session_id"`
+	StudentID uint   `gorm:"not null;index" json:"student_id"`
+	Reason    string `gorm:"type:text;not null" json:"reason"`
+	Status    string `gorm:"type:varchar(20);not null;default:'pending';index" json:"status" example:"pending"`
+
+	// Optional supporting document, stored under UPLOAD_DIR
+	AttachmentPath *string `json:"-"`
+	AttachmentName *string `json:"attachment_name"`
+
+	SubmittedByUserID *uint      `json:"submitted_by_user_id"`
+	ReviewedByUserID  *uint      `json:"reviewed_by_user_id"`
+	ReviewedAt        *time.Time `json:"reviewed_at"`
+	ReviewNote        *string    `json:"review_note"`
+
+	// Relationships
+	Session *AttendanceSession   `json:"session,omitempty"`
+	Student *Student             `json:"student,omitempty"`
+	History []ExcuseRequestEvent `gorm:"foreignKey:ExcuseRequestID" json:"history,omitempty"`
+}
+
+// TableName sets the table name for ExcuseRequest model
+func (ExcuseRequest) TableName() string {
+	return "excuse_requests"
+}
+
+// ExcuseRequestEvent is one entry in the history of an excuse request
+type ExcuseRequestEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ExcuseRequestID uint    `gorm:"not null;index" json:"excuse_request_id"`
+	Action          string  `gorm:"type:varchar(20);not null" json:"action" example:"submitted"`
+	FromStatus      *string `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus        string  `gorm:"type:varchar(20);not null" json:"to_status"`
+	ActorUserID     *uint   `json:"actor_user_id"`
+	Note            *string `json:"note"`
+}
+
+// TableName sets the table name for ExcuseRequestEvent model
+func (ExcuseRequestEvent) TableName() string {
+	return "excuse_request_events"
+}
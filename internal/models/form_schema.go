@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Check-in form field types
+const (
+	FormFieldText        = "text"
+	FormFieldTextarea    = "textarea"
+	FormFieldNumber      = "number"
+	FormFieldEmail       = "email"
+	FormFieldPhone       = "phone"
+	FormFieldDate        = "date" // YYYY-MM-DD
+	FormFieldCheckbox    = "checkbox"
+	FormFieldSelect      = "select"      // One of Choices
+	FormFieldMultiSelect = "multiselect" // Any of Choices
+)
+
+// FormField is one custom question on an event's check-in form
+type FormField struct {
+	Key      string   `json:"key" example:"faculty"` // Answers are stored under this key
+	Label    string   `json:"label" example:"Faculty"`
+	Type     string   `json:"type" example:"select"`
+	Required bool     `json:"required" example:"true"`
+	Choices  []string `json:"choices,omitempty" example:"Computer Science,Mathematics"`
+}
+
+// HasChoices reports whether answers must be picked from Choices
+func (f FormField) HasChoices() bool {
+	return f.Type == FormFieldSelect || f.Type == FormFieldMultiSelect
+}
+
+// FormSchema lists the custom fields of an event's check-in form, in display order.
+// It is stored as JSONB.
+type FormSchema []FormField
+
+// Value implements driver.Valuer
+func (s FormSchema) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner
+func (s *FormSchema) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+// FormAnswers holds the answers to an event's custom form fields by field key.
+// Values are strings, numbers, booleans or string lists depending on the field type.
+// It is stored as JSONB.
+type FormAnswers map[string]interface{}
+
+// Value implements driver.Valuer
+func (a FormAnswers) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner
+func (a *FormAnswers) Scan(value interface{}) error {
+	return scanJSON(value, a)
+}
+
+func scanJSON(value interface{}, target interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, target)
+	case string:
+		return json.Unmarshal([]byte(v), target)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", value, target)
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// IdempotencyRecord stores the first response to a POST request sent with an
+// Idempotency-Key header so that retries with the same key can be replayed
+type IdempotencyRecord struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Keys are scoped per user ("user:<id>", or "anonymous" on public routes) and endpoint
+	Scope       string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_scope_key" json:"scope"`
+	Key         string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_scope_key" json:"key"`
+	RequestHash string    `gorm:"type:varchar(64);not null" json:"request_hash"`
+	ExpiresAt   time.Time `gorm:"not null;index" json:"expires_at"`
+
+	// Completed is false while the first request is still being handled
+	Completed    bool   `gorm:"not null;default:false" json:"completed"`
+	StatusCode   int    `json:"status_code"`
+	ContentType  string `json:"content_type"`
+	ResponseBody []byte `json:"-"`
+}
+
+// TableName sets the table name for IdempotencyRecord model
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_records"
+}
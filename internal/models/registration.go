@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Registration statuses
+const (
+	RegistrationStatusRegistered = "registered"
+	RegistrationStatusWaitlisted = "waitlisted"
+	RegistrationStatusCancelled  = "cancelled"
+)
+
+// Registration is a person's pre-registration for an event, or for a single
+// session of it when SessionID is set. Registrations beyond the capacity are
+// waitlisted and promoted in order when a place frees up.
+type Registration struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	EventID   uint    `gorm:"not null;index" json:"event_id"`
+	SessionID *uint   `gorm:"index" json:"session_id"`
+	FullName  string  `gorm:"not null" json:"full_name"`
+	Email     string  `gorm:"not null" json:"email"`
+	Phone     *string `json:"phone"`
+	Status    string  `gorm:"type:varchar(20);not null;default:'registered';index" json:"status" example:"registered"`
+
+	// Used to match check-ins against registrations
+	EmailNormalized string  `gorm:"not null" json:"-"`
+	PhoneNormalized *string `json:"-"`
+
+	// Secret handed to the registrant so they can cancel without an account
+	CancelToken string `gorm:"uniqueIndex;not null" json:"-"`
+
+	PromotedAt  *time.Time `json:"promoted_at"` // Moved from the waitlist to registered
+	CancelledAt *time.Time `json:"cancelled_at"`
+
+	// Relationships
+	Event   *Event             `json:"event,omitempty"`
+	Session *AttendanceSession `json:"session,omitempty"`
+}
+
+// TableName sets the table name for Registration model
+func (Registration) TableName() string {
+	return "registrations"
+}
+
+// IsActive reports whether the registration still holds or waits for a place
+func (r *Registration) IsActive() bool {
+	return r.Status == RegistrationStatusRegistered || r.Status == RegistrationStatusWaitlisted
+}
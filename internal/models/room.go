@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Room is a bookable place where sessions take place. Its capacity caps the
+// sessions held in it, and its coordinates are published in calendar feeds.
+type Room struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Name     string  `gorm:"type:varchar(100);not null" json:"name" example:"A101"`
+	Building *string `json:"building" example:"Building B"`
+	Capacity *int    `json:"capacity" example:"60"` // nil = unlimited
+
+	Latitude  *float64 `json:"latitude" example:"10.7769"`
+	Longitude *float64 `json:"longitude" example:"106.7009"`
+}
+
+// TableName sets the table name for Room model
+func (Room) TableName() string {
+	return "rooms"
+}
+
+// Label is the room name followed by its building, as shown in calendars
+func (r *Room) Label() string {
+	if r.Building == nil || strings.TrimSpace(*r.Building) == "" {
+		return r.Name
+	}
+	return r.Name + ", " + *r.Building
+}
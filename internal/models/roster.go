@@ -0,0 +1,20 @@
+package models
+
+// RosterEntry is the attendance status of one class student in a session
+type RosterEntry struct {
+	Student    Student     `json:"student"`
+	Role       string      `json:"role,omitempty" example:"student"` // Enrollment role in the class
+	Status     string      `json:"status" example:"present"`
+	Attendance *Attendance `json:"attendance,omitempty"`
+}
+
+// SessionRoster lists every student of a session's class with their status,
+// plus check-ins that still need to be matched to a student
+type SessionRoster struct {
+	SessionID uint           `json:"session_id"`
+	ClassID   *uint          `json:"class_id"`
+	Entries   []RosterEntry  `json:"entries"`
+	Unmatched []Attendance   `json:"unmatched"`
+	Guests    []Attendance   `json:"guests"` // Matched to students outside the class
+	Summary   map[string]int `json:"summary"`
+}
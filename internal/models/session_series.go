@@ -0,0 +1,68 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SessionSeries generates recurring attendance sessions of an event from an
+// RFC 5545 recurrence rule. Each occurrence becomes an AttendanceSession
+// linked back through SeriesID; the check-in window of each session is set
+// relative to its start.
+type SessionSeries struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	EventID   *uint `gorm:"index" json:"event_id"`
+	ClassID   *uint `json:"class_id"`
+	TeacherID *uint `gorm:"index" json:"teacher_id"`
+
+	// First occurrence; its time of day is kept for every occurrence in Timezone
+	StartsAt time.Time  `gorm:"not null" json:"starts_at"`
+	Timezone string     `gorm:"type:varchar(64);not null" json:"timezone" example:"Asia/Ho_Chi_Minh"`
+	RRule    string     `gorm:"not null" json:"rrule" example:"FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20251220T235959Z"`
+	ExDates  StringList `gorm:"type:jsonb" json:"exdates" swaggertype:"array,string" example:"2025-09-02"` // Skipped days (YYYY-MM-DD)
+
+	// Check-in window of each occurrence, in minutes relative to its start
+	OpensBeforeMinutes *int `json:"opens_before_minutes" example:"15"`
+	LateAfterMinutes   *int `json:"late_after_minutes" example:"10"`
+	ClosesAfterMinutes *int `json:"closes_after_minutes" example:"90"`
+
+	DurationMinutes    *int `json:"duration_minutes" example:"120"`
+	MinDurationMinutes *int `json:"min_duration_minutes"`
+	Capacity           *int `json:"capacity"`
+
+	RoomID   *uint   `json:"room_id" example:"1"`          // Copied to every session
+	Location *string `json:"location" example:"Room A101"` // Copied to every session
+
+	CancelledAt *time.Time `json:"cancelled_at"`
+
+	// Relationships
+	Sessions []AttendanceSession `gorm:"foreignKey:SeriesID" json:"sessions,omitempty"`
+}
+
+// TableName sets the table name for SessionSeries model
+func (SessionSeries) TableName() string {
+	return "session_series"
+}
+
+// StringList is a list of strings stored as JSONB
+type StringList []string
+
+// Value implements driver.Valuer
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
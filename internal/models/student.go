@@ -15,14 +15,15 @@ type Student struct {
 
 	StudentCode *string    `json:"student_code"`
 	StudentName *string    `json:"student_name"`
-	ClassID     *uint      `json:"class_id"`
+	ClassID     *uint      `json:"class_id"` // Main class, kept in sync by transfers and withdrawals
 	Phone       *string    `json:"phone"`
 	Email       *string    `json:"email"`
 	WorkUnit    *string    `json:"work_unit"`
 	DateOfBirth *time.Time `json:"date_of_birth"`
 
 	// Relationships
-	Class *Class `json:"class,omitempty"`
+	Class       *Class       `json:"class,omitempty"`
+	Enrollments []Enrollment `gorm:"foreignKey:StudentID" json:"enrollments,omitempty"`
 }
 
 // TableName sets the table name for Student model
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TrashItem is a soft-deleted record as listed in the trash
+type TrashItem struct {
+	ID        uint       `json:"id"`
+	Label     string     `json:"label" example:"Go Workshop"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at"` // When the automatic purge removes it; nil when disabled
+}
+
+// TrashRestoreResponse reports a restored record and how many of the records
+// deleted together with it were restored, per table
+type TrashRestoreResponse struct {
+	Resource string           `json:"resource" example:"events"`
+	ID       uint             `json:"id" example:"1"`
+	Restored map[string]int64 `json:"restored"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// Token types carried in the "typ" claim of issued JWTs
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Roles that can be assigned to a user
+const (
+	RoleAdmin     = "admin"
+	RoleOrganizer = "organizer"
+	RoleTeacher   = "teacher"
+	RoleKiosk     = "kiosk"
+	RoleStudent   = "student"
+)
+
+// IsValidRole reports whether role is one of the known roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleOrganizer, RoleTeacher, RoleKiosk, RoleStudent:
+		return true
+	}
+	return false
+}
+
+// User represents an account that can log in to the API
+type User struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Username     string  `gorm:"uniqueIndex;not null" json:"username"`
+	PasswordHash string  `gorm:"not null" json:"-"`
+	FullName     *string `json:"full_name"`
+	Email        *string `json:"email"`
+	IsActive     *bool   `json:"is_active" gorm:"default:true"`
+
+	// Accounts created before roles existed had full access, so they default to admin
+	Role      string `gorm:"type:varchar(20);not null;default:'admin'" json:"role"`
+	TeacherID *uint  `json:"teacher_id"` // Set for teacher accounts
+	StudentID *uint  `json:"student_id"` // Set for student accounts
+
+	// Relationships
+	Teacher *Teacher `json:"teacher,omitempty"`
+	Student *Student `json:"student,omitempty"`
+}
+
+// TableName sets the table name for User model
+func (User) TableName() string {
+	return "users"
+}
+
+// AuthClaims are the claims embedded in access and refresh tokens
+type AuthClaims struct {
+	UserID    uint   `json:"uid"`
+	Username  string `json:"username"`
+	TokenType string `json:"typ"`
+	Role      string `json:"role"`
+	TeacherID *uint  `json:"teacher_id,omitempty"`
+	StudentID *uint  `json:"student_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasRole reports whether the claims carry one of the given roles
+func (c *AuthClaims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnsTeacherID reports whether a teacher account is linked to the given teacher
+func (c *AuthClaims) OwnsTeacherID(teacherID *uint) bool {
+	return c.TeacherID != nil && teacherID != nil && *c.TeacherID == *teacherID
+}
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"errors"
 	"hello-gin/config"
 	"hello-gin/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func GetAllAttendances() ([]models.Attendance, error) {
@@ -13,7 +17,7 @@ func GetAllAttendances() ([]models.Attendance, error) {
 
 func GetAttendanceByID(id int) (*models.Attendance, error) {
 	var attendance models.Attendance
-	result := config.DB.Preload("Session").Preload("Session.Class").Preload("Session.Teacher").First(&attendance, id)
+	result := config.DB.Preload("Session").Preload("Session.Class").Preload("Session.Teacher").Preload("Student").First(&attendance, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -39,7 +43,152 @@ func GetAttendancesByEventID(eventID uint) ([]models.Attendance, error) {
 	return attendances, result.Error
 }
 
+func GetAttendancesByTeacherID(teacherID uint) ([]models.Attendance, error) {
+	var attendances []models.Attendance
+	result := config.DB.
+		Preload("Session").
+		Preload("Session.Class").
+		Preload("Session.Teacher").
+		Joins("JOIN attendance_sessions ON attendances.session_id = attendance_sessions.id").
+		Where("attendance_sessions.teacher_id = ?", teacherID).
+		Find(&attendances)
+	return attendances, result.Error
+}
+
+func GetAttendancesByEventIDAndTeacherID(eventID uint, teacherID uint) ([]models.Attendance, error) {
+	var attendances []models.Attendance
+	result := config.DB.
+		Preload("Session").
+		Preload("Session.Class").
+		Preload("Session.Teacher").
+		Preload("Session.Event").
+		Joins("JOIN attendance_sessions ON attendances.session_id = attendance_sessions.id").
+		Where("attendance_sessions.event_id = ? AND attendance_sessions.teacher_id = ?", eventID, teacherID).
+		Find(&attendances)
+	return attendances, result.Error
+}
+
 func CreateAttendance(attendance *models.Attendance) error {
 	result := config.DB.Create(attendance)
 	return result.Error
 }
+
+// FindDuplicateAttendance returns an existing check-in in the session with the
+// same normalized email, or the same normalized phone when phone is not nil
+func FindDuplicateAttendance(sessionID uint, emailNormalized string, phoneNormalized *string) (*models.Attendance, error) {
+	var attendance models.Attendance
+	query := config.DB.Where("session_id = ?", sessionID)
+	if phoneNormalized != nil {
+		query = query.Where("email_normalized = ? OR phone_normalized = ?", emailNormalized, *phoneNormalized)
+	} else {
+		query = query.Where("email_normalized = ?", emailNormalized)
+	}
+
+	result := query.Order("id").First(&attendance)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attendance, nil
+}
+
+// CreateAttendanceWithinCapacity stores an attendance unless its session
+// already holds capacity checked-in people, and reports whether it was stored.
+// The session row is locked so that concurrent check-ins cannot overfill it.
+// A nil capacity means unlimited.
+func CreateAttendanceWithinCapacity(attendance *models.Attendance, capacity *int) (bool, error) {
+	if capacity == nil {
+		return true, CreateAttendance(attendance)
+	}
+
+	created := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var session models.AttendanceSession
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&session, *attendance.SessionID).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&models.Attendance{}).
+			Where("session_id = ? AND checked_in_at IS NOT NULL", *attendance.SessionID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count >= int64(*capacity) {
+			return nil
+		}
+
+		if err := tx.Create(attendance).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// GetAttendanceByClientID returns the attendance uploaded by a kiosk with the given client ID
+func GetAttendanceByClientID(clientID string) (*models.Attendance, error) {
+	var attendance models.Attendance
+	result := config.DB.Where("client_id = ?", clientID).First(&attendance)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attendance, nil
+}
+
+// IsDuplicateKeyError reports whether err is a unique constraint violation
+func IsDuplicateKeyError(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+// GetAttendanceBySessionAndStudent returns the attendance of a student in a session
+func GetAttendanceBySessionAndStudent(sessionID uint, studentID uint) (*models.Attendance, error) {
+	var attendance models.Attendance
+	result := config.DB.Where("session_id = ? AND student_id = ?", sessionID, studentID).Order("id").First(&attendance)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attendance, nil
+}
+
+func UpdateAttendance(attendance *models.Attendance) error {
+	result := config.DB.Omit(clause.Associations).Save(attendance)
+	return result.Error
+}
+
+// SaveAttendanceMarks writes teacher marks for a session in one transaction.
+// A student who already has an attendance in the session, or an unmatched
+// check-in with their email, gets it updated instead of a second record.
+func SaveAttendanceMarks(sessionID uint, marks []models.Attendance) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range marks {
+			mark := &marks[i]
+
+			var existing models.Attendance
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("session_id = ? AND (student_id = ? OR (student_id IS NULL AND email_normalized = ?))",
+					sessionID, *mark.StudentID, mark.EmailNormalized).
+				Order("student_id IS NULL, id").
+				First(&existing).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if err := tx.Omit(clause.Associations).Create(mark).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			existing.ApplyMark(mark)
+			if err := tx.Omit(clause.Associations).Save(&existing).Error; err != nil {
+				return err
+			}
+			*mark = existing
+		}
+		return nil
+	})
+}
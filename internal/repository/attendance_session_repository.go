@@ -3,17 +3,36 @@ package repository
 import (
 	"hello-gin/config"
 	"hello-gin/internal/models"
+	"time"
 )
 
 func GetAllAttendanceSessions() ([]models.AttendanceSession, error) {
 	var sessions []models.AttendanceSession
-	result := config.DB.Preload("Event").Preload("Class").Preload("Teacher").Preload("Attendances").Find(&sessions)
+	result := config.DB.Preload("Event").Preload("Class").Preload("Teacher").Preload("Room").Preload("Attendances").Find(&sessions)
 	return sessions, result.Error
 }
 
 func GetAttendanceSessionByID(id int) (*models.AttendanceSession, error) {
 	var session models.AttendanceSession
-	result := config.DB.Preload("Event").Preload("Class").Preload("Teacher").Preload("Attendances").First(&session, id)
+	result := config.DB.Preload("Event").Preload("Class").Preload("Teacher").Preload("Room").Preload("Attendances").First(&session, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+func GetAttendanceSessionWithEvent(id uint) (*models.AttendanceSession, error) {
+	var session models.AttendanceSession
+	result := config.DB.Preload("Event").Preload("Room").First(&session, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+func GetAttendanceSessionWithEventAndClass(id uint) (*models.AttendanceSession, error) {
+	var session models.AttendanceSession
+	result := config.DB.Preload("Event").Preload("Class").First(&session, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -26,12 +45,51 @@ func GetAttendanceSessionsByEventID(eventID uint) ([]models.AttendanceSession, e
 		Preload("Event").
 		Preload("Class").
 		Preload("Teacher").
+		Preload("Room").
 		Preload("Attendances").
 		Where("event_id = ?", eventID).
 		Find(&sessions)
 	return sessions, result.Error
 }
 
+func GetAttendanceSessionsByTeacherID(teacherID uint) ([]models.AttendanceSession, error) {
+	var sessions []models.AttendanceSession
+	result := config.DB.
+		Preload("Event").
+		Preload("Class").
+		Preload("Teacher").
+		Preload("Room").
+		Preload("Attendances").
+		Where("teacher_id = ?", teacherID).
+		Find(&sessions)
+	return sessions, result.Error
+}
+
+// GetCalendarSessions returns the dated sessions whose column (event_id,
+// class_id or teacher_id) equals id, in date order
+func GetCalendarSessions(column string, id uint) ([]models.AttendanceSession, error) {
+	var sessions []models.AttendanceSession
+	result := config.DB.
+		Preload("Event").
+		Preload("Class").
+		Preload("Teacher").
+		Preload("Room").
+		Where(column+" = ? AND session_date IS NOT NULL", id).
+		Order("session_date").
+		Find(&sessions)
+	return sessions, result.Error
+}
+
+// GetAttendanceSessionsBetween returns the sessions starting in [from, to), in date order
+func GetAttendanceSessionsBetween(from, to time.Time) ([]models.AttendanceSession, error) {
+	var sessions []models.AttendanceSession
+	result := config.DB.
+		Where("session_date >= ? AND session_date < ?", from, to).
+		Order("session_date").
+		Find(&sessions)
+	return sessions, result.Error
+}
+
 func CreateAttendanceSession(session *models.AttendanceSession) error {
 	result := config.DB.Create(session)
 	return result.Error
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"hello-gin/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CertificateRepository struct {
+	db *gorm.DB
+}
+
+func NewCertificateRepository(db *gorm.DB) *CertificateRepository {
+	return &CertificateRepository{db: db}
+}
+
+// GetByCode retrieves a certificate with its event by verification code
+func (r *CertificateRepository) GetByCode(code string) (*models.Certificate, error) {
+	var certificate models.Certificate
+	err := r.db.Preload("Event").Where("code = ?", code).First(&certificate).Error
+	if err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+// Save stores a certificate for an attendee of an event. When the attendee
+// already has one, its recipient details and hours are refreshed and the
+// stored code and issue time are kept; certificate is updated to match.
+func (r *CertificateRepository) Save(certificate *models.Certificate) error {
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "attendee_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"attendance_id", "student_id", "recipient_name", "email", "dates", "hours", "updated_at"}),
+	}).Create(certificate).Error
+	if err != nil {
+		return err
+	}
+
+	return r.db.Where("event_id = ? AND attendee_key = ?", certificate.EventID, certificate.AttendeeKey).
+		First(certificate).Error
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"time"
+)
+
+// CreateCheckinFormTicket stores a newly issued form ticket and drops the
+// tickets that expired before now
+func CreateCheckinFormTicket(ticket *models.CheckinFormTicket, now time.Time) error {
+	if err := config.DB.Where("expires_at <= ?", now).Delete(&models.CheckinFormTicket{}).Error; err != nil {
+		return err
+	}
+	return config.DB.Create(ticket).Error
+}
+
+// UseCheckinFormTicket marks an unused, unexpired ticket of the session as
+// used at now. It reports false when there is no such ticket, so that of two
+// concurrent check-ins with the same ticket only one gets it.
+func UseCheckinFormTicket(sessionID uint, nonce string, now time.Time) (bool, error) {
+	result := config.DB.Model(&models.CheckinFormTicket{}).
+		Where("session_id = ? AND nonce = ? AND used_at IS NULL AND expires_at > ?", sessionID, nonce, now).
+		Update("used_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseCheckinFormTicket makes a ticket usable again after the check-in it
+// was taken for failed
+func ReleaseCheckinFormTicket(sessionID uint, nonce string) error {
+	return config.DB.Model(&models.CheckinFormTicket{}).
+		Where("session_id = ? AND nonce = ?", sessionID, nonce).
+		Update("used_at", nil).Error
+}
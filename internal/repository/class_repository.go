@@ -1,26 +1,102 @@
 package repository
 
 import (
-	"hello-gin/config"
 	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
 )
 
-func GetAllClasses() ([]models.Class, error) {
+type ClassRepository struct {
+	db *gorm.DB
+}
+
+func NewClassRepository(db *gorm.DB) *ClassRepository {
+	return &ClassRepository{db: db}
+}
+
+// GetAll retrieves all classes
+func (r *ClassRepository) GetAll() ([]models.Class, error) {
 	var classes []models.Class
-	result := config.DB.Find(&classes)
-	return classes, result.Error
+	err := r.db.Find(&classes).Error
+	return classes, err
+}
+
+// GetByID retrieves a class by ID
+func (r *ClassRepository) GetByID(id uint) (*models.Class, error) {
+	var class models.Class
+	err := r.db.First(&class, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+// GetByIDWithStudents retrieves a class by ID with the students currently
+// enrolled in it
+func (r *ClassRepository) GetByIDWithStudents(id uint) (*models.Class, error) {
+	var class models.Class
+	if err := r.db.First(&class, id).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	err := r.db.Where("id IN ("+enrolledOn+")", id, now, now).Order("student_name").Find(&class.Students).Error
+	if err != nil {
+		return nil, err
+	}
+	return &class, nil
 }
 
-func GetClassByID(id int) (*models.Class, error) {
+// GetByCode retrieves a class by code, ignoring case and surrounding spaces
+func (r *ClassRepository) GetByCode(code string) (*models.Class, error) {
 	var class models.Class
-	result := config.DB.Preload("Students").First(&class, id)
-	if result.Error != nil {
-		return nil, result.Error
+	err := r.db.Where("LOWER(TRIM(class_code)) = LOWER(TRIM(?))", code).First(&class).Error
+	if err != nil {
+		return nil, err
 	}
 	return &class, nil
 }
 
-func CreateClass(class *models.Class) error {
-	result := config.DB.Create(class)
-	return result.Error
+// Create creates a new class
+func (r *ClassRepository) Create(class *models.Class) error {
+	return r.db.Create(class).Error
+}
+
+// Update updates an existing class without touching its students
+func (r *ClassRepository) Update(class *models.Class) error {
+	return r.db.Omit("Students", "Sessions").Save(class).Error
+}
+
+// CountStudents counts the students of a class: those whose main class it is
+// and those currently enrolled in it
+func (r *ClassRepository) CountStudents(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Student{}).
+		Where("class_id = ? OR id IN (SELECT student_id FROM enrollments WHERE class_id = ? AND enrolled_to IS NULL AND deleted_at IS NULL)", id, id).
+		Count(&count).Error
+	return count, err
+}
+
+// Delete deletes a class by ID together with its enrollments, so that no
+// student stays enrolled in a deleted class. With withStudents its students
+// are deleted in the same transaction too. Restoring the class brings them
+// back; it returns how many students were deleted.
+func (r *ClassRepository) Delete(id uint, withStudents bool) (int64, error) {
+	var removed int64
+	at := trashTimestamp()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if withStudents {
+			count, err := softDeleteRows(tx, "students", "class_id = ?", id, at)
+			if err != nil {
+				return err
+			}
+			removed = count
+		}
+		if _, err := softDeleteRows(tx, "enrollments", "class_id = ?", id, at); err != nil {
+			return err
+		}
+		_, err := softDeleteRows(tx, "classes", "id = ?", id, at)
+		return err
+	})
+	return removed, err
 }
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// enrolledOn selects the IDs of the students enrolled in a class (first
+// argument) at a time (second and third arguments)
+const enrolledOn = `SELECT student_id FROM enrollments
+	WHERE class_id = ? AND deleted_at IS NULL
+	AND enrolled_from <= ? AND (enrolled_to IS NULL OR enrolled_to > ?)`
+
+type EnrollmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEnrollmentRepository(db *gorm.DB) *EnrollmentRepository {
+	return &EnrollmentRepository{db: db}
+}
+
+// GetByStudentID retrieves the enrollments of a student with their classes,
+// oldest first
+func (r *EnrollmentRepository) GetByStudentID(studentID uint) ([]models.Enrollment, error) {
+	var enrollments []models.Enrollment
+	err := r.db.Preload("Class").
+		Where("student_id = ?", studentID).
+		Order("enrolled_from, id").
+		Find(&enrollments).Error
+	return enrollments, err
+}
+
+// GetOpen retrieves the enrollment of a student in a class that has not ended
+func (r *EnrollmentRepository) GetOpen(studentID, classID uint) (*models.Enrollment, error) {
+	var enrollment models.Enrollment
+	err := r.db.Where("student_id = ? AND class_id = ? AND enrolled_to IS NULL", studentID, classID).
+		Order("enrolled_from DESC").
+		First(&enrollment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+// GetClassEnrollmentsOn retrieves the enrollments of a class that were open
+// at a time, with their students ordered by name
+func (r *EnrollmentRepository) GetClassEnrollmentsOn(classID uint, at time.Time) ([]models.Enrollment, error) {
+	var enrollments []models.Enrollment
+	err := r.db.Preload("Student").
+		Joins("JOIN students ON students.id = enrollments.student_id AND students.deleted_at IS NULL").
+		Where("enrollments.class_id = ? AND enrollments.enrolled_from <= ? AND (enrollments.enrolled_to IS NULL OR enrollments.enrolled_to > ?)", classID, at, at).
+		Order("students.student_name, students.id").
+		Find(&enrollments).Error
+	return enrollments, err
+}
+
+// CountOpen counts the students currently enrolled in a class
+func (r *EnrollmentRepository) CountOpen(classID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Enrollment{}).
+		Where("class_id = ? AND enrolled_to IS NULL", classID).
+		Count(&count).Error
+	return count, err
+}
+
+// IsEnrolled reports whether a student was enrolled in a class at a time
+func (r *EnrollmentRepository) IsEnrolled(studentID, classID uint, at time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Enrollment{}).
+		Where("student_id = ? AND class_id = ? AND enrolled_from <= ? AND (enrolled_to IS NULL OR enrolled_to > ?)", studentID, classID, at, at).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Change ends and starts enrollments of a student in one transaction and
+// sets their main class
+func (r *EnrollmentRepository) Change(studentID uint, ended, started *models.Enrollment, classID *uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := changeEnrollments(tx, ended, started); err != nil {
+			return err
+		}
+		return tx.Model(&models.Student{}).Where("id = ?", studentID).Update("class_id", classID).Error
+	})
+}
+
+// UpdateStudentAndChange saves a student, main class included, and ends and
+// starts their enrollments in one transaction
+func (r *EnrollmentRepository) UpdateStudentAndChange(student *models.Student, ended, started *models.Enrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(student).Error; err != nil {
+			return err
+		}
+		return changeEnrollments(tx, ended, started)
+	})
+}
+
+func changeEnrollments(tx *gorm.DB, ended, started *models.Enrollment) error {
+	if ended != nil {
+		if err := tx.Omit("Student", "Class").Save(ended).Error; err != nil {
+			return err
+		}
+	}
+	if started != nil {
+		if err := tx.Omit("Student", "Class").Create(started).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStudentsEnrolledOn retrieves the students enrolled in a class at a time,
+// ordered by name
+func GetStudentsEnrolledOn(classID uint, at time.Time) ([]models.Student, error) {
+	var students []models.Student
+	result := config.DB.Where("id IN ("+enrolledOn+")", classID, at, at).Order("student_name").Find(&students)
+	return students, result.Error
+}
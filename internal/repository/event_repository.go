@@ -2,6 +2,7 @@ package repository
 
 import (
 	"hello-gin/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -34,7 +35,7 @@ func (r *EventRepository) GetByID(id uint) (*models.Event, error) {
 // GetByIDWithSessions retrieves an event by ID with its sessions
 func (r *EventRepository) GetByIDWithSessions(id uint) (*models.Event, error) {
 	var event models.Event
-	err := r.db.Preload("Sessions").Preload("Sessions.Class").Preload("Sessions.Teacher").First(&event, id).Error
+	err := r.db.Preload("Sessions").Preload("Sessions.Class").Preload("Sessions.Teacher").Preload("Sessions.Room").First(&event, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -46,19 +47,91 @@ func (r *EventRepository) Create(event *models.Event) error {
 	return r.db.Create(event).Error
 }
 
-// Update updates an existing event
+// Update updates an existing event. The status is left alone: it only
+// changes through ChangeStatus, which checks it has not moved in the meantime.
 func (r *EventRepository) Update(event *models.Event) error {
-	return r.db.Save(event).Error
+	return r.db.Omit("status", "status_changed_at").Save(event).Error
 }
 
-// Delete deletes an event by ID
+// Delete deletes an event by ID together with its sessions, their attendances
+// and its registrations, so that restoring the event brings them back
 func (r *EventRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Event{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return softDeleteRecord(tx, "events", id)
+	})
 }
 
-// GetActiveEvents retrieves all active events
-func (r *EventRepository) GetActiveEvents() ([]models.Event, error) {
+// GetByStatus retrieves all events with the given status
+func (r *EventRepository) GetByStatus(status string) ([]models.Event, error) {
 	var events []models.Event
-	err := r.db.Where("is_active = ?", true).Find(&events).Error
+	err := r.db.Where("status = ?", status).Find(&events).Error
 	return events, err
 }
+
+// GetScheduleCandidates retrieves the published and ongoing events whose start
+// or end date has passed
+func (r *EventRepository) GetScheduleCandidates(now time.Time) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.
+		Where("(status = ? AND start_date <= ?) OR (status IN ? AND end_date <= ?)",
+			models.EventStatusPublished, now,
+			[]string{models.EventStatusPublished, models.EventStatusOngoing}, now).
+		Find(&events).Error
+	return events, err
+}
+
+// ChangeStatus moves an event from one status to another and logs the change
+// in one transaction. It returns false without changing anything when the
+// event's status is no longer log.FromStatus.
+func (r *EventRepository) ChangeStatus(event *models.Event, log *models.EventStatusLog, at time.Time) (bool, error) {
+	changed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Event{}).
+			Where("id = ? AND status = ?", event.ID, log.FromStatus).
+			Updates(map[string]interface{}{"status": log.ToStatus, "status_changed_at": at})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		log.EventID = event.ID
+		if err := tx.Create(log).Error; err != nil {
+			return err
+		}
+		changed = true
+		return nil
+	})
+	if err != nil || !changed {
+		return false, err
+	}
+
+	event.Status = log.ToStatus
+	event.StatusChangedAt = &at
+	return true, nil
+}
+
+// GetStatusLogs retrieves the status changes of an event, oldest first
+func (r *EventRepository) GetStatusLogs(eventID uint) ([]models.EventStatusLog, error) {
+	var logs []models.EventStatusLog
+	err := r.db.Where("event_id = ?", eventID).Order("created_at, id").Find(&logs).Error
+	return logs, err
+}
+
+// WithAdvisoryLock runs fn in a transaction holding the Postgres advisory lock
+// key, so that only one server instance runs it at a time. It returns false
+// without running fn when another instance holds the lock.
+func (r *EventRepository) WithAdvisoryLock(key int64, fn func(repo *EventRepository) error) (bool, error) {
+	locked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		return fn(&EventRepository{db: tx})
+	})
+	return locked, err
+}
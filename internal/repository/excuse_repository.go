@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ExcuseRepository struct {
+	db *gorm.DB
+}
+
+func NewExcuseRepository(db *gorm.DB) *ExcuseRepository {
+	return &ExcuseRepository{db: db}
+}
+
+// GetAll retrieves excuse requests matching the filter, newest first
+func (r *ExcuseRepository) GetAll(filter models.ExcuseFilter) ([]models.ExcuseRequest, error) {
+	var excuses []models.ExcuseRequest
+	query := r.db.Preload("Session").Preload("Student")
+
+	if filter.Status != "" {
+		query = query.Where("excuse_requests.status = ?", filter.Status)
+	}
+	if filter.SessionID != nil {
+		query = query.Where("excuse_requests.session_id = ?", *filter.SessionID)
+	}
+	if filter.StudentID != nil {
+		query = query.Where("excuse_requests.student_id = ?", *filter.StudentID)
+	}
+	if filter.TeacherID != nil {
+		query = query.Joins("JOIN attendance_sessions ON attendance_sessions.id = excuse_requests.session_id").
+			Where("attendance_sessions.teacher_id = ?", *filter.TeacherID)
+	}
+
+	err := query.Order("excuse_requests.created_at DESC").Find(&excuses).Error
+	return excuses, err
+}
+
+// GetByID retrieves an excuse request with its session, student and history
+func (r *ExcuseRepository) GetByID(id uint) (*models.ExcuseRequest, error) {
+	var excuse models.ExcuseRequest
+	err := r.db.Preload("Session").Preload("Student").
+		Preload("History", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		First(&excuse, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &excuse, nil
+}
+
+// GetHistory retrieves the history of an excuse request, oldest first
+func (r *ExcuseRepository) GetHistory(excuseID uint) ([]models.ExcuseRequestEvent, error) {
+	var events []models.ExcuseRequestEvent
+	err := r.db.Where("excuse_request_id = ?", excuseID).Order("created_at ASC, id ASC").Find(&events).Error
+	return events, err
+}
+
+// HasOpenRequest reports whether a student already has a pending or approved
+// excuse request for a session
+func (r *ExcuseRepository) HasOpenRequest(sessionID, studentID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ExcuseRequest{}).
+		Where("session_id = ? AND student_id = ? AND status IN ?", sessionID, studentID,
+			[]string{models.ExcuseStatusPending, models.ExcuseStatusApproved}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetApprovedStudentIDs returns the students with an approved excuse for a session
+func (r *ExcuseRepository) GetApprovedStudentIDs(sessionID uint) ([]uint, error) {
+	var studentIDs []uint
+	err := r.db.Model(&models.ExcuseRequest{}).
+		Where("session_id = ? AND status = ?", sessionID, models.ExcuseStatusApproved).
+		Pluck("student_id", &studentIDs).Error
+	return studentIDs, err
+}
+
+// Create stores a new excuse request together with its first history entry
+func (r *ExcuseRepository) Create(excuse *models.ExcuseRequest, event *models.ExcuseRequestEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Session", "Student", "History").Create(excuse).Error; err != nil {
+			return err
+		}
+		event.ExcuseRequestID = excuse.ID
+		return tx.Create(event).Error
+	})
+}
+
+// Review moves a pending excuse request to the event's status and records the
+// event. It returns false without changing anything if the request is no
+// longer pending, so concurrent reviews cannot both succeed.
+func (r *ExcuseRepository) Review(excuseID uint, reviewerID *uint, reviewedAt time.Time, event *models.ExcuseRequestEvent) (bool, error) {
+	reviewed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ExcuseRequest{}).
+			Where("id = ? AND status = ?", excuseID, models.ExcuseStatusPending).
+			Updates(map[string]interface{}{
+				"status":              event.ToStatus,
+				"reviewed_by_user_id": reviewerID,
+				"reviewed_at":         reviewedAt,
+				"review_note":         event.Note,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		reviewed = true
+		event.ExcuseRequestID = excuseID
+		return tx.Create(event).Error
+	})
+	return reviewed, err
+}
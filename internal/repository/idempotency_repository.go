@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Begin inserts an in-progress record for scope and key, relying on the
+// unique index to detect a concurrent or earlier request with the same key.
+// An expired record is deleted and the key reserved again.
+func (r *IdempotencyRepository) Begin(scope, key, requestHash string, ttl time.Duration) (*models.IdempotencyRecord, bool, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		record := &models.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now.Add(ttl),
+		}
+		err := r.db.Create(record).Error
+		if err == nil {
+			return record, true, nil
+		}
+		if !IsDuplicateKeyError(err) {
+			return nil, false, err
+		}
+
+		var existing models.IdempotencyRecord
+		err = r.db.Where("scope = ? AND key = ?", scope, key).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue // Released in the meantime
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if existing.ExpiresAt.After(now) {
+			return &existing, false, nil
+		}
+
+		err = r.db.Where("id = ? AND expires_at <= ?", existing.ID, now).Delete(&models.IdempotencyRecord{}).Error
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return nil, false, errors.New("could not reserve idempotency key")
+}
+
+// Complete stores the response of a reserved request
+func (r *IdempotencyRepository) Complete(record *models.IdempotencyRecord, statusCode int, contentType string, body []byte) error {
+	record.Completed = true
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.ResponseBody = body
+	return r.db.Model(record).Updates(map[string]interface{}{
+		"completed":     true,
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+	}).Error
+}
+
+// Release deletes a reservation so that the request can be retried
+func (r *IdempotencyRepository) Release(record *models.IdempotencyRecord) error {
+	return r.db.Delete(&models.IdempotencyRecord{}, record.ID).Error
+}
+
+// DeleteExpired deletes the records that expired before now and returns how
+// many were deleted
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&models.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegistrationRepository struct {
+	db *gorm.DB
+}
+
+func NewRegistrationRepository(db *gorm.DB) *RegistrationRepository {
+	return &RegistrationRepository{db: db}
+}
+
+var activeRegistrationStatuses = []string{models.RegistrationStatusRegistered, models.RegistrationStatusWaitlisted}
+
+// GetByID retrieves a registration by ID
+func (r *RegistrationRepository) GetByID(id uint) (*models.Registration, error) {
+	var registration models.Registration
+	err := r.db.First(&registration, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// GetByCancelToken retrieves a registration by its cancel token
+func (r *RegistrationRepository) GetByCancelToken(token string) (*models.Registration, error) {
+	var registration models.Registration
+	err := r.db.Where("cancel_token = ?", token).First(&registration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// GetByEventID retrieves an event's registrations in registration order,
+// optionally narrowed to one session and status
+func (r *RegistrationRepository) GetByEventID(eventID uint, sessionID *uint, status string) ([]models.Registration, error) {
+	var registrations []models.Registration
+	query := r.db.Where("event_id = ?", eventID)
+	if sessionID != nil {
+		query = query.Where("session_id = ?", *sessionID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at ASC, id ASC").Find(&registrations).Error
+	return registrations, err
+}
+
+// FindActiveByContact finds an active registration covering a session, either
+// for the session itself or for the whole event, by normalized email or phone.
+// Registered entries are returned before waitlisted ones.
+func (r *RegistrationRepository) FindActiveByContact(eventID, sessionID uint, emailNormalized string, phoneNormalized *string) (*models.Registration, error) {
+	var registration models.Registration
+	query := r.db.Where("event_id = ? AND (session_id IS NULL OR session_id = ?) AND status IN ?", eventID, sessionID, activeRegistrationStatuses)
+	if phoneNormalized != nil {
+		query = query.Where("(email_normalized = ? OR phone_normalized = ?)", emailNormalized, *phoneNormalized)
+	} else {
+		query = query.Where("email_normalized = ?", emailNormalized)
+	}
+	err := query.Order(clause.Expr{SQL: "CASE WHEN status = ? THEN 0 ELSE 1 END, created_at ASC", Vars: []interface{}{models.RegistrationStatusRegistered}}).
+		First(&registration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// Create stores a registration as registered while the scope has free places
+// and as waitlisted otherwise. The event row is locked so that concurrent
+// registrations cannot overbook. A nil capacity means unlimited.
+func (r *RegistrationRepository) Create(registration *models.Registration, capacity *int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockEvent(tx, registration.EventID); err != nil {
+			return err
+		}
+
+		registered, err := countRegistered(tx, registration.EventID, registration.SessionID)
+		if err != nil {
+			return err
+		}
+
+		registration.Status = models.RegistrationStatusRegistered
+		if capacity != nil && registered >= int64(*capacity) {
+			registration.Status = models.RegistrationStatusWaitlisted
+		}
+		return tx.Omit(clause.Associations).Create(registration).Error
+	})
+}
+
+// Cancel cancels an active registration and, if it held a place, promotes
+// waitlisted registrations of the same scope in order until it is full again.
+// cancelled is false when the registration was already cancelled.
+func (r *RegistrationRepository) Cancel(registration *models.Registration, capacity *int, now time.Time) (promoted []models.Registration, cancelled bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockEvent(tx, registration.EventID); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Registration{}).
+			Where("id = ? AND status IN ?", registration.ID, activeRegistrationStatuses).
+			Updates(map[string]interface{}{
+				"status":       models.RegistrationStatusCancelled,
+				"cancelled_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		cancelled = true
+
+		promoted, err = promoteWaitlist(tx, registration.EventID, registration.SessionID, capacity, now)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if cancelled {
+		registration.Status = models.RegistrationStatusCancelled
+		registration.CancelledAt = &now
+	}
+	return promoted, cancelled, nil
+}
+
+// promoteWaitlist moves the oldest waitlisted registrations of a scope to
+// registered while there are free places
+func promoteWaitlist(tx *gorm.DB, eventID uint, sessionID *uint, capacity *int, now time.Time) ([]models.Registration, error) {
+	registered, err := countRegistered(tx, eventID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := registrationScope(tx.Model(&models.Registration{}), eventID, sessionID).
+		Where("status = ?", models.RegistrationStatusWaitlisted).
+		Order("created_at ASC, id ASC")
+	if capacity != nil {
+		free := int64(*capacity) - registered
+		if free <= 0 {
+			return nil, nil
+		}
+		query = query.Limit(int(free))
+	}
+
+	var waitlisted []models.Registration
+	if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&waitlisted).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range waitlisted {
+		waitlisted[i].Status = models.RegistrationStatusRegistered
+		waitlisted[i].PromotedAt = &now
+		err := tx.Model(&waitlisted[i]).Updates(map[string]interface{}{
+			"status":      models.RegistrationStatusRegistered,
+			"promoted_at": now,
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return waitlisted, nil
+}
+
+func countRegistered(tx *gorm.DB, eventID uint, sessionID *uint) (int64, error) {
+	var count int64
+	err := registrationScope(tx.Model(&models.Registration{}), eventID, sessionID).
+		Where("status = ?", models.RegistrationStatusRegistered).
+		Count(&count).Error
+	return count, err
+}
+
+// registrationScope limits a query to event-wide registrations or to one session's
+func registrationScope(query *gorm.DB, eventID uint, sessionID *uint) *gorm.DB {
+	query = query.Where("event_id = ?", eventID)
+	if sessionID == nil {
+		return query.Where("session_id IS NULL")
+	}
+	return query.Where("session_id = ?", *sessionID)
+}
+
+func lockEvent(tx *gorm.DB, eventID uint) error {
+	var event models.Event
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&event, eventID).Error
+}
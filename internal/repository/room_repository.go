@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RoomRepository struct {
+	db *gorm.DB
+}
+
+func NewRoomRepository(db *gorm.DB) *RoomRepository {
+	return &RoomRepository{db: db}
+}
+
+// GetAll retrieves all rooms ordered by building and name
+func (r *RoomRepository) GetAll() ([]models.Room, error) {
+	var rooms []models.Room
+	err := r.db.Order("building, name").Find(&rooms).Error
+	return rooms, err
+}
+
+// GetByID retrieves a room by ID
+func (r *RoomRepository) GetByID(id uint) (*models.Room, error) {
+	var room models.Room
+	err := r.db.First(&room, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// GetByName retrieves a room by name, ignoring case
+func (r *RoomRepository) GetByName(name string) (*models.Room, error) {
+	var room models.Room
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&room).Error
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// Create creates a new room
+func (r *RoomRepository) Create(room *models.Room) error {
+	return r.db.Create(room).Error
+}
+
+// Update updates an existing room
+func (r *RoomRepository) Update(room *models.Room) error {
+	return r.db.Save(room).Error
+}
+
+// Delete deletes a room by ID
+func (r *RoomRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Room{}, id).Error
+}
+
+// CountUpcomingSessions counts the sessions booked in a room that start after now
+func (r *RoomRepository) CountUpcomingSessions(roomID uint, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AttendanceSession{}).
+		Where("room_id = ? AND session_date > ?", roomID, now).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func GetSessionSeries(eventID *uint, teacherID *uint) ([]models.SessionSeries, error) {
+	var series []models.SessionSeries
+	query := config.DB.Order("starts_at")
+	if eventID != nil {
+		query = query.Where("event_id = ?", *eventID)
+	}
+	if teacherID != nil {
+		query = query.Where("teacher_id = ?", *teacherID)
+	}
+	result := query.Find(&series)
+	return series, result.Error
+}
+
+func GetSessionSeriesByID(id uint) (*models.SessionSeries, error) {
+	var series models.SessionSeries
+	result := config.DB.
+		Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Order("session_date") }).
+		First(&series, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &series, nil
+}
+
+// GetSeriesSessionsAfter returns the sessions of a series that start after the given time
+func GetSeriesSessionsAfter(seriesID uint, after time.Time) ([]models.AttendanceSession, error) {
+	var sessions []models.AttendanceSession
+	result := config.DB.
+		Where("series_id = ? AND session_date > ?", seriesID, after).
+		Order("session_date").
+		Find(&sessions)
+	return sessions, result.Error
+}
+
+// CreateSessionSeries stores a series and its generated sessions in one transaction
+func CreateSessionSeries(series *models.SessionSeries, sessions []models.AttendanceSession) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Sessions").Create(series).Error; err != nil {
+			return err
+		}
+		for i := range sessions {
+			sessions[i].SeriesID = &series.ID
+		}
+		if len(sessions) > 0 {
+			if err := tx.Create(&sessions).Error; err != nil {
+				return err
+			}
+		}
+		series.Sessions = sessions
+		return nil
+	})
+}
+
+// ApplySeriesPlan saves a series and updates, creates and removes its sessions
+// in one transaction. Sessions to remove that already have attendances are
+// kept; their IDs are returned.
+func ApplySeriesPlan(series *models.SessionSeries, update, create, remove []models.AttendanceSession) ([]uint, error) {
+	kept := []uint{}
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Sessions").Save(series).Error; err != nil {
+			return err
+		}
+
+		for i := range update {
+			if err := tx.Omit(clause.Associations).Save(&update[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range create {
+			create[i].SeriesID = &series.ID
+		}
+		if len(create) > 0 {
+			if err := tx.Create(&create).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, session := range remove {
+			var attendances int64
+			if err := tx.Model(&models.Attendance{}).Where("session_id = ?", session.ID).Count(&attendances).Error; err != nil {
+				return err
+			}
+			if attendances > 0 {
+				kept = append(kept, session.ID)
+				continue
+			}
+			if err := tx.Delete(&models.AttendanceSession{}, session.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kept, nil
+}
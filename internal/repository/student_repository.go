@@ -3,15 +3,94 @@ package repository
 import (
 	"hello-gin/config"
 	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func GetAllStudents() ([]models.Student, error) {
+type StudentRepository struct {
+	db *gorm.DB
+}
+
+func NewStudentRepository(db *gorm.DB) *StudentRepository {
+	return &StudentRepository{db: db}
+}
+
+// GetAll retrieves all students
+func (r *StudentRepository) GetAll() ([]models.Student, error) {
+	var students []models.Student
+	err := r.db.Find(&students).Error
+	return students, err
+}
+
+// GetByID retrieves a student with their class by ID
+func (r *StudentRepository) GetByID(id uint) (*models.Student, error) {
+	var student models.Student
+	err := r.db.Preload("Class").First(&student, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// GetByCode retrieves a student by code, ignoring case and surrounding spaces
+func (r *StudentRepository) GetByCode(code string) (*models.Student, error) {
+	var student models.Student
+	err := r.db.Where("LOWER(TRIM(student_code)) = LOWER(TRIM(?))", code).First(&student).Error
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// Create creates a new student
+func (r *StudentRepository) Create(student *models.Student) error {
+	return r.db.Create(student).Error
+}
+
+// Update updates an existing student without touching their class
+func (r *StudentRepository) Update(student *models.Student) error {
+	return r.db.Omit(clause.Associations).Save(student).Error
+}
+
+// Delete deletes a student by ID; their attendances are kept
+func (r *StudentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Student{}, id).Error
+}
+
+// FindStudentsByCode returns students with the given code, limited to the
+// students enrolled in a class at a time when classID is set
+func FindStudentsByCode(code string, classID *uint, at time.Time) ([]models.Student, error) {
+	return findStudents("LOWER(TRIM(student_code)) = LOWER(TRIM(?))", code, classID, at)
+}
+
+// FindStudentsByEmail returns students whose email normalizes to emailNormalized
+func FindStudentsByEmail(emailNormalized string, classID *uint, at time.Time) ([]models.Student, error) {
+	return findStudents("LOWER(TRIM(email)) = ?", emailNormalized, classID, at)
+}
+
+// FindStudentsByPhone returns students whose phone normalizes to phoneNormalized.
+// The expression mirrors services.NormalizePhone.
+func FindStudentsByPhone(phoneNormalized string, classID *uint, at time.Time) ([]models.Student, error) {
+	return findStudents(`regexp_replace(regexp_replace(TRIM(phone), '^(\+84|0084)', '0'), '[^0-9]', '', 'g') = ?`, phoneNormalized, classID, at)
+}
+
+func findStudents(condition string, value string, classID *uint, at time.Time) ([]models.Student, error) {
 	var students []models.Student
-	result := config.DB.Find(&students)
+	query := config.DB.Where(condition, value)
+	if classID != nil {
+		query = query.Where("id IN ("+enrolledOn+")", *classID, at, at)
+	}
+	result := query.Limit(2).Find(&students)
 	return students, result.Error
 }
 
-func CreateStudent(student *models.Student) error {
-	result := config.DB.Create(student)
-	return result.Error
+func GetStudentByID(id uint) (*models.Student, error) {
+	var student models.Student
+	result := config.DB.First(&student, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &student, nil
 }
@@ -1,26 +1,67 @@
 package repository
 
 import (
-	"hello-gin/config"
 	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
 )
 
-func GetAllTeachers() ([]models.Teacher, error) {
+type TeacherRepository struct {
+	db *gorm.DB
+}
+
+func NewTeacherRepository(db *gorm.DB) *TeacherRepository {
+	return &TeacherRepository{db: db}
+}
+
+// GetAll retrieves all teachers
+func (r *TeacherRepository) GetAll() ([]models.Teacher, error) {
 	var teachers []models.Teacher
-	result := config.DB.Find(&teachers)
-	return teachers, result.Error
+	err := r.db.Find(&teachers).Error
+	return teachers, err
+}
+
+// GetByID retrieves a teacher by ID
+func (r *TeacherRepository) GetByID(id uint) (*models.Teacher, error) {
+	var teacher models.Teacher
+	err := r.db.First(&teacher, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &teacher, nil
 }
 
-func GetTeacherByID(id int) (*models.Teacher, error) {
+// GetByCode retrieves a teacher by code, ignoring case and surrounding spaces
+func (r *TeacherRepository) GetByCode(code string) (*models.Teacher, error) {
 	var teacher models.Teacher
-	result := config.DB.First(&teacher, id)
-	if result.Error != nil {
-		return nil, result.Error
+	err := r.db.Where("LOWER(TRIM(teacher_code)) = LOWER(TRIM(?))", code).First(&teacher).Error
+	if err != nil {
+		return nil, err
 	}
 	return &teacher, nil
 }
 
-func CreateTeacher(teacher *models.Teacher) error {
-	result := config.DB.Create(teacher)
-	return result.Error
+// Create creates a new teacher
+func (r *TeacherRepository) Create(teacher *models.Teacher) error {
+	return r.db.Create(teacher).Error
+}
+
+// Update updates an existing teacher without touching their sessions
+func (r *TeacherRepository) Update(teacher *models.Teacher) error {
+	return r.db.Omit("Sessions").Save(teacher).Error
+}
+
+// CountUpcomingSessions counts the sessions a teacher teaches that start after now
+func (r *TeacherRepository) CountUpcomingSessions(id uint, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AttendanceSession{}).
+		Where("teacher_id = ? AND session_date > ?", id, now).
+		Count(&count).Error
+	return count, err
+}
+
+// Delete deletes a teacher by ID; past sessions keep their teacher ID
+func (r *TeacherRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Teacher{}, id).Error
 }
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"hello-gin/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashTable describes how the records of a table are listed, deleted,
+// restored and purged through the trash
+type trashTable struct {
+	label string // SQL expression naming a record in listings
+	// Records deleted together with a record, restored with it again
+	cascade []trashChild
+	// Records removed for good when a record is purged, in deletion order
+	purge []trashChild
+	// SQL expression that must stay unique among live records, checked on restore
+	unique string
+	// Parent table and column; a record cannot be restored while its parent is deleted
+	parent, parentColumn string
+}
+
+// trashChild selects the records of table that belong to a record, where
+// takes the record ID as its only argument
+type trashChild struct {
+	table string
+	where string
+}
+
+const eventSessionIDs = "SELECT id FROM attendance_sessions WHERE event_id = ?"
+
+// trashTables maps each resource that has a trash to its table
+var trashTables = map[string]trashTable{
+	"events": {
+		label: "COALESCE(event_name, '')",
+		// Certificates go with the event so that they no longer verify while it is in the trash
+		cascade: []trashChild{
+			{table: "session_series", where: "event_id = ?"},
+			{table: "attendance_sessions", where: "event_id = ?"},
+			{table: "attendances", where: "session_id IN (" + eventSessionIDs + ")"},
+			{table: "registrations", where: "event_id = ?"},
+			{table: "certificates", where: "event_id = ?"},
+		},
+		purge: []trashChild{
+			{table: "excuse_request_events", where: "excuse_request_id IN (SELECT id FROM excuse_requests WHERE session_id IN (" + eventSessionIDs + "))"},
+			{table: "excuse_requests", where: "session_id IN (" + eventSessionIDs + ")"},
+			{table: "certificates", where: "event_id = ?"},
+			{table: "attendances", where: "session_id IN (" + eventSessionIDs + ")"},
+			{table: "registrations", where: "event_id = ?"},
+			{table: "attendance_sessions", where: "event_id = ?"},
+			{table: "session_series", where: "event_id = ?"},
+			{table: "event_status_logs", where: "event_id = ?"},
+		},
+	},
+	"classes": {
+		label: "COALESCE(class_name, class_code, '')",
+		cascade: []trashChild{
+			{table: "students", where: "class_id = ?"},
+			{table: "enrollments", where: "class_id = ?"},
+		},
+		purge: []trashChild{
+			{table: "enrollments", where: "student_id IN (SELECT id FROM students WHERE class_id = ? AND deleted_at IS NOT NULL)"},
+			{table: "enrollments", where: "class_id = ?"},
+			{table: "students", where: "class_id = ? AND deleted_at IS NOT NULL"},
+		},
+		unique: "LOWER(TRIM(class_code))",
+	},
+	"students": {
+		label:        "COALESCE(student_name, student_code, '')",
+		purge:        []trashChild{{table: "enrollments", where: "student_id = ?"}},
+		unique:       "LOWER(TRIM(student_code))",
+		parent:       "classes",
+		parentColumn: "class_id",
+	},
+	"teachers": {
+		label:  "COALESCE(teacher_name, teacher_code, '')",
+		unique: "LOWER(TRIM(teacher_code))",
+	},
+	"rooms": {
+		label:  "name",
+		unique: "LOWER(name)",
+	},
+}
+
+// trashTimestamp is the deleted_at stamped on a record and on the records
+// deleted together with it. Postgres keeps microseconds, so truncating lets
+// restore find them again by comparing timestamps.
+func trashTimestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// softDeleteRows stamps deleted_at on the live rows of table matching where
+func softDeleteRows(tx *gorm.DB, table, where string, id uint, at time.Time) (int64, error) {
+	result := tx.Exec("UPDATE "+table+" SET deleted_at = ? WHERE deleted_at IS NULL AND ("+where+")", at, id)
+	return result.RowsAffected, result.Error
+}
+
+// softDeleteRecord deletes a record of a trash table together with its
+// cascade children, all with the same timestamp
+func softDeleteRecord(tx *gorm.DB, resource string, id uint) error {
+	spec := trashTables[resource]
+	at := trashTimestamp()
+	for _, child := range spec.cascade {
+		if _, err := softDeleteRows(tx, child.table, child.where, id, at); err != nil {
+			return err
+		}
+	}
+	_, err := softDeleteRows(tx, resource, "id = ?", id, at)
+	return err
+}
+
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// GetDeleted retrieves the deleted records of a resource, most recently
+// deleted first
+func (r *TrashRepository) GetDeleted(resource string) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := r.db.Table(resource).
+		Select("id, " + trashTables[resource].label + " AS label, deleted_at").
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Scan(&items).Error
+	return items, err
+}
+
+// GetDeletedBefore retrieves the IDs of the records of a resource deleted
+// before the given time
+func (r *TrashRepository) GetDeletedBefore(resource string, before time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Table(resource).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at, id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetDeletedAt retrieves when a record was deleted, or gorm.ErrRecordNotFound
+// when it does not exist or is not deleted
+func (r *TrashRepository) GetDeletedAt(resource string, id uint) (time.Time, error) {
+	var at []time.Time
+	err := r.db.Table(resource).Where("id = ? AND deleted_at IS NOT NULL", id).Pluck("deleted_at", &at).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(at) == 0 {
+		return time.Time{}, gorm.ErrRecordNotFound
+	}
+	return at[0], nil
+}
+
+// IsParentDeleted reports whether the record belongs to a record that is
+// still deleted, such as a student of a deleted class
+func (r *TrashRepository) IsParentDeleted(resource string, id uint) (bool, error) {
+	spec := trashTables[resource]
+	if spec.parent == "" {
+		return false, nil
+	}
+	var count int64
+	err := r.db.Table(spec.parent).
+		Where("deleted_at IS NOT NULL AND id = (SELECT "+spec.parentColumn+" FROM "+resource+" WHERE id = ?)", id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsUniqueTaken reports whether a live record already uses the code or name
+// of the deleted record
+func (r *TrashRepository) IsUniqueTaken(resource string, id uint) (bool, error) {
+	spec := trashTables[resource]
+	if spec.unique == "" {
+		return false, nil
+	}
+	var count int64
+	err := r.db.Table(resource).
+		Where("deleted_at IS NULL AND "+spec.unique+" = (SELECT "+spec.unique+" FROM "+resource+" WHERE id = ?)", id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Restore undeletes a record deleted at the given time, together with the
+// records deleted with it. It returns how many of those were restored, per table.
+func (r *TrashRepository) Restore(resource string, id uint, deletedAt time.Time) (map[string]int64, error) {
+	restored := map[string]int64{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, child := range trashTables[resource].cascade {
+			result := tx.Exec("UPDATE "+child.table+" SET deleted_at = NULL WHERE deleted_at = ? AND ("+child.where+")", deletedAt, id)
+			if result.Error != nil {
+				return result.Error
+			}
+			restored[child.table] = result.RowsAffected
+		}
+		return tx.Exec("UPDATE "+resource+" SET deleted_at = NULL WHERE id = ?", id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Purge permanently deletes a deleted record together with the records that
+// belong to it. It fails with gorm.ErrForeignKeyViolated when other records
+// still refer to it.
+func (r *TrashRepository) Purge(resource string, id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, child := range trashTables[resource].purge {
+			if err := tx.Exec("DELETE FROM "+child.table+" WHERE "+child.where, id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Exec("DELETE FROM "+resource+" WHERE id = ? AND deleted_at IS NOT NULL", id).Error
+	})
+}
+
+// WithAdvisoryLock runs fn in a transaction holding the Postgres advisory lock
+// key, so that only one server instance runs it at a time. It returns false
+// without running fn when another instance holds the lock.
+func (r *TrashRepository) WithAdvisoryLock(key int64, fn func(repo *TrashRepository) error) (bool, error) {
+	locked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		return fn(&TrashRepository{db: tx})
+	})
+	return locked, err
+}
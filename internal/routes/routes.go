@@ -1,49 +1,162 @@
 package routes
 
 import (
+	"hello-gin/config"
 	"hello-gin/internal/controllers"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/middleware"
+	"hello-gin/internal/models"
+	"hello-gin/internal/web"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(r *gin.Engine, eventController *controllers.EventController) {
+// publicRoutes can be called without an access token
+var publicRoutes = []string{
+	"GET /api/health",
+	"POST /api/auth/login",
+	"POST /api/auth/refresh",
+	"POST /api/attendances",              // attendee self check-in
+	"POST /api/attendances/checkout",     // attendee self check-out
+	"POST /api/events/:id/registrations", // self registration
+	"POST /api/registrations/cancel",     // cancel with the registration token
+	"GET /api/certificates/verify/:code", // printed on certificates
+	"GET /api/calendar/:scope/:file",     // .ics feeds, signed with a feed token
+}
+
+func RegisterRoutes(r *gin.Engine, eventController *controllers.EventController, authController *controllers.AuthController, excuseController *controllers.ExcuseController, registrationController *controllers.RegistrationController, certificateController *controllers.CertificateController, roomController *controllers.RoomController, studentController *controllers.StudentController, classController *controllers.ClassController, teacherController *controllers.TeacherController, trashController *controllers.TrashController, calendarController *controllers.CalendarController, sessionController *controllers.AttendanceSessionController, seriesController *controllers.SessionSeriesController, attendanceController *controllers.AttendanceController, checkinPageController *controllers.CheckinPageController, authService interfaces.AuthServiceInterface, idempotencyStore interfaces.IdempotencyStoreInterface) {
+	// Self check-in page opened from the session QR code
+	r.SetHTMLTemplate(web.Templates())
+	r.StaticFS("/assets", web.Assets())
+	r.GET("/checkin/:sessionId", checkinPageController.ShowCheckinPage)
+	r.POST("/checkin/:sessionId", checkinPageController.SubmitCheckinPage)
+
 	api := r.Group("/api")
+	api.Use(middleware.AuthRequired(authService, publicRoutes...))
+	api.Use(middleware.Idempotency(idempotencyStore, config.IdempotencyTTL()))
+
+	// Role checks; teachers are further restricted to their own sessions in the handlers
+	admins := middleware.RequireRoles(models.RoleAdmin)
+	managers := middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer)
+	staff := middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer, models.RoleTeacher)
+	anyRole := middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer, models.RoleTeacher, models.RoleKiosk)
+	kiosks := middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer, models.RoleKiosk)
+	// Students can only see their own excuse requests and profile
+	staffOrStudent := middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer, models.RoleTeacher, models.RoleStudent)
+	managersOrStudent := middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer, models.RoleStudent)
 	{
+		// Auth routes
+		api.POST("/auth/login", authController.Login)
+		api.POST("/auth/refresh", authController.RefreshToken)
+		api.GET("/auth/me", authController.Me)
+
 		// Event routes
-		api.GET("/events", eventController.GetEvents)
-		api.GET("/events/active", eventController.GetActiveEvents)
-		api.GET("/events/:id", eventController.GetEventByID)
-		api.GET("/events/:id/sessions", eventController.GetEventWithSessions)
-		api.GET("/events/:id/attendances", controllers.GetAttendancesByEventID)
-		api.POST("/events", eventController.CreateEvent)
-		api.PUT("/events/:id", eventController.UpdateEvent)
-		api.PUT("/events/:id/active", eventController.EventActive)
-		api.DELETE("/events/:id", eventController.DeleteEvent)
+		api.GET("/events", anyRole, eventController.GetEvents)
+		api.GET("/events/active", anyRole, eventController.GetActiveEvents)
+		api.GET("/events/:id", anyRole, eventController.GetEventByID)
+		api.GET("/events/:id/sessions", anyRole, eventController.GetEventWithSessions)
+		api.GET("/events/:id/attendances", staff, attendanceController.GetAttendancesByEventID)
+		api.GET("/events/:id/attendances/export", staff, attendanceController.ExportEventAttendances)
+		api.GET("/events/:id/attendance-report", staff, attendanceController.GetEventAttendanceReport)
+		api.GET("/events/:id/calendar", staff, calendarController.GetEventCalendarLink)
+		api.POST("/events", managers, eventController.CreateEvent)
+		api.POST("/events/:id/clone", managers, eventController.CloneEvent)
+		api.PUT("/events/:id", managers, eventController.UpdateEvent)
+		api.PUT("/events/:id/status", managers, eventController.SetEventStatus)
+		api.GET("/events/:id/status-log", managers, eventController.GetEventStatusLog)
+		api.PUT("/events/:id/active", managers, eventController.EventActive)
+		api.DELETE("/events/:id", managers, eventController.DeleteEvent)
+
+		// Registration routes
+		api.POST("/events/:id/registrations", registrationController.Register)
+		api.GET("/events/:id/registrations", managers, registrationController.GetRegistrations)
+		api.GET("/events/:id/no-shows", managers, registrationController.GetNoShows)
+		api.POST("/registrations/cancel", registrationController.CancelRegistration)
+		api.DELETE("/registrations/:id", managers, registrationController.DeleteRegistration)
+
+		// Certificate routes
+		api.GET("/events/:id/certificates", managers, certificateController.GetEventCertificates)
+		api.GET("/attendances/:id/certificate", managers, certificateController.GetAttendanceCertificate)
+		api.GET("/certificates/verify/:code", certificateController.VerifyCertificate)
 
 		// Student routes
-		api.GET("/students", controllers.GetStudents)
-		api.POST("/students", controllers.CreateStudent)
+		api.GET("/students", staff, studentController.GetStudents)
+		api.GET("/students/:id", staffOrStudent, studentController.GetStudentByID)
+		api.POST("/students", managers, studentController.CreateStudent)
+		api.PATCH("/students/:id", managers, studentController.UpdateStudent)
+		api.DELETE("/students/:id", managers, studentController.DeleteStudent)
+		api.GET("/students/:id/enrollments", staffOrStudent, studentController.GetStudentEnrollments)
+		api.POST("/students/:id/enrollments", managers, studentController.EnrollStudent)
+		api.POST("/students/:id/transfer", managers, studentController.TransferStudent)
+		api.POST("/students/:id/withdraw", managers, studentController.WithdrawStudent)
 
 		// Class routes
-		api.GET("/classes", controllers.GetClasses)
-		api.GET("/classes/:id", controllers.GetClassByID)
-		api.POST("/classes", controllers.CreateClass)
+		api.GET("/classes", staff, classController.GetClasses)
+		api.GET("/classes/:id", staff, classController.GetClassByID)
+		api.GET("/classes/:id/calendar", staff, calendarController.GetClassCalendarLink)
+		api.POST("/classes", managers, classController.CreateClass)
+		api.PATCH("/classes/:id", managers, classController.UpdateClass)
+		api.DELETE("/classes/:id", managers, classController.DeleteClass)
 
 		// Teacher routes
-		api.GET("/teachers", controllers.GetTeachers)
-		api.GET("/teachers/:id", controllers.GetTeacherByID)
-		api.POST("/teachers", controllers.CreateTeacher)
+		api.GET("/teachers", staff, teacherController.GetTeachers)
+		api.GET("/teachers/:id", staff, teacherController.GetTeacherByID)
+		api.GET("/teachers/:id/calendar", staff, calendarController.GetTeacherCalendarLink)
+		api.POST("/teachers", managers, teacherController.CreateTeacher)
+		api.PATCH("/teachers/:id", managers, teacherController.UpdateTeacher)
+		api.DELETE("/teachers/:id", managers, teacherController.DeleteTeacher)
+
+		// Room routes
+		api.GET("/rooms", staff, roomController.GetRooms)
+		api.GET("/rooms/available", staff, roomController.GetAvailableRooms)
+		api.GET("/rooms/:id", staff, roomController.GetRoomByID)
+		api.POST("/rooms", managers, roomController.CreateRoom)
+		api.PUT("/rooms/:id", managers, roomController.UpdateRoom)
+		api.DELETE("/rooms/:id", managers, roomController.DeleteRoom)
+
+		// Trash routes; only admins can purge for good
+		api.GET("/trash/:resource", managers, trashController.GetTrash)
+		api.POST("/trash/:resource/:id/restore", managers, trashController.RestoreFromTrash)
+		api.DELETE("/trash/:resource/:id", admins, trashController.PurgeFromTrash)
 
 		// Attendance Session routes
-		api.GET("/attendance-sessions", controllers.GetAttendanceSessions)
-		api.GET("/attendance-sessions/:id", controllers.GetAttendanceSessionByID)
-		api.POST("/attendance-sessions", controllers.CreateAttendanceSession)
+		api.GET("/attendance-sessions", anyRole, sessionController.GetAttendanceSessions)
+		api.GET("/attendance-sessions/conflicts", staff, sessionController.GetAttendanceSessionConflicts)
+		api.GET("/attendance-sessions/:id", anyRole, sessionController.GetAttendanceSessionByID)
+		api.POST("/attendance-sessions", staff, sessionController.CreateAttendanceSession)
+		api.GET("/attendance-sessions/:id/checkin-token", anyRole, sessionController.GetAttendanceSessionCheckinToken)
+		api.GET("/attendance-sessions/:id/qr", anyRole, sessionController.GetAttendanceSessionQRCode)
+		api.GET("/attendance-sessions/:id/roster", staff, sessionController.GetAttendanceSessionRoster)
+		api.POST("/attendance-sessions/:id/marks", staff, sessionController.MarkAttendanceSession)
+
+		// Recurring session series routes
+		api.GET("/session-series", staff, seriesController.GetSessionSeries)
+		api.GET("/session-series/:id", staff, seriesController.GetSessionSeriesByID)
+		api.POST("/session-series", staff, seriesController.CreateSessionSeries)
+		api.PUT("/session-series/:id", staff, seriesController.UpdateSessionSeries)
+		api.DELETE("/session-series/:id", staff, seriesController.CancelSessionSeries)
 
 		// Attendance routes
-		api.GET("/attendances", controllers.GetAttendances)
-		api.GET("/attendances/:id", controllers.GetAttendanceByID)
-		api.POST("/attendances", controllers.CreateAttendance)
-		api.GET("/sessions/:sessionId/attendances", controllers.GetAttendancesBySessionID)
+		api.GET("/attendances", staff, attendanceController.GetAttendances)
+		api.GET("/attendances/:id", staff, attendanceController.GetAttendanceByID)
+		api.POST("/attendances", attendanceController.CreateAttendance)
+		api.POST("/attendances/batch", kiosks, attendanceController.SyncAttendances)
+		api.POST("/attendances/checkout", attendanceController.SelfCheckOut)
+		api.POST("/attendances/:id/checkout", anyRole, attendanceController.CheckOutAttendance)
+		api.PUT("/attendances/:id/student", staff, attendanceController.ResolveAttendanceStudent)
+		api.GET("/sessions/:sessionId/attendances", staff, attendanceController.GetAttendancesBySessionID)
+
+		// Excuse request routes
+		api.GET("/excuses", staffOrStudent, excuseController.GetExcuses)
+		api.GET("/excuses/:id", staffOrStudent, excuseController.GetExcuseByID)
+		api.GET("/excuses/:id/history", staffOrStudent, excuseController.GetExcuseHistory)
+		api.GET("/excuses/:id/attachment", staffOrStudent, excuseController.DownloadExcuseAttachment)
+		api.POST("/excuses", managersOrStudent, excuseController.CreateExcuse)
+		api.PUT("/excuses/:id/approve", staff, excuseController.ApproveExcuse)
+		api.PUT("/excuses/:id/reject", staff, excuseController.RejectExcuse)
+
+		// Calendar feeds
+		api.GET("/calendar/:scope/:file", calendarController.GetCalendarFeed)
 
 		// Health check
 		api.GET("/health", controllers.HealthCheck)
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"hello-gin/internal/services"
+	"log"
+	"time"
+)
+
+// EventScheduler periodically starts and closes events at their start and end
+// dates. Every server instance may run one; a Postgres advisory lock makes
+// sure only one of them applies the changes at a time.
+type EventScheduler struct {
+	eventService *services.EventService
+	interval     time.Duration
+}
+
+func NewEventScheduler(eventService *services.EventService, interval time.Duration) *EventScheduler {
+	return &EventScheduler{
+		eventService: eventService,
+		interval:     interval,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled
+func (s *EventScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.run()
+			}
+		}
+	}()
+}
+
+func (s *EventScheduler) run() {
+	logs, err := s.eventService.ApplyScheduledTransitions(time.Now().UTC())
+	if err != nil {
+		log.Printf("⚠️  Event scheduler failed: %v", err)
+		return
+	}
+	for _, entry := range logs {
+		log.Printf("📅 Event %d: %s -> %s (%s)", entry.EventID, entry.FromStatus, entry.ToStatus, entry.Reason)
+	}
+}
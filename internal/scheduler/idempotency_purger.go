@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"hello-gin/internal/repository"
+	"log"
+	"time"
+)
+
+// IdempotencyPurger periodically deletes expired Idempotency-Key records.
+// Running it on every server instance is harmless; each deletes whatever has
+// expired by then.
+type IdempotencyPurger struct {
+	idempotencyRepo *repository.IdempotencyRepository
+	interval        time.Duration
+}
+
+func NewIdempotencyPurger(idempotencyRepo *repository.IdempotencyRepository, interval time.Duration) *IdempotencyPurger {
+	return &IdempotencyPurger{
+		idempotencyRepo: idempotencyRepo,
+		interval:        interval,
+	}
+}
+
+// Start runs the purger in the background until ctx is cancelled
+func (p *IdempotencyPurger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.run()
+			}
+		}
+	}()
+}
+
+func (p *IdempotencyPurger) run() {
+	deleted, err := p.idempotencyRepo.DeleteExpired(time.Now())
+	if err != nil {
+		log.Printf("⚠️  Idempotency-Key purge failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("🗑️  Deleted %d expired Idempotency-Key records", deleted)
+	}
+}
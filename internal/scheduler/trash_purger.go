@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"hello-gin/internal/services"
+	"log"
+	"time"
+)
+
+// TrashPurger periodically purges records that have been in the trash longer
+// than the retention period. Like EventScheduler it may run on every server
+// instance; an advisory lock lets only one of them purge at a time.
+type TrashPurger struct {
+	trashService *services.TrashService
+	retention    time.Duration
+	interval     time.Duration
+}
+
+func NewTrashPurger(trashService *services.TrashService, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		trashService: trashService,
+		retention:    retention,
+		interval:     interval,
+	}
+}
+
+// Start runs the purger in the background until ctx is cancelled
+func (p *TrashPurger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.run()
+			}
+		}
+	}()
+}
+
+func (p *TrashPurger) run() {
+	purged, err := p.trashService.PurgeExpired(time.Now().UTC(), p.retention)
+	if err != nil {
+		log.Printf("⚠️  Trash purge failed: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("🗑️  Purged %d records deleted more than %s ago", purged, p.retention)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// attendanceExportColumns are the columns exported for every event, before the
+// event's custom form fields
+var attendanceExportColumns = []string{
+	"Session ID", "Session date", "Class", "Student name", "Email", "Phone",
+	"Work unit", "Work unit address", "Status", "Source",
+	"Checked in at", "Checked out at", "Duration (minutes)",
+}
+
+// GetEventAttendanceExport builds the attendance export of an event, limited
+// to one teacher's sessions when teacherID is set
+func GetEventAttendanceExport(eventID uint, teacherID *uint) ([][]string, error) {
+	event, err := repository.NewEventRepository(config.DB).GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	var attendances []models.Attendance
+	if teacherID != nil {
+		attendances, err = repository.GetAttendancesByEventIDAndTeacherID(eventID, *teacherID)
+	} else {
+		attendances, err = repository.GetAttendancesByEventID(eventID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildAttendanceExport(event.FormSchema, attendances), nil
+}
+
+// BuildAttendanceExport returns the header and one row per attendance, ordered
+// by check-in time. Each field of the form schema gets a column headed by its
+// label; multi-select answers are joined with "; ". Text entered by attendees
+// is escaped with csvText so spreadsheets do not evaluate it as a formula.
+func BuildAttendanceExport(schema models.FormSchema, attendances []models.Attendance) [][]string {
+	header := append([]string{}, attendanceExportColumns...)
+	for _, field := range schema {
+		header = append(header, csvText(field.Label))
+	}
+	rows := [][]string{header}
+
+	sorted := append([]models.Attendance{}, attendances...)
+	sortAttendancesByCheckin(sorted)
+
+	for _, attendance := range sorted {
+		row := []string{
+			formatOptionalUint(attendance.SessionID),
+			"",
+			"",
+			csvText(derefString(attendance.StudentName)),
+			csvText(derefString(attendance.Email)),
+			csvText(derefString(attendance.Phone)),
+			csvText(derefString(attendance.WorkUnit)),
+			csvText(derefString(attendance.WorkUnitAddress)),
+			derefString(attendance.Status),
+			derefString(attendance.Source),
+			formatOptionalTime(attendance.CheckedInAt),
+			formatOptionalTime(attendance.CheckedOutAt),
+			"",
+		}
+		if session := attendance.Session; session != nil {
+			if session.SessionDate != nil {
+				row[1] = session.SessionDate.Format("2006-01-02")
+			}
+			if session.Class != nil {
+				row[2] = csvText(derefString(session.Class.ClassName))
+			}
+		}
+		if attendance.DurationMinutes != nil {
+			row[12] = strconv.Itoa(*attendance.DurationMinutes)
+		}
+
+		for _, field := range schema {
+			row = append(row, formatFormAnswer(attendance.Answers[field.Key]))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func formatFormAnswer(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return csvText(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case []string:
+		return csvText(strings.Join(v, "; "))
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, formatFormAnswer(item))
+		}
+		return strings.Join(values, "; ")
+	default:
+		return ""
+	}
+}
+
+// csvText prefixes text starting with a character that spreadsheets treat as
+// the start of a formula with a quote, so it is shown as text
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func sortAttendancesByCheckin(attendances []models.Attendance) {
+	checkedIn := func(a models.Attendance) time.Time {
+		if a.CheckedInAt == nil {
+			return time.Time{}
+		}
+		return *a.CheckedInAt
+	}
+	sort.SliceStable(attendances, func(i, j int) bool {
+		return checkedIn(attendances[i]).Before(checkedIn(attendances[j]))
+	})
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func formatOptionalUint(value *uint) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*value), 10)
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -1,8 +1,13 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"hello-gin/internal/models"
 	"hello-gin/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 func GetAttendances() ([]models.Attendance, error) {
@@ -21,6 +26,302 @@ func GetAttendancesByEventID(eventID uint) ([]models.Attendance, error) {
 	return repository.GetAttendancesByEventID(eventID)
 }
 
+func GetAttendancesByTeacherID(teacherID uint) ([]models.Attendance, error) {
+	return repository.GetAttendancesByTeacherID(teacherID)
+}
+
+func GetAttendancesByEventIDAndTeacherID(eventID uint, teacherID uint) ([]models.Attendance, error) {
+	return repository.GetAttendancesByEventIDAndTeacherID(eventID, teacherID)
+}
+
 func CreateAttendance(attendance *models.Attendance) error {
 	return repository.CreateAttendance(attendance)
 }
+
+type CheckInService struct {
+	registrationRepo *repository.RegistrationRepository
+}
+
+func NewCheckInService(registrationRepo *repository.RegistrationRepository) *CheckInService {
+	return &CheckInService{
+		registrationRepo: registrationRepo,
+	}
+}
+
+// CheckIn records a self check-in to a session of an ongoing event after
+// verifying the session's rotating QR token and check-in window. Form tickets
+// of the check-in page are not accepted here, see CheckInFromPage. The attendance is marked late after the session's LateAfter time.
+// A repeat check-in with the same email (or phone, when the event dedupes by phone)
+// returns the existing attendance with duplicate set to true. Answers are checked
+// against the event's form schema. Events that require registration only accept
+// people holding a registered place. Check-ins are linked to a student where
+// possible and flagged for review otherwise.
+func (s *CheckInService) CheckIn(req *models.CreateAttendanceRequest) (attendance *models.Attendance, duplicate bool, err error) {
+	session, err := loadOngoingCheckinSession(req.SessionID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	if err := ValidateSessionCheckinToken(session, req.CheckinToken, now); err != nil {
+		return nil, false, err
+	}
+
+	return s.recordCheckIn(session, req, now, nil)
+}
+
+// loadOngoingCheckinSession loads a session to check in to, which must belong to an ongoing event
+func loadOngoingCheckinSession(sessionID uint) (*models.AttendanceSession, error) {
+	session, err := repository.GetAttendanceSessionWithEvent(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	if err := EnsureEventOngoing(session.Event); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// recordCheckIn applies duplicate detection, the registration requirement,
+// student matching, the session capacity and the check-in window at time at,
+// then stores the attendance. Kiosk uploads pass their clientID so replays are recognized.
+func (s *CheckInService) recordCheckIn(session *models.AttendanceSession, req *models.CreateAttendanceRequest, at time.Time, clientID *string) (attendance *models.Attendance, duplicate bool, err error) {
+	if clientID != nil {
+		existing, err := repository.GetAttendanceByClientID(*clientID)
+		if err == nil {
+			return existing, true, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+	}
+
+	emailNormalized := NormalizeEmail(req.Email)
+	phoneNormalized := optionalString(NormalizePhone(req.Phone))
+	var dedupePhone *string
+	if session.Event != nil && session.Event.DedupeByPhone != nil && *session.Event.DedupeByPhone {
+		dedupePhone = phoneNormalized
+	}
+
+	existing, err := repository.FindDuplicateAttendance(session.ID, emailNormalized, dedupePhone)
+	if err == nil {
+		return existing, true, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	answers, err := ValidateCheckinForm(session.Event, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.checkRegistration(session, emailNormalized, phoneNormalized); err != nil {
+		return nil, false, err
+	}
+
+	student, matchedBy, err := MatchStudent(session, req.StudentCode, emailNormalized, NormalizePhone(req.Phone))
+	if err != nil {
+		return nil, false, err
+	}
+	if student != nil {
+		existing, err := repository.GetAttendanceBySessionAndStudent(session.ID, student.ID)
+		if err == nil {
+			return existing, true, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+	}
+
+	status, err := EvaluateCheckinWindow(session, at)
+	if err != nil {
+		return nil, false, err
+	}
+
+	attendance = &models.Attendance{
+		SessionID:       &session.ID,
+		CheckedInAt:     &at,
+		Status:          &status,
+		StudentName:     &req.StudentName,
+		Email:           &req.Email,
+		Phone:           &req.Phone,
+		WorkUnit:        &req.WorkUnit,
+		WorkUnitAddress: &req.WorkUnitAddress,
+		Answers:         answers,
+		EmailNormalized: &emailNormalized,
+		PhoneNormalized: phoneNormalized,
+		PhoneDedupeKey:  dedupePhone,
+	}
+	needsReview := student == nil
+	attendance.NeedsReview = &needsReview
+	if student != nil {
+		attendance.StudentID = &student.ID
+		attendance.MatchedBy = &matchedBy
+	}
+	if clientID != nil {
+		source := models.AttendanceSourceKiosk
+		syncedAt := time.Now()
+		attendance.Source = &source
+		attendance.ClientID = clientID
+		attendance.SyncedAt = &syncedAt
+	}
+
+	// Kiosk uploads record people who were already let in
+	var capacity *int
+	if clientID == nil {
+		capacity = checkinCapacity(session)
+	}
+	created, err := repository.CreateAttendanceWithinCapacity(attendance, capacity)
+	if err != nil {
+		// A concurrent submission won the race for a unique index
+		if repository.IsDuplicateKeyError(err) {
+			if clientID != nil {
+				if existing, findErr := repository.GetAttendanceByClientID(*clientID); findErr == nil {
+					return existing, true, nil
+				}
+			}
+			if existing, findErr := repository.FindDuplicateAttendance(session.ID, emailNormalized, dedupePhone); findErr == nil {
+				return existing, true, nil
+			}
+		}
+		return nil, false, err
+	}
+
+	if !created {
+		return nil, false, ErrSessionFull
+	}
+
+	return attendance, false, nil
+}
+
+// checkRegistration returns an error unless the person checking in to the
+// session holds a registered (not waitlisted) place, for events that require
+// registration
+func (s *CheckInService) checkRegistration(session *models.AttendanceSession, emailNormalized string, phoneNormalized *string) error {
+	if session.Event == nil || session.Event.RequireRegistration == nil || !*session.Event.RequireRegistration {
+		return nil
+	}
+
+	registration, err := s.registrationRepo.FindActiveByContact(session.Event.ID, session.ID, emailNormalized, phoneNormalized)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotRegistered
+		}
+		return err
+	}
+	if registration.Status == models.RegistrationStatusWaitlisted {
+		return ErrRegistrationWaitlisted
+	}
+	return nil
+}
+
+// checkinCapacity is how many people may check in to a session (see
+// SessionCapacity); check-ins are refused once that many attendances are
+// checked in. Sessions of events that require registration are limited by
+// their registrations instead, so they return nil.
+func checkinCapacity(session *models.AttendanceSession) *int {
+	if session.Event != nil && session.Event.RequireRegistration != nil && *session.Event.RequireRegistration {
+		return nil
+	}
+	return SessionCapacity(session)
+}
+
+// MarkAttendances applies a teacher's marks to a session's class roster in one
+// transaction. Every student must be enrolled in the session's class on the
+// session date; marking a student again updates their existing attendance.
+func MarkAttendances(sessionID uint, req *models.BulkMarkAttendanceRequest, markedByUserID uint) ([]models.Attendance, error) {
+	session, err := repository.GetAttendanceSessionWithEvent(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	if session.ClassID == nil {
+		return nil, ErrSessionHasNoClass
+	}
+
+	students, err := repository.GetStudentsEnrolledOn(*session.ClassID, RosterDate(session))
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := BuildAttendanceMarks(session, students, req.Marks, markedByUserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.SaveAttendanceMarks(session.ID, attendances); err != nil {
+		return nil, err
+	}
+
+	return attendances, nil
+}
+
+// BuildAttendanceMarks turns a teacher's marks into attendances of the
+// session for the given class students. A student listed twice keeps the
+// last mark; marking a student outside the class fails with ErrStudentNotInClass.
+func BuildAttendanceMarks(session *models.AttendanceSession, students []models.Student, marks []models.AttendanceMark, markedByUserID uint, now time.Time) ([]models.Attendance, error) {
+	classStudents := make(map[uint]*models.Student, len(students))
+	for i := range students {
+		classStudents[students[i].ID] = &students[i]
+	}
+
+	order := make([]uint, 0, len(marks))
+	latest := make(map[uint]models.AttendanceMark, len(marks))
+	var unknown []uint
+	for _, mark := range marks {
+		if classStudents[mark.StudentID] == nil {
+			unknown = append(unknown, mark.StudentID)
+			continue
+		}
+		if _, seen := latest[mark.StudentID]; !seen {
+			order = append(order, mark.StudentID)
+		}
+		latest[mark.StudentID] = mark
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrStudentNotInClass, unknown)
+	}
+
+	source := models.AttendanceSourceTeacher
+	matchedBy := models.MatchedByManual
+	needsReview := false
+	attendances := make([]models.Attendance, 0, len(order))
+	for _, studentID := range order {
+		mark := latest[studentID]
+		student := classStudents[studentID]
+		status := mark.Status
+
+		attendance := models.Attendance{
+			SessionID:      &session.ID,
+			StudentID:      &student.ID,
+			StudentName:    student.StudentName,
+			Email:          student.Email,
+			Phone:          student.Phone,
+			WorkUnit:       student.WorkUnit,
+			Status:         &status,
+			Source:         &source,
+			Note:           mark.Note,
+			MatchedBy:      &matchedBy,
+			NeedsReview:    &needsReview,
+			MarkedByUserID: &markedByUserID,
+			MarkedAt:       &now,
+		}
+		if student.Email != nil {
+			attendance.EmailNormalized = optionalString(NormalizeEmail(*student.Email))
+		}
+		if student.Phone != nil {
+			attendance.PhoneNormalized = optionalString(NormalizePhone(*student.Phone))
+		}
+		if status == models.AttendanceStatusPresent || status == models.AttendanceStatusLate {
+			attendance.CheckedInAt = &now
+		}
+		attendances = append(attendances, attendance)
+	}
+	return attendances, nil
+}
@@ -13,10 +13,54 @@ func GetAttendanceSessionByID(id int) (*models.AttendanceSession, error) {
 	return repository.GetAttendanceSessionByID(id)
 }
 
+func GetAttendanceSessionWithEvent(id uint) (*models.AttendanceSession, error) {
+	return repository.GetAttendanceSessionWithEvent(id)
+}
+
 func GetAttendanceSessionsByEventID(eventID uint) ([]models.AttendanceSession, error) {
 	return repository.GetAttendanceSessionsByEventID(eventID)
 }
 
-func CreateAttendanceSession(session *models.AttendanceSession) error {
-	return repository.CreateAttendanceSession(session)
+func GetAttendanceSessionsByTeacherID(teacherID uint) ([]models.AttendanceSession, error) {
+	return repository.GetAttendanceSessionsByTeacherID(teacherID)
+}
+
+type AttendanceSessionService struct {
+	roomRepo *repository.RoomRepository
+}
+
+func NewAttendanceSessionService(roomRepo *repository.RoomRepository) *AttendanceSessionService {
+	return &AttendanceSessionService{
+		roomRepo: roomRepo,
+	}
+}
+
+// CreateAttendanceSession stores a session unless it overlaps another session
+// of the same teacher, class or room. The room, when given, must exist. With force it is stored anyway and the
+// overlapping sessions are returned as a warning.
+func (s *AttendanceSessionService) CreateAttendanceSession(session *models.AttendanceSession, force bool) ([]models.SessionConflict, error) {
+	if err := ValidateCheckinWindow(session); err != nil {
+		return nil, err
+	}
+	if err := ValidateSessionDuration(session); err != nil {
+		return nil, err
+	}
+	room, err := findRoom(s.roomRepo, session.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	session.Room = room
+
+	conflicts, err := CheckSessionConflicts(session)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 && !force {
+		return nil, &SessionConflictError{Conflicts: conflicts}
+	}
+
+	if err := repository.CreateAttendanceSession(session); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
 }
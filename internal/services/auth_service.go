@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthService struct {
+	userRepo *repository.UserRepository
+	cfg      config.JWTConfig
+}
+
+func NewAuthService(userRepo *repository.UserRepository, cfg config.JWTConfig) *AuthService {
+	return &AuthService{
+		userRepo: userRepo,
+		cfg:      cfg,
+	}
+}
+
+// Login verifies the credentials and issues a new token pair
+func (s *AuthService) Login(req *models.LoginRequest) (*models.TokenResponse, error) {
+	user, err := s.userRepo.GetByUsername(req.Username)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if user.IsActive != nil && !*user.IsActive {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issueTokens(user)
+}
+
+// RefreshToken exchanges a valid refresh token for a new token pair
+func (s *AuthService) RefreshToken(refreshToken string) (*models.TokenResponse, error) {
+	claims, err := s.parseToken(refreshToken, models.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-read the user so disabled or deleted accounts cannot keep refreshing
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if user.IsActive != nil && !*user.IsActive {
+		return nil, ErrInvalidToken
+	}
+
+	return s.issueTokens(user)
+}
+
+// ParseAccessToken validates an access token and returns its claims
+func (s *AuthService) ParseAccessToken(accessToken string) (*models.AuthClaims, error) {
+	return s.parseToken(accessToken, models.TokenTypeAccess)
+}
+
+// GetUserByID retrieves the account behind a token
+func (s *AuthService) GetUserByID(id uint) (*models.User, error) {
+	return s.userRepo.GetByID(id)
+}
+
+// CreateUser creates an account with a bcrypt-hashed password
+// linkedID is the teacher ID for teacher accounts and the student ID for student accounts.
+func (s *AuthService) CreateUser(username, password, role string, linkedID *uint) (*models.User, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+	if !models.IsValidRole(role) {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+	if role == models.RoleTeacher && linkedID == nil {
+		return nil, errors.New("teacher accounts must be linked to a teacher")
+	}
+	if role == models.RoleStudent && linkedID == nil {
+		return nil, errors.New("student accounts must be linked to a student")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         role,
+	}
+	switch role {
+	case models.RoleTeacher:
+		user.TeacherID = linkedID
+	case models.RoleStudent:
+		user.StudentID = linkedID
+	}
+
+	err = s.userRepo.Create(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *AuthService) issueTokens(user *models.User) (*models.TokenResponse, error) {
+	accessToken, err := s.signToken(user, models.TokenTypeAccess, s.cfg.AccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.signToken(user, models.TokenTypeRefresh, s.cfg.RefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.cfg.AccessTTL.Seconds()),
+	}, nil
+}
+
+func (s *AuthService) signToken(user *models.User, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := models.AuthClaims{
+		UserID:    user.ID,
+		Username:  user.Username,
+		TokenType: tokenType,
+		Role:      user.Role,
+		TeacherID: user.TeacherID,
+		StudentID: user.StudentID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.cfg.Issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(s.cfg.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %v", err)
+	}
+	return signed, nil
+}
+
+func (s *AuthService) parseToken(tokenString string, expectedType string) (*models.AuthClaims, error) {
+	claims := &models.AuthClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.cfg.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(s.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// An access token must not be usable as a refresh token and vice versa
+	if claims.TokenType != expectedType {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Calendar feeds are served without an access token so that calendar apps can
+// subscribe to them; instead every feed link carries an HMAC over its scope
+// and ID. Feed links do not expire.
+
+// Calendar feed scopes
+const (
+	CalendarScopeEvents   = "events"
+	CalendarScopeClasses  = "classes"
+	CalendarScopeTeachers = "teachers"
+)
+
+// calendarScopeColumns maps each feed scope to the session column it filters on
+var calendarScopeColumns = map[string]string{
+	CalendarScopeEvents:   "event_id",
+	CalendarScopeClasses:  "class_id",
+	CalendarScopeTeachers: "teacher_id",
+}
+
+const calendarTimeLayout = "20060102T150405Z"
+
+type CalendarService struct {
+	eventRepo   *repository.EventRepository
+	classRepo   *repository.ClassRepository
+	teacherRepo *repository.TeacherRepository
+}
+
+func NewCalendarService(eventRepo *repository.EventRepository, classRepo *repository.ClassRepository, teacherRepo *repository.TeacherRepository) *CalendarService {
+	return &CalendarService{
+		eventRepo:   eventRepo,
+		classRepo:   classRepo,
+		teacherRepo: teacherRepo,
+	}
+}
+
+// GetCalendarFeedLink returns the subscription link of the feed of an existing
+// event, class or teacher
+func (s *CalendarService) GetCalendarFeedLink(scope string, id uint) (*models.CalendarFeedResponse, error) {
+	if _, ok := calendarScopeColumns[scope]; !ok {
+		return nil, ErrCalendarNotFound
+	}
+	if _, err := s.calendarName(scope, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+	return CalendarFeedLink(scope, id), nil
+}
+
+// CalendarFeedLink returns the subscription link of a feed
+func CalendarFeedLink(scope string, id uint) *models.CalendarFeedResponse {
+	link := fmt.Sprintf("%s/%s/%d.ics?token=%s", config.CalendarFeedURL(), scope, id,
+		url.QueryEscape(CalendarFeedToken(config.CalendarFeedSecret(), scope, id)))
+
+	webcal := link
+	if _, rest, found := strings.Cut(link, "://"); found {
+		webcal = "webcal://" + rest
+	}
+	return &models.CalendarFeedResponse{Scope: scope, ID: id, URL: link, WebcalURL: webcal}
+}
+
+// CalendarFeedToken signs the scope and ID of a feed
+func CalendarFeedToken(secret []byte, scope string, id uint) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("calendar:" + scope + ":" + strconv.FormatUint(uint64(id), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// ValidateCalendarFeedToken checks that token was issued for the feed
+func ValidateCalendarFeedToken(secret []byte, scope string, id uint, token string) error {
+	if !hmac.Equal([]byte(token), []byte(CalendarFeedToken(secret, scope, id))) {
+		return ErrInvalidCalendarToken
+	}
+	return nil
+}
+
+// GetCalendarFeed checks the feed token and renders the sessions of an event,
+// class or teacher as an iCalendar document
+func (s *CalendarService) GetCalendarFeed(scope string, id uint, token string) ([]byte, error) {
+	column, ok := calendarScopeColumns[scope]
+	if !ok {
+		return nil, ErrCalendarNotFound
+	}
+	if err := ValidateCalendarFeedToken(config.CalendarFeedSecret(), scope, id, token); err != nil {
+		return nil, err
+	}
+
+	name, err := s.calendarName(scope, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	sessions, err := repository.GetCalendarSessions(column, id)
+	if err != nil {
+		return nil, err
+	}
+
+	link, _ := url.Parse(config.CalendarFeedURL())
+	return BuildCalendar(name, sessions, link.Hostname()), nil
+}
+
+// calendarName is the name calendar apps show for a feed
+func (s *CalendarService) calendarName(scope string, id uint) (string, error) {
+	switch scope {
+	case CalendarScopeEvents:
+		event, err := s.eventRepo.GetByID(id)
+		if err != nil {
+			return "", err
+		}
+		return derefString(event.EventName), nil
+	case CalendarScopeClasses:
+		class, err := s.classRepo.GetByID(id)
+		if err != nil {
+			return "", err
+		}
+		return derefString(class.ClassName), nil
+	default:
+		teacher, err := s.teacherRepo.GetByID(id)
+		if err != nil {
+			return "", err
+		}
+		return derefString(teacher.TeacherName), nil
+	}
+}
+
+// BuildCalendar renders sessions as an RFC 5545 calendar. Each session keeps
+// the UID "session-<id>@<domain>" so that calendar apps update the same entry
+// when the session changes; sessions without a date are left out.
+func BuildCalendar(name string, sessions []models.AttendanceSession, domain string) []byte {
+	var b strings.Builder
+	line := func(property, value string) {
+		writeCalendarLine(&b, property+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//hello-gin//Attendance Sessions//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeCalendarText(name))
+	line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	line("X-PUBLISHED-TTL", "PT1H")
+
+	for _, session := range sessions {
+		if session.SessionDate == nil {
+			continue
+		}
+		start, end := SessionTimes(session)
+		checkinLink := fmt.Sprintf("%s/%d", config.CheckinURL(), session.ID)
+
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("session-%d@%s", session.ID, domain))
+		line("DTSTAMP", session.UpdatedAt.UTC().Format(calendarTimeLayout))
+		line("LAST-MODIFIED", session.UpdatedAt.UTC().Format(calendarTimeLayout))
+		line("DTSTART", start.UTC().Format(calendarTimeLayout))
+		line("DTEND", end.UTC().Format(calendarTimeLayout))
+		line("SUMMARY", escapeCalendarText(calendarSummary(session)))
+		if location := calendarLocation(session); location != "" {
+			line("LOCATION", escapeCalendarText(location))
+		}
+		if room := session.Room; room != nil && room.Latitude != nil && room.Longitude != nil {
+			line("GEO", fmt.Sprintf("%.6f;%.6f", *room.Latitude, *room.Longitude))
+		}
+		line("DESCRIPTION", escapeCalendarText(calendarDescription(session, checkinLink)))
+		line("URL", checkinLink)
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// calendarLocation is the session's room, followed by its location when it
+// has both
+func calendarLocation(session models.AttendanceSession) string {
+	var parts []string
+	if session.Room != nil {
+		parts = append(parts, session.Room.Label())
+	}
+	if session.Location != nil && *session.Location != "" {
+		parts = append(parts, *session.Location)
+	}
+	return strings.Join(parts, " – ")
+}
+
+func calendarSummary(session models.AttendanceSession) string {
+	var parts []string
+	if session.Event != nil && session.Event.EventName != nil {
+		parts = append(parts, *session.Event.EventName)
+	}
+	if session.Class != nil && session.Class.ClassName != nil {
+		parts = append(parts, *session.Class.ClassName)
+	}
+	if len(parts) == 0 {
+		return "Attendance session"
+	}
+	return strings.Join(parts, " – ")
+}
+
+func calendarDescription(session models.AttendanceSession, checkinLink string) string {
+	var lines []string
+	if session.Teacher != nil && session.Teacher.TeacherName != nil {
+		lines = append(lines, "Teacher: "+*session.Teacher.TeacherName)
+	}
+	if session.OpensAt != nil && session.ClosesAt != nil {
+		lines = append(lines, fmt.Sprintf("Check-in open %s – %s",
+			session.OpensAt.In(config.DefaultLocation()).Format("15:04"),
+			session.ClosesAt.In(config.DefaultLocation()).Format("15:04")))
+	}
+	lines = append(lines, "Check in: "+checkinLink)
+	return strings.Join(lines, "\n")
+}
+
+// escapeCalendarText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeCalendarText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeCalendarLine writes a content line folded at 75 octets, without
+// splitting UTF-8 characters (RFC 5545 section 3.1)
+func writeCalendarLine(b *strings.Builder, line string) {
+	const limit = 75
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"bytes"
+	"hello-gin/internal/models"
+	"hello-gin/internal/web"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const certificateFont = "dejavu"
+
+// RenderCertificatePDF renders a certificate as a one-page landscape A4 PDF
+// with the verification code and a QR code linking to verifyURL
+func RenderCertificatePDF(event *models.Event, certificate *models.Certificate, verifyURL string) ([]byte, error) {
+	regular, err := web.Font("DejaVuSansCondensed.ttf")
+	if err != nil {
+		return nil, err
+	}
+	bold, err := web.Font("DejaVuSansCondensed-Bold.ttf")
+	if err != nil {
+		return nil, err
+	}
+	qr, err := RenderQRCodePNG(verifyURL, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetCreationDate(certificate.IssuedAt)
+	pdf.SetTitle("Certificate of Attendance - "+certificate.RecipientName, true)
+	pdf.AddUTF8FontFromBytes(certificateFont, "", regular)
+	pdf.AddUTF8FontFromBytes(certificateFont, "B", bold)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetMargins(25, 25, 25)
+	pdf.AddPage()
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	contentWidth := pageWidth - 50
+
+	// Double border
+	pdf.SetDrawColor(40, 70, 130)
+	pdf.SetLineWidth(1.5)
+	pdf.Rect(10, 10, pageWidth-20, pageHeight-20, "D")
+	pdf.SetLineWidth(0.4)
+	pdf.Rect(14, 14, pageWidth-28, pageHeight-28, "D")
+
+	pdf.SetTextColor(40, 70, 130)
+	pdf.SetFont(certificateFont, "B", 30)
+	pdf.SetXY(25, 35)
+	pdf.CellFormat(contentWidth, 14, "CERTIFICATE OF ATTENDANCE", "", 1, "C", false, 0, "")
+
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFont(certificateFont, "", 14)
+	pdf.Ln(8)
+	pdf.CellFormat(contentWidth, 8, "This is to certify that", "", 1, "C", false, 0, "")
+
+	pdf.SetTextColor(20, 20, 20)
+	pdf.SetFont(certificateFont, "B", 28)
+	pdf.Ln(4)
+	pdf.CellFormat(contentWidth, 14, certificate.RecipientName, "", 1, "C", false, 0, "")
+
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFont(certificateFont, "", 14)
+	pdf.Ln(6)
+	pdf.MultiCell(contentWidth, 8, RenderCertificateText(event, certificate), "", "C", false)
+
+	// Verification footer
+	qrSize := 32.0
+	qrX, qrY := pageWidth-25-qrSize, pageHeight-25-qrSize
+	pdf.RegisterImageOptionsReader("verify-qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("verify-qr", qrX, qrY, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, verifyURL)
+
+	pdf.SetFont(certificateFont, "", 10)
+	pdf.SetXY(25, pageHeight-25-14)
+	pdf.CellFormat(qrX-30, 6, "Issued "+certificate.IssuedAt.Local().Format("02/01/2006")+" · Verification code: "+certificate.Code, "", 1, "L", false, 0, "")
+	pdf.SetX(25)
+	pdf.CellFormat(qrX-30, 6, "Verify at "+verifyURL, "", 1, "L", false, 0, verifyURL)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// DefaultCertificateTemplate is the certificate text of events without their own template
+const DefaultCertificateTemplate = "has attended {event} on {dates}, for a total of {hours} hours."
+
+var certificatePlaceholder = regexp.MustCompile(`\{([a-z_]*)\}`)
+
+var certificatePlaceholders = map[string]bool{"name": true, "event": true, "dates": true, "hours": true}
+
+type CertificateService struct {
+	certificateRepo *repository.CertificateRepository
+	eventRepo       *repository.EventRepository
+}
+
+func NewCertificateService(certificateRepo *repository.CertificateRepository, eventRepo *repository.EventRepository) *CertificateService {
+	return &CertificateService{
+		certificateRepo: certificateRepo,
+		eventRepo:       eventRepo,
+	}
+}
+
+// GetAttendanceCertificate issues (or re-issues) the certificate of the person
+// behind an attendance, covering all their sessions of the event, and renders it as PDF
+func (s *CertificateService) GetAttendanceCertificate(attendanceID uint) (*models.Certificate, []byte, error) {
+	attendance, err := repository.GetAttendanceByID(int(attendanceID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAttendanceNotFound
+		}
+		return nil, nil, err
+	}
+	if attendance.Session == nil || attendance.Session.EventID == nil {
+		return nil, nil, ErrNotEligibleForCertificate
+	}
+
+	event, attendees, err := s.loadAttendees(*attendance.Session.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := attendeeKey(*attendance)
+	for _, attendee := range attendees {
+		if attendee.Key != key {
+			continue
+		}
+		if !IsEligibleForCertificate(event, attendee) {
+			return nil, nil, ErrNotEligibleForCertificate
+		}
+		certificate, err := s.issue(event, attendee)
+		if err != nil {
+			return nil, nil, err
+		}
+		pdf, err := RenderCertificatePDF(event, certificate, CertificateVerifyLink(certificate.Code))
+		if err != nil {
+			return nil, nil, err
+		}
+		return certificate, pdf, nil
+	}
+	return nil, nil, ErrNotEligibleForCertificate
+}
+
+// GetEventCertificates issues the certificates of every eligible attendee of
+// an event and returns them as a ZIP of PDFs, with the number of certificates
+func (s *CertificateService) GetEventCertificates(eventID uint) ([]byte, int, error) {
+	event, attendees, err := s.loadAttendees(eventID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	count := 0
+	names := make(map[string]bool)
+	for _, attendee := range attendees {
+		if !IsEligibleForCertificate(event, attendee) {
+			continue
+		}
+
+		certificate, err := s.issue(event, attendee)
+		if err != nil {
+			return nil, 0, err
+		}
+		pdf, err := RenderCertificatePDF(event, certificate, CertificateVerifyLink(certificate.Code))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Every issued certificate is in the archive, under a name of its own
+		name := UniqueFileName(CertificateFileName(certificate), names)
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, 0, err
+		}
+		if _, err := file.Write(pdf); err != nil {
+			return nil, 0, err
+		}
+		count++
+	}
+
+	if count == 0 {
+		return nil, 0, ErrNoEligibleAttendees
+	}
+	if err := archive.Close(); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), count, nil
+}
+
+// VerifyCertificate looks up a certificate by its verification code
+func (s *CertificateService) VerifyCertificate(code string) (*models.Certificate, error) {
+	certificate, err := s.certificateRepo.GetByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, err
+	}
+	return certificate, nil
+}
+
+func (s *CertificateService) loadAttendees(eventID uint) (*models.Event, []models.CertificateAttendee, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrEventNotFound
+		}
+		return nil, nil, err
+	}
+
+	attendances, err := repository.GetAttendancesByEventID(eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return event, CollectCertificateAttendees(attendances), nil
+}
+
+// issue stores the certificate of an attendee, keeping the verification code
+// of an earlier certificate for the same attendee
+func (s *CertificateService) issue(event *models.Event, attendee models.CertificateAttendee) (*models.Certificate, error) {
+	code, err := newCertificateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &models.Certificate{
+		EventID:       event.ID,
+		AttendeeKey:   attendee.Key,
+		AttendanceID:  attendee.AttendanceID,
+		StudentID:     attendee.StudentID,
+		RecipientName: attendee.Name,
+		Email:         attendee.Email,
+		Dates:         FormatCertificateDates(attendee.Dates),
+		Hours:         CertificateHours(attendee.TotalMinutes),
+		Code:          code,
+		IssuedAt:      time.Now(),
+	}
+	if err := s.certificateRepo.Save(certificate); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// CollectCertificateAttendees groups the check-ins of an event by person, in
+// name order. Marked absences and excuses are not counted.
+func CollectCertificateAttendees(attendances []models.Attendance) []models.CertificateAttendee {
+	sorted := append([]models.Attendance{}, attendances...)
+	sortAttendancesByCheckin(sorted)
+
+	byKey := make(map[string]*models.CertificateAttendee)
+	days := make(map[string]map[string]bool)
+	var order []string
+	for _, attendance := range sorted {
+		if attendance.CheckedInAt == nil {
+			continue
+		}
+
+		key := attendeeKey(attendance)
+		attendee, exists := byKey[key]
+		if !exists {
+			attendee = &models.CertificateAttendee{
+				Key:          key,
+				AttendanceID: attendance.ID,
+				StudentID:    attendance.StudentID,
+				Name:         derefString(attendance.StudentName),
+				Email:        derefString(attendance.Email),
+			}
+			if attendance.Student != nil && attendance.Student.StudentName != nil {
+				attendee.Name = *attendance.Student.StudentName
+			}
+			byKey[key] = attendee
+			days[key] = make(map[string]bool)
+			order = append(order, key)
+		}
+
+		day := *attendance.CheckedInAt
+		if attendance.Session != nil && attendance.Session.SessionDate != nil {
+			day = *attendance.Session.SessionDate
+		}
+		if dayKey := day.Local().Format("2006-01-02"); !days[key][dayKey] {
+			days[key][dayKey] = true
+			attendee.Dates = append(attendee.Dates, day)
+		}
+
+		if attendance.DurationMinutes != nil {
+			attendee.TotalMinutes += *attendance.DurationMinutes
+		}
+		if attendance.IsPartial != nil && *attendance.IsPartial {
+			attendee.PartialSessions++
+		}
+	}
+
+	attendees := make([]models.CertificateAttendee, 0, len(order))
+	for _, key := range order {
+		attendee := byKey[key]
+		sort.Slice(attendee.Dates, func(i, j int) bool { return attendee.Dates[i].Before(attendee.Dates[j]) })
+		attendees = append(attendees, *attendee)
+	}
+	sort.SliceStable(attendees, func(i, j int) bool {
+		if attendees[i].Name != attendees[j].Name {
+			return attendees[i].Name < attendees[j].Name
+		}
+		return attendees[i].Email < attendees[j].Email
+	})
+	return attendees
+}
+
+// IsEligibleForCertificate reports whether an attendee earned a certificate:
+// no session was left early, and the event's minimum hours are reached when set
+func IsEligibleForCertificate(event *models.Event, attendee models.CertificateAttendee) bool {
+	if len(attendee.Dates) == 0 || attendee.PartialSessions > 0 {
+		return false
+	}
+	if event.CertificateMinHours != nil && CertificateHours(attendee.TotalMinutes) < *event.CertificateMinHours {
+		return false
+	}
+	return true
+}
+
+// ValidateCertificateTemplate rejects placeholders other than {name}, {event},
+// {dates} and {hours}
+func ValidateCertificateTemplate(template string) error {
+	for _, match := range certificatePlaceholder.FindAllStringSubmatch(template, -1) {
+		if !certificatePlaceholders[match[1]] {
+			return fmt.Errorf("%w: unknown placeholder %s, use {name}, {event}, {dates} or {hours}", ErrInvalidCertificateTemplate, match[0])
+		}
+	}
+	return nil
+}
+
+// RenderCertificateText fills in the event's certificate template for a certificate
+func RenderCertificateText(event *models.Event, certificate *models.Certificate) string {
+	template := DefaultCertificateTemplate
+	if event.CertificateTemplate != nil && strings.TrimSpace(*event.CertificateTemplate) != "" {
+		template = *event.CertificateTemplate
+	}
+
+	return strings.NewReplacer(
+		"{name}", certificate.RecipientName,
+		"{event}", derefString(event.EventName),
+		"{dates}", certificate.Dates,
+		"{hours}", strconv.FormatFloat(certificate.Hours, 'f', -1, 64),
+	).Replace(template)
+}
+
+// FormatCertificateDates lists the attended days, or gives the first and last
+// day when there are more than three
+func FormatCertificateDates(dates []time.Time) string {
+	const layout = "02/01/2006"
+	if len(dates) > 3 {
+		return dates[0].Local().Format(layout) + " – " + dates[len(dates)-1].Local().Format(layout)
+	}
+	formatted := make([]string, 0, len(dates))
+	for _, date := range dates {
+		formatted = append(formatted, date.Local().Format(layout))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// CertificateHours converts attended minutes to hours, rounded to one decimal
+func CertificateHours(minutes int) float64 {
+	return math.Round(float64(minutes)/6) / 10
+}
+
+// CertificateVerifyLink is the public verification URL printed on a certificate
+func CertificateVerifyLink(code string) string {
+	return config.CertificateVerifyURL() + "/" + code
+}
+
+// CertificateFileName names a certificate PDF after its recipient and code
+func CertificateFileName(certificate *models.Certificate) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-':
+			return r
+		case unicode.IsSpace(r):
+			return '_'
+		default:
+			return -1
+		}
+	}, strings.TrimSpace(certificate.RecipientName))
+	if name == "" {
+		name = "certificate"
+	}
+	return name + "-" + certificate.Code + ".pdf"
+}
+
+// UniqueFileName returns name, or name with a -2, -3, ... suffix before its
+// extension when taken already, and marks the result as taken
+func UniqueFileName(name string, taken map[string]bool) string {
+	unique := name
+	ext := path.Ext(name)
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// newCertificateCode returns a random code such as "7KQ2M-XR4PD"
+func newCertificateCode() (string, error) {
+	random := make([]byte, 10)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	code := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(random)[:10]
+	return code[:5] + "-" + code[5:], nil
+}
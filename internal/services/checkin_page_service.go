@@ -0,0 +1,98 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetCheckinPageSession loads a session with the event and class names shown on the check-in page
+func GetCheckinPageSession(sessionID uint) (*models.AttendanceSession, error) {
+	session, err := repository.GetAttendanceSessionWithEventAndClass(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// OpenCheckinForm validates the rotating token from a scanned QR code and
+// returns a single-use form ticket that keeps the check-in form valid while it
+// is filled in.
+// Sessions of events that are not ongoing, or whose check-in window is closed,
+// are refused up front.
+func OpenCheckinForm(session *models.AttendanceSession, token string, now time.Time) (string, error) {
+	if err := EnsureEventOngoing(session.Event); err != nil {
+		return "", err
+	}
+	ttl, skew := CheckinTokenSettings(session.Event)
+	if err := ValidateCheckinToken(config.CheckinTokenSecret(), session.ID, token, ttl, skew, now); err != nil {
+		return "", err
+	}
+	if _, err := EvaluateCheckinWindow(session, now); err != nil {
+		return "", err
+	}
+
+	nonce, err := newCheckinFormNonce()
+	if err != nil {
+		return "", err
+	}
+	ticket := &models.CheckinFormTicket{
+		SessionID: session.ID,
+		Nonce:     nonce,
+		ExpiresAt: now.Add(config.CheckinFormTTL()),
+	}
+	if err := repository.CreateCheckinFormTicket(ticket, now); err != nil {
+		return "", err
+	}
+	return GenerateCheckinFormTicket(config.CheckinTokenSecret(), session.ID, nonce, now), nil
+}
+
+// CheckInFromPage records a check-in submitted from the check-in page with a
+// form ticket. The ticket is used up by the check-in; it is only given back
+// when the check-in fails, so that the attendee can correct the form.
+func (s *CheckInService) CheckInFromPage(req *models.CreateAttendanceRequest) (attendance *models.Attendance, duplicate bool, err error) {
+	session, err := loadOngoingCheckinSession(req.SessionID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	nonce, err := ValidateCheckinFormTicket(config.CheckinTokenSecret(), session.ID, req.CheckinToken, config.CheckinFormTTL(), now)
+	if err != nil {
+		return nil, false, err
+	}
+	used, err := repository.UseCheckinFormTicket(session.ID, nonce, now)
+	if err != nil {
+		return nil, false, err
+	}
+	if !used {
+		return nil, false, ErrCheckinTicketUsed
+	}
+
+	attendance, duplicate, err = s.recordCheckIn(session, req, now, nil)
+	if err != nil {
+		if releaseErr := repository.ReleaseCheckinFormTicket(session.ID, nonce); releaseErr != nil {
+			log.Printf("⚠️  Failed to release check-in form ticket of session %d: %v", session.ID, releaseErr)
+		}
+		return nil, false, err
+	}
+	return attendance, duplicate, nil
+}
+
+func newCheckinFormNonce() (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(random), nil
+}
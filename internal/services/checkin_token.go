@@ -0,0 +1,152 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Check-in tokens are TOTP-like: the current time is divided into steps of
+// ttl seconds and each step gets its own HMAC over the session ID, so the
+// token shown on the projector changes every ttl seconds and cannot be
+// reused for another session.
+//
+// Token format: "<step>.<base64url(hmac[:16])>"
+
+// CheckinTokenSettings returns the token lifetime and clock-skew tolerance for an event
+func CheckinTokenSettings(event *models.Event) (ttl time.Duration, skew time.Duration) {
+	ttl = config.DefaultCheckinTokenTTL()
+	skew = config.DefaultCheckinTokenSkew()
+	if event != nil {
+		if event.CheckinTokenTTLSeconds != nil && *event.CheckinTokenTTLSeconds > 0 {
+			ttl = time.Duration(*event.CheckinTokenTTLSeconds) * time.Second
+		}
+		if event.CheckinTokenSkewSeconds != nil && *event.CheckinTokenSkewSeconds >= 0 {
+			skew = time.Duration(*event.CheckinTokenSkewSeconds) * time.Second
+		}
+	}
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return ttl, skew
+}
+
+// SessionCheckinToken returns the current token of a session along with the URL the QR code should encode
+func SessionCheckinToken(session *models.AttendanceSession, now time.Time) *models.CheckinTokenResponse {
+	ttl, _ := CheckinTokenSettings(session.Event)
+	token, expiresAt := GenerateCheckinToken(config.CheckinTokenSecret(), session.ID, ttl, now)
+
+	return &models.CheckinTokenResponse{
+		SessionID:  session.ID,
+		Token:      token,
+		ExpiresAt:  expiresAt,
+		TTLSeconds: int(ttl.Seconds()),
+		CheckinURL: fmt.Sprintf("%s/%d?token=%s", config.CheckinURL(), session.ID, url.QueryEscape(token)),
+	}
+}
+
+// GenerateCheckinToken returns the token valid for sessionID at time now and when it expires
+func GenerateCheckinToken(secret []byte, sessionID uint, ttl time.Duration, now time.Time) (string, time.Time) {
+	step := now.Unix() / int64(ttl.Seconds())
+	expiresAt := time.Unix((step+1)*int64(ttl.Seconds()), 0)
+	return strconv.FormatInt(step, 10) + "." + signCheckinStep(secret, sessionID, step), expiresAt
+}
+
+// ValidateCheckinToken checks that token was issued for sessionID and is still
+// inside its step window, extended by skew on both sides
+func ValidateCheckinToken(secret []byte, sessionID uint, token string, ttl, skew time.Duration, now time.Time) error {
+	stepPart, signature, found := strings.Cut(token, ".")
+	if !found {
+		return ErrInvalidCheckinToken
+	}
+
+	step, err := strconv.ParseInt(stepPart, 10, 64)
+	if err != nil {
+		return ErrInvalidCheckinToken
+	}
+
+	expected := signCheckinStep(secret, sessionID, step)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidCheckinToken
+	}
+
+	seconds := int64(ttl.Seconds())
+	validFrom := time.Unix(step*seconds, 0).Add(-skew)
+	validUntil := time.Unix((step+1)*seconds, 0).Add(skew)
+	if now.Before(validFrom) || now.After(validUntil) {
+		return ErrExpiredCheckinToken
+	}
+
+	return nil
+}
+
+// Form tickets: a rotating token only lives for ttl seconds, which is not
+// enough to fill in the check-in form. When the check-in page is opened with
+// a valid token it hands out a ticket that stays valid for CHECKIN_FORM_TTL
+// and is good for a single check-in from that page. Each ticket carries a
+// random nonce that is stored when it is handed out and used up by the check-in.
+//
+// Ticket format: "form.<issued unix time>.<nonce>.<base64url(hmac[:16])>"
+
+const checkinFormTicketPrefix = "form."
+
+// GenerateCheckinFormTicket returns a form ticket for sessionID with the given nonce issued at now
+func GenerateCheckinFormTicket(secret []byte, sessionID uint, nonce string, now time.Time) string {
+	issued := now.Unix()
+	return checkinFormTicketPrefix + strconv.FormatInt(issued, 10) + "." + nonce + "." + signCheckinFormTicket(secret, sessionID, issued, nonce)
+}
+
+// ValidateCheckinFormTicket checks that ticket was issued for sessionID less
+// than ttl ago and returns its nonce
+func ValidateCheckinFormTicket(secret []byte, sessionID uint, ticket string, ttl time.Duration, now time.Time) (string, error) {
+	if !strings.HasPrefix(ticket, checkinFormTicketPrefix) {
+		return "", ErrInvalidCheckinToken
+	}
+	parts := strings.Split(strings.TrimPrefix(ticket, checkinFormTicketPrefix), ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return "", ErrInvalidCheckinToken
+	}
+	issuedPart, nonce, signature := parts[0], parts[1], parts[2]
+
+	issued, err := strconv.ParseInt(issuedPart, 10, 64)
+	if err != nil {
+		return "", ErrInvalidCheckinToken
+	}
+
+	expected := signCheckinFormTicket(secret, sessionID, issued, nonce)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "", ErrInvalidCheckinToken
+	}
+
+	issuedAt := time.Unix(issued, 0)
+	if now.Before(issuedAt.Add(-time.Minute)) || now.After(issuedAt.Add(ttl)) {
+		return "", ErrExpiredCheckinToken
+	}
+
+	return nonce, nil
+}
+
+// ValidateSessionCheckinToken checks the session's current rotating token
+func ValidateSessionCheckinToken(session *models.AttendanceSession, token string, now time.Time) error {
+	ttl, skew := CheckinTokenSettings(session.Event)
+	return ValidateCheckinToken(config.CheckinTokenSecret(), session.ID, token, ttl, skew, now)
+}
+
+func signCheckinFormTicket(secret []byte, sessionID uint, issued int64, nonce string) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "form:%d:%d:%s", sessionID, issued, nonce)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func signCheckinStep(secret []byte, sessionID uint, step int64) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d:%d", sessionID, step)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
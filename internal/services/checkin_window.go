@@ -0,0 +1,68 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"time"
+)
+
+// EvaluateCheckinWindow returns the status a check-in at time at receives.
+// Check-ins outside the window return ErrSessionNotOpen or ErrSessionClosed.
+func EvaluateCheckinWindow(session *models.AttendanceSession, at time.Time) (string, error) {
+	if session.OpensAt != nil && at.Before(*session.OpensAt) {
+		return models.AttendanceStatusRejected, ErrSessionNotOpen
+	}
+	if session.ClosesAt != nil && at.After(*session.ClosesAt) {
+		return models.AttendanceStatusRejected, ErrSessionClosed
+	}
+	if session.LateAfter != nil && at.After(*session.LateAfter) {
+		return models.AttendanceStatusLate, nil
+	}
+	return models.AttendanceStatusPresent, nil
+}
+
+// ValidateCheckinWindow checks that the window bounds that are set are in order
+func ValidateCheckinWindow(session *models.AttendanceSession) error {
+	bounds := []*time.Time{session.OpensAt, session.LateAfter, session.ClosesAt}
+	var previous *time.Time
+	for _, bound := range bounds {
+		if bound == nil {
+			continue
+		}
+		if previous != nil && bound.Before(*previous) {
+			return ErrInvalidCheckinWindow
+		}
+		previous = bound
+	}
+	return nil
+}
+
+// SummarizeAttendanceStatuses counts attendances per status; records without
+// a status predate check-in windows and count as present
+func SummarizeAttendanceStatuses(attendances []models.Attendance) map[string]int {
+	summary := map[string]int{
+		models.AttendanceStatusPresent: 0,
+		models.AttendanceStatusLate:    0,
+	}
+	for _, attendance := range attendances {
+		summary[attendanceStatus(&attendance)]++
+	}
+	return summary
+}
+
+// FilterAttendancesByStatus keeps only the attendances with the given status
+func FilterAttendancesByStatus(attendances []models.Attendance, status string) []models.Attendance {
+	filtered := make([]models.Attendance, 0, len(attendances))
+	for _, attendance := range attendances {
+		if attendanceStatus(&attendance) == status {
+			filtered = append(filtered, attendance)
+		}
+	}
+	return filtered
+}
+
+func attendanceStatus(attendance *models.Attendance) string {
+	if attendance.Status == nil || *attendance.Status == "" {
+		return models.AttendanceStatusPresent
+	}
+	return *attendance.Status
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ApplyCheckout sets the check-out time and attended duration of an
+// attendance, flagging it as partial when it is shorter than the session's
+// minimum duration. Checking out again moves the check-out time.
+func ApplyCheckout(attendance *models.Attendance, session *models.AttendanceSession, at time.Time) error {
+	if attendance.CheckedInAt == nil {
+		return ErrNotCheckedIn
+	}
+	if at.Before(*attendance.CheckedInAt) {
+		at = *attendance.CheckedInAt
+	}
+
+	minutes := int(at.Sub(*attendance.CheckedInAt).Minutes())
+	partial := false
+	if session != nil && session.MinDurationMinutes != nil && minutes < *session.MinDurationMinutes {
+		partial = true
+	}
+
+	attendance.CheckedOutAt = &at
+	attendance.DurationMinutes = &minutes
+	attendance.IsPartial = &partial
+	return nil
+}
+
+// CheckOutAttendance checks out an attendance on behalf of the attendee
+func CheckOutAttendance(attendance *models.Attendance) error {
+	if err := ApplyCheckout(attendance, attendance.Session, time.Now()); err != nil {
+		return err
+	}
+	return repository.UpdateAttendance(attendance)
+}
+
+// SelfCheckOut checks an attendee out of a session. Like check-in it requires
+// the session's current QR token, so attendees must still be in the room.
+func SelfCheckOut(req *models.CheckoutRequest) (*models.Attendance, error) {
+	session, err := repository.GetAttendanceSessionWithEvent(req.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := ValidateSessionCheckinToken(session, req.CheckinToken, now); err != nil {
+		return nil, err
+	}
+
+	attendance, err := repository.FindDuplicateAttendance(session.ID, NormalizeEmail(req.Email), nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttendanceNotFound
+		}
+		return nil, err
+	}
+
+	if err := ApplyCheckout(attendance, session, now); err != nil {
+		return nil, err
+	}
+	if err := repository.UpdateAttendance(attendance); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// BuildAttendanceReport totals the attended minutes of each person across
+// the given attendances. People are identified by their linked student, or
+// by normalized email for unmatched check-ins.
+func BuildAttendanceReport(attendances []models.Attendance) []models.AttendeeReport {
+	reports := make(map[string]*models.AttendeeReport)
+	for _, attendance := range attendances {
+		if attendance.CheckedInAt == nil {
+			continue // Marked absent or excused
+		}
+
+		key := attendeeKey(attendance)
+		report, exists := reports[key]
+		if !exists {
+			report = &models.AttendeeReport{StudentID: attendance.StudentID}
+			if attendance.StudentName != nil {
+				report.StudentName = *attendance.StudentName
+			}
+			if attendance.Email != nil {
+				report.Email = *attendance.Email
+			}
+			reports[key] = report
+		}
+
+		report.SessionsAttended++
+		if attendance.DurationMinutes == nil {
+			report.NotCheckedOut++
+			continue
+		}
+		report.TotalMinutes += *attendance.DurationMinutes
+		if attendance.IsPartial != nil && *attendance.IsPartial {
+			report.PartialSessions++
+		}
+	}
+
+	result := make([]models.AttendeeReport, 0, len(reports))
+	for _, report := range reports {
+		result = append(result, *report)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StudentName != result[j].StudentName {
+			return result[i].StudentName < result[j].StudentName
+		}
+		return result[i].Email < result[j].Email
+	})
+	return result
+}
+
+// attendeeKey identifies the person behind an attendance across sessions: the
+// linked student, or the normalized email for unmatched check-ins
+func attendeeKey(attendance models.Attendance) string {
+	switch {
+	case attendance.StudentID != nil:
+		return fmt.Sprintf("student:%d", *attendance.StudentID)
+	case attendance.EmailNormalized != nil:
+		return "email:" + *attendance.EmailNormalized
+	default:
+		return fmt.Sprintf("attendance:%d", attendance.ID)
+	}
+}
@@ -1,18 +1,116 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"hello-gin/internal/models"
 	"hello-gin/internal/repository"
+
+	"gorm.io/gorm"
 )
 
-func GetClasses() ([]models.Class, error) {
-	return repository.GetAllClasses()
+type ClassService struct {
+	classRepo *repository.ClassRepository
+}
+
+func NewClassService(classRepo *repository.ClassRepository) *ClassService {
+	return &ClassService{
+		classRepo: classRepo,
+	}
+}
+
+// GetClasses retrieves all classes
+func (s *ClassService) GetClasses() ([]models.Class, error) {
+	return s.classRepo.GetAll()
+}
+
+// GetClassByID retrieves a class with its students
+func (s *ClassService) GetClassByID(id uint) (*models.Class, error) {
+	class, err := s.classRepo.GetByIDWithStudents(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+	return class, nil
 }
 
-func GetClassByID(id int) (*models.Class, error) {
-	return repository.GetClassByID(id)
+// CreateClass creates a class with a code no other class uses
+func (s *ClassService) CreateClass(req *models.CreateClassRequest) (*models.Class, error) {
+	class := &models.Class{
+		ClassCode: &req.ClassCode,
+		ClassName: &req.ClassName,
+	}
+	if err := s.ensureCodeFree(class); err != nil {
+		return nil, err
+	}
+
+	if err := s.classRepo.Create(class); err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// UpdateClass changes the fields set in req
+func (s *ClassService) UpdateClass(id uint, req *models.UpdateClassRequest) (*models.Class, error) {
+	class, err := s.GetClassByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ClassCode != nil {
+		class.ClassCode = req.ClassCode
+	}
+	if req.ClassName != nil {
+		class.ClassName = req.ClassName
+	}
+	if err := s.ensureCodeFree(class); err != nil {
+		return nil, err
+	}
+
+	if err := s.classRepo.Update(class); err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// DeleteClass deletes a class and its enrollments. A class that still has
+// students is only deleted with cascade, which deletes its students too and
+// returns how many were. Sessions keep their class ID.
+func (s *ClassService) DeleteClass(id uint, cascade bool) (int64, error) {
+	if _, err := s.classRepo.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrClassNotFound
+		}
+		return 0, err
+	}
+
+	if !cascade {
+		students, err := s.classRepo.CountStudents(id)
+		if err != nil {
+			return 0, err
+		}
+		if students > 0 {
+			return 0, fmt.Errorf("%w (%d students)", ErrClassHasStudents, students)
+		}
+	}
+	return s.classRepo.Delete(id, cascade)
 }
 
-func CreateClass(class *models.Class) error {
-	return repository.CreateClass(class)
+func (s *ClassService) ensureCodeFree(class *models.Class) error {
+	if class.ClassCode == nil {
+		return nil
+	}
+	existing, err := s.classRepo.GetByCode(*class.ClassCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != class.ID {
+		return ErrClassCodeTaken
+	}
+	return nil
 }
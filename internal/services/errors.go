@@ -0,0 +1,85 @@
+package services
+
+import "errors"
+
+// Errors returned by services so controllers can map them to HTTP status codes
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+
+	ErrSessionNotFound      = errors.New("attendance session not found")
+	ErrInvalidCheckinToken  = errors.New("invalid check-in token")
+	ErrExpiredCheckinToken  = errors.New("check-in token has expired, please scan the QR code again")
+	ErrCheckinTicketUsed    = errors.New("this check-in form was already used, please scan the QR code again")
+	ErrSessionNotOpen       = errors.New("check-in for this session has not opened yet")
+	ErrSessionClosed        = errors.New("check-in for this session has closed")
+	ErrCheckinInFuture      = errors.New("check-in time is in the future, check the device clock")
+	ErrInvalidCheckinWindow = errors.New("check-in window must satisfy opens_at <= late_after <= closes_at")
+	ErrInvalidDuration      = errors.New("duration_minutes must be between 1 and 1440")
+	ErrSessionConflict      = errors.New("session overlaps another session of the same teacher, class or room")
+
+	ErrSeriesNotFound    = errors.New("session series not found")
+	ErrSeriesCancelled   = errors.New("session series has been cancelled")
+	ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+	ErrClassNotFound      = errors.New("class not found")
+	ErrClassCodeTaken     = errors.New("another class already has this class code")
+	ErrClassHasStudents   = errors.New("class still has students; delete them with cascade=true or move them first")
+	ErrTeacherNotFound    = errors.New("teacher not found")
+	ErrTeacherCodeTaken   = errors.New("another teacher already has this teacher code")
+	ErrTeacherHasSessions = errors.New("teacher still has upcoming sessions")
+
+	ErrRoomNotFound  = errors.New("room not found")
+	ErrRoomNameTaken = errors.New("a room with this name already exists")
+	ErrInvalidRoom   = errors.New("invalid room")
+	ErrRoomInUse     = errors.New("room still has upcoming sessions")
+	ErrSessionFull   = errors.New("this session is full")
+
+	ErrCalendarNotFound     = errors.New("calendar not found")
+	ErrInvalidCalendarToken = errors.New("invalid calendar feed token")
+
+	ErrStudentNotFound         = errors.New("student not found")
+	ErrStudentCodeTaken        = errors.New("another student already has this student code")
+	ErrStudentAlreadyCheckedIn = errors.New("student already has an attendance in this session")
+	ErrStudentNotInClass       = errors.New("student is not in the session's class")
+	ErrNotEnrolled             = errors.New("student is not enrolled in this class")
+	ErrAlreadyEnrolled         = errors.New("student is already enrolled in this class")
+	ErrInvalidEnrollmentRole   = errors.New("role must be student, auditor or assistant")
+	ErrInvalidEnrollmentDate   = errors.New("invalid enrollment date")
+	ErrSessionHasNoClass       = errors.New("attendance session has no class to mark")
+
+	ErrAttendanceNotFound = errors.New("attendance not found")
+	ErrNotCheckedIn       = errors.New("attendance has no check-in time to check out from")
+
+	ErrExcuseNotFound        = errors.New("excuse request not found")
+	ErrExcuseAlreadyExists   = errors.New("student already has a pending or approved excuse for this session")
+	ErrExcuseAlreadyReviewed = errors.New("excuse request has already been reviewed")
+	ErrInvalidAttachment     = errors.New("attachment must be a PDF, JPG or PNG file of at most 5 MB")
+
+	ErrEventNotFound               = errors.New("event not found")
+	ErrInvalidEventStatus          = errors.New("status must be draft, published, ongoing, closed or archived")
+	ErrInvalidEventTransition      = errors.New("event status cannot change this way")
+	ErrInvalidEventDates           = errors.New("end_date must not be before start_date")
+	ErrEventNotOngoing             = errors.New("this event is not open for check-in")
+	ErrInvalidCloneRequest         = errors.New("give either start_date or offset_days")
+	ErrRegistrationNotFound        = errors.New("registration not found")
+	ErrEventNotOpenForRegistration = errors.New("this event is not open for registration")
+	ErrAlreadyRegistered           = errors.New("this email is already registered or waitlisted")
+	ErrRegistrationCancelled       = errors.New("registration has already been cancelled")
+	ErrNotRegistered               = errors.New("this event requires registration before check-in")
+	ErrRegistrationWaitlisted      = errors.New("registration is still on the waitlist")
+
+	ErrInvalidFormSchema  = errors.New("invalid form schema")
+	ErrInvalidFormAnswers = errors.New("invalid form answers")
+
+	ErrInvalidCertificateTemplate = errors.New("invalid certificate template")
+	ErrNotEligibleForCertificate  = errors.New("attendee is not eligible for a certificate")
+	ErrNoEligibleAttendees        = errors.New("no attendee of this event is eligible for a certificate")
+	ErrCertificateNotFound        = errors.New("certificate not found")
+
+	ErrTrashResourceNotFound = errors.New("trash resource must be events, students, classes, teachers or rooms")
+	ErrNotInTrash            = errors.New("record is not in the trash")
+	ErrTrashRestoreConflict  = errors.New("another record already uses this code or name; change it before restoring")
+	ErrTrashParentDeleted    = errors.New("the record belongs to a record that is still in the trash; restore that first")
+	ErrTrashInUse            = errors.New("record is still referred to by other records and cannot be purged")
+)
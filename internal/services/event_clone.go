@@ -0,0 +1,107 @@
+package services
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"time"
+)
+
+// BuildEventClone copies an event and its sessions as a new draft event.
+// Dates move by req.OffsetDays calendar days, keeping their time of day in
+// APP_TIMEZONE, or by the distance from the event's start (or first session)
+// to req.StartDate. Class, teacher and location of the sessions are kept;
+// attendances, registrations and the link to a recurring series are not copied.
+func BuildEventClone(event *models.Event, req *models.CloneEventRequest) (*models.Event, error) {
+	shift, err := cloneShift(event, req)
+	if err != nil {
+		return nil, err
+	}
+
+	name := derefString(event.EventName) + " (copy)"
+	if req.EventName != nil && *req.EventName != "" {
+		name = *req.EventName
+	}
+
+	clone := &models.Event{
+		EventName:   &name,
+		Description: event.Description,
+		StartDate:   shift(event.StartDate),
+		EndDate:     shift(event.EndDate),
+		Status:      models.EventStatusDraft,
+
+		CheckinTokenTTLSeconds:  event.CheckinTokenTTLSeconds,
+		CheckinTokenSkewSeconds: event.CheckinTokenSkewSeconds,
+		DedupeByPhone:           event.DedupeByPhone,
+		Capacity:                event.Capacity,
+		RequireRegistration:     event.RequireRegistration,
+		FormSchema:              event.FormSchema,
+		CertificateTemplate:     event.CertificateTemplate,
+		CertificateMinHours:     event.CertificateMinHours,
+	}
+
+	for _, session := range event.Sessions {
+		clone.Sessions = append(clone.Sessions, models.AttendanceSession{
+			ClassID:     session.ClassID,
+			TeacherID:   session.TeacherID,
+			SessionDate: shift(session.SessionDate),
+			RoomID:      session.RoomID,
+			Location:    session.Location,
+			OpensAt:     shift(session.OpensAt),
+			LateAfter:   shift(session.LateAfter),
+			ClosesAt:    shift(session.ClosesAt),
+
+			DurationMinutes:    session.DurationMinutes,
+			MinDurationMinutes: session.MinDurationMinutes,
+			Capacity:           session.Capacity,
+		})
+	}
+	return clone, nil
+}
+
+// cloneShift returns the function that moves the dates of a cloned event
+func cloneShift(event *models.Event, req *models.CloneEventRequest) (func(*time.Time) *time.Time, error) {
+	if (req.StartDate == nil) == (req.OffsetDays == nil) {
+		return nil, ErrInvalidCloneRequest
+	}
+
+	var move func(time.Time) time.Time
+	if req.OffsetDays != nil {
+		location := config.DefaultLocation()
+		days := *req.OffsetDays
+		move = func(t time.Time) time.Time {
+			local := t.In(location)
+			return time.Date(local.Year(), local.Month(), local.Day()+days,
+				local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), location)
+		}
+	} else {
+		reference := cloneReference(event)
+		if reference == nil {
+			return nil, ErrInvalidCloneRequest
+		}
+		offset := req.StartDate.Sub(*reference)
+		move = func(t time.Time) time.Time { return t.Add(offset) }
+	}
+
+	return func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		moved := move(*t)
+		return &moved
+	}, nil
+}
+
+// cloneReference is the date a clone's start_date is aligned with: the event's
+// start date, or its first session when it has none
+func cloneReference(event *models.Event) *time.Time {
+	if event.StartDate != nil {
+		return event.StartDate
+	}
+	var first *time.Time
+	for _, session := range event.Sessions {
+		if session.SessionDate != nil && (first == nil || session.SessionDate.Before(*first)) {
+			first = session.SessionDate
+		}
+	}
+	return first
+}
@@ -1,8 +1,13 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"hello-gin/internal/models"
 	"hello-gin/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type EventService struct {
@@ -30,12 +35,29 @@ func (s *EventService) GetEventByIDWithSessions(id uint) (*models.Event, error)
 	return s.eventRepo.GetByIDWithSessions(id)
 }
 
-// CreateEvent creates a new event
+// CreateEvent creates a new event as a draft
 func (s *EventService) CreateEvent(req *models.CreateEventRequest) (*models.Event, error) {
+	if err := validateEventRequest(req, req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+
 	event := &models.Event{
 		EventName:   req.EventName,
 		Description: req.Description,
 		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Status:      models.EventStatusDraft,
+
+		CheckinTokenTTLSeconds:  req.CheckinTokenTTLSeconds,
+		CheckinTokenSkewSeconds: req.CheckinTokenSkewSeconds,
+		DedupeByPhone:           req.DedupeByPhone,
+		Capacity:                req.Capacity,
+		RequireRegistration:     req.RequireRegistration,
+		CertificateTemplate:     req.CertificateTemplate,
+		CertificateMinHours:     req.CertificateMinHours,
+	}
+	if req.FormSchema != nil {
+		event.FormSchema = *req.FormSchema
 	}
 
 	err := s.eventRepo.Create(event)
@@ -53,6 +75,17 @@ func (s *EventService) UpdateEvent(id uint, req *models.CreateEventRequest) (*mo
 		return nil, err
 	}
 
+	startDate, endDate := event.StartDate, event.EndDate
+	if req.StartDate != nil {
+		startDate = req.StartDate
+	}
+	if req.EndDate != nil {
+		endDate = req.EndDate
+	}
+	if err := validateEventRequest(req, startDate, endDate); err != nil {
+		return nil, err
+	}
+
 	// Update fields
 	if req.EventName != nil {
 		event.EventName = req.EventName
@@ -60,8 +93,32 @@ func (s *EventService) UpdateEvent(id uint, req *models.CreateEventRequest) (*mo
 	if req.Description != nil {
 		event.Description = req.Description
 	}
-	if req.StartDate != nil {
-		event.StartDate = req.StartDate
+	event.StartDate = startDate
+	event.EndDate = endDate
+	if req.CheckinTokenTTLSeconds != nil {
+		event.CheckinTokenTTLSeconds = req.CheckinTokenTTLSeconds
+	}
+	if req.CheckinTokenSkewSeconds != nil {
+		event.CheckinTokenSkewSeconds = req.CheckinTokenSkewSeconds
+	}
+	if req.DedupeByPhone != nil {
+		event.DedupeByPhone = req.DedupeByPhone
+	}
+	if req.Capacity != nil {
+		event.Capacity = req.Capacity
+	}
+	if req.RequireRegistration != nil {
+		event.RequireRegistration = req.RequireRegistration
+	}
+	if req.FormSchema != nil {
+		// Answers already stored keep the keys they were given under
+		event.FormSchema = *req.FormSchema
+	}
+	if req.CertificateTemplate != nil {
+		event.CertificateTemplate = req.CertificateTemplate
+	}
+	if req.CertificateMinHours != nil {
+		event.CertificateMinHours = req.CertificateMinHours
 	}
 
 	err = s.eventRepo.Update(event)
@@ -72,30 +129,171 @@ func (s *EventService) UpdateEvent(id uint, req *models.CreateEventRequest) (*mo
 	return event, nil
 }
 
+// CloneEvent copies an event and its sessions to new dates as a draft event
+// (see BuildEventClone). Moved sessions that overlap other sessions of the
+// same teacher, class or room are refused unless req.Force is set, in which
+// case the overlapping sessions are returned as a warning.
+func (s *EventService) CloneEvent(id uint, req *models.CloneEventRequest) (*models.Event, []models.SessionConflict, error) {
+	event, err := s.eventRepo.GetByIDWithSessions(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrEventNotFound
+		}
+		return nil, nil, err
+	}
+
+	clone, err := BuildEventClone(event, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conflicts, err := CheckPlannedSessionConflicts(clone.Sessions, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(conflicts) > 0 && !req.Force {
+		return nil, nil, &SessionConflictError{Conflicts: conflicts}
+	}
+
+	if err := s.eventRepo.Create(clone); err != nil {
+		return nil, nil, err
+	}
+	return clone, conflicts, nil
+}
+
 // DeleteEvent deletes an event by ID
 func (s *EventService) DeleteEvent(id uint) error {
 	return s.eventRepo.Delete(id)
 }
 
-// GetActiveEvents retrieves all active events
+// GetActiveEvents retrieves all ongoing events
 func (s *EventService) GetActiveEvents() ([]models.Event, error) {
-	return s.eventRepo.GetActiveEvents()
+	return s.eventRepo.GetByStatus(models.EventStatusOngoing)
 }
 
-// SetEventActive sets the active status of an event
-func (s *EventService) SetEventActive(id uint, isActive bool) (*models.Event, error) {
+// SetEventStatus moves an event to another status if the transition is allowed:
+// draft -> published|archived, published -> draft|ongoing|closed,
+// ongoing -> closed, closed -> ongoing|archived. Archived events are final.
+// The change is recorded in the event's status log.
+func (s *EventService) SetEventStatus(id uint, status string) (*models.Event, error) {
 	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
 		return nil, err
 	}
 
-	// Set the specific active status
-	event.IsActive = &isActive
+	if err := s.changeStatus(event, status); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
 
-	err = s.eventRepo.Update(event)
+// SetEventActive backs the deprecated active flag: active events are moved to
+// ongoing, publishing drafts first, and inactive ones are closed (see
+// EventActiveSteps). Each step is checked and logged like SetEventStatus.
+func (s *EventService) SetEventActive(id uint, active bool) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
 		return nil, err
 	}
 
+	for _, status := range EventActiveSteps(event.Status, active) {
+		if err := s.changeStatus(event, status); err != nil {
+			return nil, err
+		}
+	}
 	return event, nil
 }
+
+// changeStatus moves a loaded event to status by hand and logs the change
+func (s *EventService) changeStatus(event *models.Event, status string) error {
+	if err := ValidateEventTransition(event.Status, status); err != nil {
+		return err
+	}
+
+	entry := &models.EventStatusLog{
+		FromStatus: event.Status,
+		ToStatus:   status,
+		Source:     models.EventStatusSourceManual,
+	}
+	changed, err := s.eventRepo.ChangeStatus(event, entry, time.Now())
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return fmt.Errorf("%w: the status was changed at the same time, try again", ErrInvalidEventTransition)
+	}
+	return nil
+}
+
+// GetEventStatusLog retrieves the status changes of an event, oldest first
+func (s *EventService) GetEventStatusLog(id uint) ([]models.EventStatusLog, error) {
+	if _, err := s.eventRepo.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	return s.eventRepo.GetStatusLogs(id)
+}
+
+// eventScheduleLock is the Postgres advisory lock key held while scheduled
+// status changes are applied, so only one server instance applies them
+const eventScheduleLock int64 = 0x6576656e7473 // "events"
+
+// ApplyScheduledTransitions starts published events whose start date has
+// passed and closes events whose end date has passed, logging each change.
+// It does nothing when another server instance is applying them already.
+func (s *EventService) ApplyScheduledTransitions(now time.Time) ([]models.EventStatusLog, error) {
+	var logs []models.EventStatusLog
+	_, err := s.eventRepo.WithAdvisoryLock(eventScheduleLock, func(repo *repository.EventRepository) error {
+		events, err := repo.GetScheduleCandidates(now)
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			status, reason, due := ScheduledEventStatus(&events[i], now)
+			if !due {
+				continue
+			}
+			entry := &models.EventStatusLog{
+				FromStatus: events[i].Status,
+				ToStatus:   status,
+				Source:     models.EventStatusSourceScheduler,
+				Reason:     reason,
+			}
+			changed, err := repo.ChangeStatus(&events[i], entry, now)
+			if err != nil {
+				return err
+			}
+			if changed {
+				logs = append(logs, *entry)
+			}
+		}
+		return nil
+	})
+	return logs, err
+}
+
+func validateEventRequest(req *models.CreateEventRequest, startDate, endDate *time.Time) error {
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return ErrInvalidEventDates
+	}
+	if req.FormSchema != nil {
+		if err := ValidateFormSchema(*req.FormSchema); err != nil {
+			return err
+		}
+	}
+	if req.CertificateTemplate != nil {
+		if err := ValidateCertificateTemplate(*req.CertificateTemplate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
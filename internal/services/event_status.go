@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"hello-gin/internal/models"
+	"time"
+)
+
+// eventTransitions lists the statuses each event status may change to.
+// Closed events can be reopened; archived events are final.
+var eventTransitions = map[string][]string{
+	models.EventStatusDraft:     {models.EventStatusPublished, models.EventStatusArchived},
+	models.EventStatusPublished: {models.EventStatusDraft, models.EventStatusOngoing, models.EventStatusClosed},
+	models.EventStatusOngoing:   {models.EventStatusClosed},
+	models.EventStatusClosed:    {models.EventStatusOngoing, models.EventStatusArchived},
+	models.EventStatusArchived:  {},
+}
+
+// IsEventStatus reports whether status is one of the event statuses
+func IsEventStatus(status string) bool {
+	_, ok := eventTransitions[status]
+	return ok
+}
+
+// ValidateEventTransition checks that an event may change from one status to another
+func ValidateEventTransition(from, to string) error {
+	if !IsEventStatus(to) {
+		return ErrInvalidEventStatus
+	}
+	for _, allowed := range eventTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s -> %s", ErrInvalidEventTransition, from, to)
+}
+
+// EventActiveSteps returns the statuses the deprecated active flag moves an
+// event through, in order. Active drafts are published on the way to ongoing;
+// inactive drafts, closed and archived events stay as they are.
+func EventActiveSteps(from string, active bool) []string {
+	if !active {
+		if from == models.EventStatusPublished || from == models.EventStatusOngoing {
+			return []string{models.EventStatusClosed}
+		}
+		return nil
+	}
+	switch from {
+	case models.EventStatusOngoing:
+		return nil
+	case models.EventStatusDraft:
+		return []string{models.EventStatusPublished, models.EventStatusOngoing}
+	default:
+		return []string{models.EventStatusOngoing}
+	}
+}
+
+// ScheduledEventStatus returns the status the scheduler should move an event
+// to at time now, and why. Published events become ongoing once StartDate has
+// passed; published and ongoing events are closed once EndDate has passed,
+// unless they were set to their status by hand after EndDate (e.g. reopened).
+// Dates are instants, so the comparison does not depend on the server's time zone.
+func ScheduledEventStatus(event *models.Event, now time.Time) (status string, reason string, due bool) {
+	ended := event.EndDate != nil && !now.Before(*event.EndDate) &&
+		(event.StatusChangedAt == nil || event.StatusChangedAt.Before(*event.EndDate))
+	started := event.StartDate != nil && !now.Before(*event.StartDate)
+
+	switch event.Status {
+	case models.EventStatusPublished:
+		if ended {
+			return models.EventStatusClosed, "end_date reached", true
+		}
+		if started {
+			return models.EventStatusOngoing, "start_date reached", true
+		}
+	case models.EventStatusOngoing:
+		if ended {
+			return models.EventStatusClosed, "end_date reached", true
+		}
+	}
+	return "", "", false
+}
+
+// EnsureEventOngoing rejects check-ins to sessions of events that are not
+// ongoing. Sessions without an event are not restricted.
+func EnsureEventOngoing(event *models.Event) error {
+	if event != nil && event.Status != models.EventStatusOngoing {
+		return ErrEventNotOngoing
+	}
+	return nil
+}
+
+// EnsureEventOpenForRegistration rejects registrations for events that are
+// not published or ongoing: drafts are not announced yet, and closed or
+// archived events are over
+func EnsureEventOpenForRegistration(event *models.Event) error {
+	if event.Status != models.EventStatusPublished && event.Status != models.EventStatusOngoing {
+		return ErrEventNotOpenForRegistration
+	}
+	return nil
+}
+
+// EnsureEventAcceptsOfflineCheckins is EnsureEventOngoing for kiosk uploads,
+// which may arrive after the event was closed; the session's check-in window
+// still applies to the device time of each check-in
+func EnsureEventAcceptsOfflineCheckins(event *models.Event) error {
+	if event != nil && event.Status == models.EventStatusClosed {
+		return nil
+	}
+	return EnsureEventOngoing(event)
+}
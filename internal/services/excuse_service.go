@@ -0,0 +1,213 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxExcuseAttachmentSize is the largest attachment accepted with an excuse request
+const MaxExcuseAttachmentSize = 5 << 20
+
+var allowedExcuseAttachmentExts = map[string]bool{
+	".pdf":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+type ExcuseService struct {
+	excuseRepo     *repository.ExcuseRepository
+	enrollmentRepo *repository.EnrollmentRepository
+}
+
+func NewExcuseService(excuseRepo *repository.ExcuseRepository, enrollmentRepo *repository.EnrollmentRepository) *ExcuseService {
+	return &ExcuseService{
+		excuseRepo:     excuseRepo,
+		enrollmentRepo: enrollmentRepo,
+	}
+}
+
+// GetExcuses retrieves excuse requests matching the filter
+func (s *ExcuseService) GetExcuses(filter models.ExcuseFilter) ([]models.ExcuseRequest, error) {
+	return s.excuseRepo.GetAll(filter)
+}
+
+// GetExcuseByID retrieves an excuse request with its history
+func (s *ExcuseService) GetExcuseByID(id uint) (*models.ExcuseRequest, error) {
+	excuse, err := s.excuseRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExcuseNotFound
+		}
+		return nil, err
+	}
+	return excuse, nil
+}
+
+// GetExcuseHistory retrieves the history of an excuse request
+func (s *ExcuseService) GetExcuseHistory(id uint) ([]models.ExcuseRequestEvent, error) {
+	return s.excuseRepo.GetHistory(id)
+}
+
+// SubmitExcuse creates a pending excuse request for a student who is
+// expected at the session, saving the optional attachment
+func (s *ExcuseService) SubmitExcuse(req *models.CreateExcuseRequest, attachment *multipart.FileHeader, submittedBy *uint) (*models.ExcuseRequest, error) {
+	session, err := repository.GetAttendanceSessionByID(int(req.SessionID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	student, err := repository.GetStudentByID(req.StudentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, err
+	}
+	if session.ClassID != nil {
+		enrolled, err := s.enrollmentRepo.IsEnrolled(student.ID, *session.ClassID, RosterDate(session))
+		if err != nil {
+			return nil, err
+		}
+		if !enrolled {
+			return nil, ErrStudentNotInClass
+		}
+	}
+
+	open, err := s.excuseRepo.HasOpenRequest(session.ID, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, ErrExcuseAlreadyExists
+	}
+
+	excuse := &models.ExcuseRequest{
+		SessionID:         session.ID,
+		StudentID:         student.ID,
+		Reason:            strings.TrimSpace(req.Reason),
+		Status:            models.ExcuseStatusPending,
+		SubmittedByUserID: submittedBy,
+	}
+
+	if attachment != nil {
+		path, err := saveExcuseAttachment(attachment)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(attachment.Filename)
+		excuse.AttachmentPath = &path
+		excuse.AttachmentName = &name
+	}
+
+	event := &models.ExcuseRequestEvent{
+		Action:      models.ExcuseActionSubmitted,
+		ToStatus:    models.ExcuseStatusPending,
+		ActorUserID: submittedBy,
+	}
+	if err := s.excuseRepo.Create(excuse, event); err != nil {
+		if excuse.AttachmentPath != nil {
+			os.Remove(*excuse.AttachmentPath)
+		}
+		return nil, err
+	}
+
+	return s.GetExcuseByID(excuse.ID)
+}
+
+// ReviewExcuse approves or rejects a pending excuse request
+func (s *ExcuseService) ReviewExcuse(id uint, approve bool, req *models.ReviewExcuseRequest, reviewerID *uint) (*models.ExcuseRequest, error) {
+	action, status := models.ExcuseActionRejected, models.ExcuseStatusRejected
+	if approve {
+		action, status = models.ExcuseActionApproved, models.ExcuseStatusApproved
+	}
+
+	fromStatus := models.ExcuseStatusPending
+	event := &models.ExcuseRequestEvent{
+		Action:      action,
+		FromStatus:  &fromStatus,
+		ToStatus:    status,
+		ActorUserID: reviewerID,
+		Note:        req.Note,
+	}
+
+	reviewed, err := s.excuseRepo.Review(id, reviewerID, time.Now(), event)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		if _, err := s.GetExcuseByID(id); err != nil {
+			return nil, err
+		}
+		return nil, ErrExcuseAlreadyReviewed
+	}
+
+	return s.GetExcuseByID(id)
+}
+
+// ValidateExcuseAttachment checks the size and file type of an attachment
+func ValidateExcuseAttachment(attachment *multipart.FileHeader) error {
+	if attachment.Size <= 0 || attachment.Size > MaxExcuseAttachmentSize {
+		return ErrInvalidAttachment
+	}
+	if !allowedExcuseAttachmentExts[strings.ToLower(filepath.Ext(attachment.Filename))] {
+		return ErrInvalidAttachment
+	}
+	return nil
+}
+
+// saveExcuseAttachment stores an attachment under UPLOAD_DIR/excuses with a
+// random file name and returns its path
+func saveExcuseAttachment(attachment *multipart.FileHeader) (string, error) {
+	if err := ValidateExcuseAttachment(attachment); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(config.UploadDir(), "excuses")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, hex.EncodeToString(random)+strings.ToLower(filepath.Ext(attachment.Filename)))
+
+	src, err := attachment.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(dst, io.LimitReader(src, MaxExcuseAttachmentSize)); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"fmt"
+	"hello-gin/internal/models"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxFormAnswerLength = 1000
+
+var formFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// FormValidationError lists the problems with submitted form answers by field key
+type FormValidationError struct {
+	Fields map[string]string
+}
+
+func (e *FormValidationError) Error() string {
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	problems := make([]string, 0, len(keys))
+	for _, key := range keys {
+		problems = append(problems, key+": "+e.Fields[key])
+	}
+	return ErrInvalidFormAnswers.Error() + ": " + strings.Join(problems, "; ")
+}
+
+// Unwrap lets callers match the error with errors.Is(err, ErrInvalidFormAnswers)
+func (e *FormValidationError) Unwrap() error {
+	return ErrInvalidFormAnswers
+}
+
+// ValidateFormSchema checks that every field has a unique key, a label, a
+// known type, and choices when the type needs them
+func ValidateFormSchema(schema models.FormSchema) error {
+	seen := make(map[string]bool, len(schema))
+	for i, field := range schema {
+		switch {
+		case !formFieldKeyPattern.MatchString(field.Key):
+			return fmt.Errorf("%w: field %d: key must be lowercase letters, digits and underscores", ErrInvalidFormSchema, i+1)
+		case seen[field.Key]:
+			return fmt.Errorf("%w: duplicate key %q", ErrInvalidFormSchema, field.Key)
+		case strings.TrimSpace(field.Label) == "":
+			return fmt.Errorf("%w: field %q has no label", ErrInvalidFormSchema, field.Key)
+		}
+		seen[field.Key] = true
+
+		switch field.Type {
+		case models.FormFieldText, models.FormFieldTextarea, models.FormFieldNumber, models.FormFieldEmail,
+			models.FormFieldPhone, models.FormFieldDate, models.FormFieldCheckbox:
+			if len(field.Choices) > 0 {
+				return fmt.Errorf("%w: field %q of type %s cannot have choices", ErrInvalidFormSchema, field.Key, field.Type)
+			}
+		case models.FormFieldSelect, models.FormFieldMultiSelect:
+			if len(field.Choices) == 0 {
+				return fmt.Errorf("%w: field %q needs choices", ErrInvalidFormSchema, field.Key)
+			}
+			choices := make(map[string]bool, len(field.Choices))
+			for _, choice := range field.Choices {
+				if strings.TrimSpace(choice) == "" || choices[choice] {
+					return fmt.Errorf("%w: field %q has an empty or duplicate choice", ErrInvalidFormSchema, field.Key)
+				}
+				choices[choice] = true
+			}
+		default:
+			return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidFormSchema, field.Key, field.Type)
+		}
+	}
+	return nil
+}
+
+// ValidateFormAnswers checks answers against a form schema and returns them
+// normalized: strings trimmed, numbers as float64, checkboxes as bool and
+// multi-selects as string lists. Empty optional answers are dropped. Form
+// posts send every value as a string, which is accepted for all field types.
+func ValidateFormAnswers(schema models.FormSchema, answers models.FormAnswers) (models.FormAnswers, error) {
+	problems := make(map[string]string)
+	cleaned := make(models.FormAnswers)
+
+	known := make(map[string]bool, len(schema))
+	for _, field := range schema {
+		known[field.Key] = true
+
+		value, empty, problem := normalizeFormAnswer(field, answers[field.Key])
+		switch {
+		case problem != "":
+			problems[field.Key] = problem
+		case empty && field.Required:
+			problems[field.Key] = "is required"
+		case !empty:
+			cleaned[field.Key] = value
+		}
+	}
+	for key := range answers {
+		if !known[key] {
+			problems[key] = "is not a field of this form"
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &FormValidationError{Fields: problems}
+	}
+	if len(cleaned) == 0 {
+		return nil, nil
+	}
+	return cleaned, nil
+}
+
+// ValidateCheckinForm validates the form part of a check-in. Events with a form
+// schema get their answers validated; events without one keep the original
+// form, which requires the work unit and its address.
+func ValidateCheckinForm(event *models.Event, req *models.CreateAttendanceRequest) (models.FormAnswers, error) {
+	if event == nil || len(event.FormSchema) == 0 {
+		problems := make(map[string]string)
+		if strings.TrimSpace(req.WorkUnit) == "" {
+			problems["work_unit"] = "is required"
+		}
+		if strings.TrimSpace(req.WorkUnitAddress) == "" {
+			problems["work_unit_address"] = "is required"
+		}
+		for key := range req.Answers {
+			problems[key] = "is not a field of this form"
+		}
+		if len(problems) > 0 {
+			return nil, &FormValidationError{Fields: problems}
+		}
+		return nil, nil
+	}
+	return ValidateFormAnswers(event.FormSchema, req.Answers)
+}
+
+// normalizeFormAnswer converts one answer to its stored form. empty is set
+// for missing answers; problem describes an answer that is not acceptable.
+func normalizeFormAnswer(field models.FormField, value interface{}) (normalized interface{}, empty bool, problem string) {
+	if value == nil {
+		return nil, true, ""
+	}
+
+	switch field.Type {
+	case models.FormFieldCheckbox:
+		switch v := value.(type) {
+		case bool:
+			if !v && field.Required {
+				return nil, true, "" // A required checkbox has to be ticked
+			}
+			return v, false, ""
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "":
+				return nil, true, ""
+			case "true", "on", "yes", "1":
+				return true, false, ""
+			case "false", "off", "no", "0":
+				if field.Required {
+					return nil, true, ""
+				}
+				return false, false, ""
+			}
+		}
+		return nil, false, "must be true or false"
+
+	case models.FormFieldNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, false, ""
+		case string:
+			v = strings.TrimSpace(v)
+			if v == "" {
+				return nil, true, ""
+			}
+			number, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, false, "must be a number"
+			}
+			return number, false, ""
+		}
+		return nil, false, "must be a number"
+
+	case models.FormFieldMultiSelect:
+		var picked []string
+		switch v := value.(type) {
+		case string:
+			if strings.TrimSpace(v) != "" {
+				picked = []string{v}
+			}
+		case []string:
+			picked = v
+		case []interface{}:
+			for _, item := range v {
+				choice, ok := item.(string)
+				if !ok {
+					return nil, false, "must be a list of choices"
+				}
+				picked = append(picked, choice)
+			}
+		default:
+			return nil, false, "must be a list of choices"
+		}
+
+		selected := []string{}
+		seen := make(map[string]bool)
+		for _, choice := range picked {
+			choice = strings.TrimSpace(choice)
+			if choice == "" || seen[choice] {
+				continue
+			}
+			if !containsString(field.Choices, choice) {
+				return nil, false, fmt.Sprintf("%q is not one of the choices", choice)
+			}
+			seen[choice] = true
+			selected = append(selected, choice)
+		}
+		if len(selected) == 0 {
+			return nil, true, ""
+		}
+		return selected, false, ""
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return nil, false, "must be text"
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, true, ""
+	}
+	if len(text) > maxFormAnswerLength {
+		return nil, false, fmt.Sprintf("must be at most %d characters", maxFormAnswerLength)
+	}
+
+	switch field.Type {
+	case models.FormFieldEmail:
+		if address, err := mail.ParseAddress(text); err != nil || address.Address != text {
+			return nil, false, "must be an email address"
+		}
+	case models.FormFieldPhone:
+		if digits := NormalizePhone(text); len(digits) < 8 || len(digits) > 15 {
+			return nil, false, "must be a phone number"
+		}
+	case models.FormFieldDate:
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, false, "must be a date (YYYY-MM-DD)"
+		}
+	case models.FormFieldSelect:
+		if !containsString(field.Choices, text) {
+			return nil, false, fmt.Sprintf("%q is not one of the choices", text)
+		}
+	}
+	return text, false, ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxKioskClockDrift is how far in the future a device timestamp may be
+// before the check-in is rejected
+const maxKioskClockDrift = 5 * time.Minute
+
+// SyncKioskAttendances applies check-ins uploaded by a kiosk in order. Each
+// item is identified by its client ID, so uploading the same batch again
+// reports the items as duplicates instead of creating them twice. The
+// check-in window is evaluated against the device time of each item, and
+// uploads are still accepted after the event was closed.
+func (s *CheckInService) SyncKioskAttendances(items []models.BatchAttendanceItem) []models.BatchItemResult {
+	results := make([]models.BatchItemResult, 0, len(items))
+	sessions := make(map[uint]*models.AttendanceSession)
+	now := time.Now()
+
+	for i := range items {
+		item := &items[i]
+		result := models.BatchItemResult{ClientID: item.ClientID}
+
+		attendance, duplicate, err := s.syncKioskAttendance(item, sessions, now)
+		switch {
+		case err != nil:
+			result.Result = models.BatchResultRejected
+			result.Reason = err.Error()
+		case duplicate:
+			result.Result = models.BatchResultDuplicate
+			result.Attendance = attendance
+		default:
+			result.Result = models.BatchResultCreated
+			result.Attendance = attendance
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (s *CheckInService) syncKioskAttendance(item *models.BatchAttendanceItem, sessions map[uint]*models.AttendanceSession, now time.Time) (*models.Attendance, bool, error) {
+	if item.CheckedInAt.After(now.Add(maxKioskClockDrift)) {
+		return nil, false, ErrCheckinInFuture
+	}
+
+	session, cached := sessions[item.SessionID]
+	if !cached {
+		var err error
+		session, err = repository.GetAttendanceSessionWithEvent(item.SessionID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, false, ErrSessionNotFound
+			}
+			return nil, false, err
+		}
+		sessions[item.SessionID] = session
+	}
+	if err := EnsureEventAcceptsOfflineCheckins(session.Event); err != nil {
+		return nil, false, err
+	}
+
+	req := &models.CreateAttendanceRequest{
+		SessionID:       item.SessionID,
+		StudentCode:     item.StudentCode,
+		StudentName:     item.StudentName,
+		Email:           item.Email,
+		Phone:           item.Phone,
+		WorkUnit:        item.WorkUnit,
+		WorkUnitAddress: item.WorkUnitAddress,
+		Answers:         item.Answers,
+	}
+	clientID := item.ClientID
+	return s.recordCheckIn(session, req, item.CheckedInAt, &clientID)
+}
@@ -0,0 +1,36 @@
+package services
+
+import "strings"
+
+// NormalizeEmail lowercases and trims an email address for duplicate detection
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone reduces a phone number to its digits, rewriting the
+// Vietnamese country code (+84 / 0084) to the domestic 0 prefix.
+// Keep in sync with the SQL backfill in migrations.
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	for _, prefix := range []string{"+84", "0084"} {
+		if strings.HasPrefix(phone, prefix) {
+			phone = "0" + strings.TrimPrefix(phone, prefix)
+			break
+		}
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// optionalString returns nil for empty strings so they are stored as NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
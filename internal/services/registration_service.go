@@ -0,0 +1,222 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RegistrationService struct {
+	registrationRepo *repository.RegistrationRepository
+	eventRepo        *repository.EventRepository
+}
+
+func NewRegistrationService(registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository) *RegistrationService {
+	return &RegistrationService{
+		registrationRepo: registrationRepo,
+		eventRepo:        eventRepo,
+	}
+}
+
+// Register registers a person for an event, or for one of its sessions when
+// SessionID is set. Only published and ongoing events take registrations. The
+// registration is waitlisted if the capacity is reached.
+func (s *RegistrationService) Register(eventID uint, req *models.CreateRegistrationRequest) (*models.Registration, error) {
+	event, err := s.getEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := EnsureEventOpenForRegistration(event); err != nil {
+		return nil, err
+	}
+	capacity, err := s.capacityFor(event, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelToken, err := newCancelToken()
+	if err != nil {
+		return nil, err
+	}
+
+	registration := &models.Registration{
+		EventID:         eventID,
+		SessionID:       req.SessionID,
+		FullName:        strings.TrimSpace(req.FullName),
+		Email:           strings.TrimSpace(req.Email),
+		Phone:           optionalString(strings.TrimSpace(req.Phone)),
+		EmailNormalized: NormalizeEmail(req.Email),
+		PhoneNormalized: optionalString(NormalizePhone(req.Phone)),
+		CancelToken:     cancelToken,
+	}
+
+	if err := s.registrationRepo.Create(registration, capacity); err != nil {
+		if repository.IsDuplicateKeyError(err) {
+			return nil, ErrAlreadyRegistered
+		}
+		return nil, err
+	}
+	return registration, nil
+}
+
+// CancelRegistration cancels the registration holding the given cancel token
+// and returns the waitlisted registrations promoted into the freed place
+func (s *RegistrationService) CancelRegistration(cancelToken string) (*models.Registration, []models.Registration, error) {
+	registration, err := s.registrationRepo.GetByCancelToken(cancelToken)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrRegistrationNotFound
+		}
+		return nil, nil, err
+	}
+	return s.cancel(registration)
+}
+
+// CancelRegistrationByID cancels a registration on behalf of an organizer
+func (s *RegistrationService) CancelRegistrationByID(id uint) (*models.Registration, []models.Registration, error) {
+	registration, err := s.registrationRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrRegistrationNotFound
+		}
+		return nil, nil, err
+	}
+	return s.cancel(registration)
+}
+
+// GetRegistrations lists an event's registrations, optionally narrowed to one session and status
+func (s *RegistrationService) GetRegistrations(eventID uint, sessionID *uint, status string) ([]models.Registration, error) {
+	return s.registrationRepo.GetByEventID(eventID, sessionID, status)
+}
+
+// GetNoShows lists the registered people of an event (or one session) who
+// have not checked in
+func (s *RegistrationService) GetNoShows(eventID uint, sessionID *uint) ([]models.Registration, error) {
+	registrations, err := s.registrationRepo.GetByEventID(eventID, sessionID, models.RegistrationStatusRegistered)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := repository.GetAttendancesByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindNoShows(registrations, attendances), nil
+}
+
+// FindNoShows returns the registered registrations without a matching
+// check-in. A session registration needs a check-in for that session; an
+// event registration is satisfied by a check-in for any of its sessions.
+// Check-ins are matched by normalized email or phone.
+func FindNoShows(registrations []models.Registration, attendances []models.Attendance) []models.Registration {
+	type contact struct {
+		sessionID uint
+		value     string
+	}
+	bySession := make(map[contact]bool)
+	byEvent := make(map[string]bool)
+	for _, attendance := range attendances {
+		if attendance.CheckedInAt == nil || attendance.SessionID == nil {
+			continue
+		}
+		for _, value := range []*string{attendance.EmailNormalized, attendance.PhoneNormalized} {
+			if value == nil || *value == "" {
+				continue
+			}
+			bySession[contact{*attendance.SessionID, *value}] = true
+			byEvent[*value] = true
+		}
+	}
+
+	attended := func(registration models.Registration, value string) bool {
+		if registration.SessionID == nil {
+			return byEvent[value]
+		}
+		return bySession[contact{*registration.SessionID, value}]
+	}
+
+	noShows := []models.Registration{}
+	for _, registration := range registrations {
+		if registration.Status != models.RegistrationStatusRegistered {
+			continue
+		}
+		if attended(registration, registration.EmailNormalized) {
+			continue
+		}
+		if registration.PhoneNormalized != nil && attended(registration, *registration.PhoneNormalized) {
+			continue
+		}
+		noShows = append(noShows, registration)
+	}
+	return noShows
+}
+
+func (s *RegistrationService) cancel(registration *models.Registration) (*models.Registration, []models.Registration, error) {
+	if !registration.IsActive() {
+		return nil, nil, ErrRegistrationCancelled
+	}
+
+	event, err := s.getEvent(registration.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	capacity, err := s.capacityFor(event, registration.SessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	promoted, cancelled, err := s.registrationRepo.Cancel(registration, capacity, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !cancelled {
+		return nil, nil, ErrRegistrationCancelled
+	}
+	return registration, promoted, nil
+}
+
+func (s *RegistrationService) getEvent(eventID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+// capacityFor returns the capacity of the session (see SessionCapacity) when
+// sessionID is set and of the event otherwise; nil means unlimited
+func (s *RegistrationService) capacityFor(event *models.Event, sessionID *uint) (*int, error) {
+	if sessionID == nil {
+		return event.Capacity, nil
+	}
+
+	session, err := repository.GetAttendanceSessionByID(int(*sessionID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	if session.EventID == nil || *session.EventID != event.ID {
+		return nil, ErrSessionNotFound
+	}
+	return SessionCapacity(session), nil
+}
+
+func newCancelToken() (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(random), nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RoomService struct {
+	roomRepo *repository.RoomRepository
+}
+
+func NewRoomService(roomRepo *repository.RoomRepository) *RoomService {
+	return &RoomService{
+		roomRepo: roomRepo,
+	}
+}
+
+// GetRooms retrieves all rooms
+func (s *RoomService) GetRooms() ([]models.Room, error) {
+	return s.roomRepo.GetAll()
+}
+
+// GetRoomByID retrieves a room by ID
+func (s *RoomService) GetRoomByID(id uint) (*models.Room, error) {
+	room, err := s.roomRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, err
+	}
+	return room, nil
+}
+
+// CreateRoom creates a room with a name no other room uses
+func (s *RoomService) CreateRoom(req *models.RoomRequest) (*models.Room, error) {
+	room := &models.Room{}
+	ApplyRoomRequest(room, req)
+	if err := ValidateRoom(room); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameFree(room); err != nil {
+		return nil, err
+	}
+
+	if err := s.roomRepo.Create(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// UpdateRoom changes the fields set in req
+func (s *RoomService) UpdateRoom(id uint, req *models.RoomRequest) (*models.Room, error) {
+	room, err := s.GetRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ApplyRoomRequest(room, req)
+	if err := ValidateRoom(room); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameFree(room); err != nil {
+		return nil, err
+	}
+
+	if err := s.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// DeleteRoom deletes a room unless sessions are still booked in it. Past
+// sessions keep their room ID.
+func (s *RoomService) DeleteRoom(id uint) error {
+	if _, err := s.GetRoomByID(id); err != nil {
+		return err
+	}
+
+	upcoming, err := s.roomRepo.CountUpcomingSessions(id, time.Now())
+	if err != nil {
+		return err
+	}
+	if upcoming > 0 {
+		return fmt.Errorf("%w (%d)", ErrRoomInUse, upcoming)
+	}
+	return s.roomRepo.Delete(id)
+}
+
+// GetAvailableRooms returns the rooms holding at least minCapacity people that
+// have no session overlapping [from, to)
+func (s *RoomService) GetAvailableRooms(from, to time.Time, minCapacity int) ([]models.Room, error) {
+	rooms, err := s.roomRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := repository.GetAttendanceSessionsBetween(from.Add(-maxSessionLength), to)
+	if err != nil {
+		return nil, err
+	}
+	return FreeRooms(rooms, sessions, from, to, minCapacity), nil
+}
+
+func (s *RoomService) ensureNameFree(room *models.Room) error {
+	existing, err := s.roomRepo.GetByName(room.Name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != room.ID {
+		return ErrRoomNameTaken
+	}
+	return nil
+}
+
+// ApplyRoomRequest copies the fields set in req onto room
+func ApplyRoomRequest(room *models.Room, req *models.RoomRequest) {
+	if req.Name != nil {
+		room.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Building != nil {
+		room.Building = req.Building
+	}
+	if req.Capacity != nil {
+		room.Capacity = req.Capacity
+	}
+	if req.Latitude != nil {
+		room.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		room.Longitude = req.Longitude
+	}
+}
+
+// ValidateRoom checks that a room has a name, a positive capacity if any, and
+// either both coordinates or neither
+func ValidateRoom(room *models.Room) error {
+	switch {
+	case room.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidRoom)
+	case room.Capacity != nil && *room.Capacity < 1:
+		return fmt.Errorf("%w: capacity must be at least 1", ErrInvalidRoom)
+	case (room.Latitude == nil) != (room.Longitude == nil):
+		return fmt.Errorf("%w: give both latitude and longitude", ErrInvalidRoom)
+	case room.Latitude != nil && (*room.Latitude < -90 || *room.Latitude > 90):
+		return fmt.Errorf("%w: latitude must be between -90 and 90", ErrInvalidRoom)
+	case room.Longitude != nil && (*room.Longitude < -180 || *room.Longitude > 180):
+		return fmt.Errorf("%w: longitude must be between -180 and 180", ErrInvalidRoom)
+	}
+	return nil
+}
+
+// FreeRooms returns the rooms holding at least minCapacity people that no
+// session overlapping [from, to) is booked in. Rooms without a capacity are
+// treated as large enough.
+func FreeRooms(rooms []models.Room, sessions []models.AttendanceSession, from, to time.Time, minCapacity int) []models.Room {
+	busy := make(map[uint]bool)
+	for _, session := range sessions {
+		if session.RoomID == nil || session.SessionDate == nil {
+			continue
+		}
+		start, end := SessionTimes(session)
+		if start.Before(to) && from.Before(end) {
+			busy[*session.RoomID] = true
+		}
+	}
+
+	free := []models.Room{}
+	for _, room := range rooms {
+		if busy[room.ID] || (room.Capacity != nil && *room.Capacity < minCapacity) {
+			continue
+		}
+		free = append(free, room)
+	}
+	return free
+}
+
+// findRoom loads the room a session or series is booked in; a nil roomID
+// returns no room
+func findRoom(roomRepo *repository.RoomRepository, roomID *uint) (*models.Room, error) {
+	if roomID == nil {
+		return nil, nil
+	}
+	room, err := roomRepo.GetByID(*roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, err
+	}
+	return room, nil
+}
+
+// SessionCapacity returns the smaller of the session's own capacity and the
+// capacity of its room, when loaded; nil means unlimited
+func SessionCapacity(session *models.AttendanceSession) *int {
+	capacity := session.Capacity
+	if session.Room != nil && session.Room.Capacity != nil {
+		if capacity == nil || *session.Room.Capacity < *capacity {
+			capacity = session.Room.Capacity
+		}
+	}
+	return capacity
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"errors"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MatchStudent links a check-in to a student by student code, then email,
+// then phone. Students enrolled in the session's class on the session date
+// are tried first, then all students. A lookup that finds more than one student is treated as no match
+// so that it gets flagged for manual review.
+func MatchStudent(session *models.AttendanceSession, studentCode, emailNormalized, phoneNormalized string) (*models.Student, string, error) {
+	lookups := []struct {
+		matchedBy string
+		value     string
+		find      func(string, *uint, time.Time) ([]models.Student, error)
+	}{
+		{models.MatchedByStudentCode, studentCode, repository.FindStudentsByCode},
+		{models.MatchedByEmail, emailNormalized, repository.FindStudentsByEmail},
+		{models.MatchedByPhone, phoneNormalized, repository.FindStudentsByPhone},
+	}
+
+	at := RosterDate(session)
+	scopes := []*uint{nil}
+	if session.ClassID != nil {
+		scopes = []*uint{session.ClassID, nil}
+	}
+
+	for _, scope := range scopes {
+		for _, lookup := range lookups {
+			if lookup.value == "" {
+				continue
+			}
+			students, err := lookup.find(lookup.value, scope, at)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(students) == 1 {
+				return &students[0], lookup.matchedBy, nil
+			}
+		}
+	}
+
+	return nil, "", nil
+}
+
+// RosterDate is when class membership is looked up for a session: its date,
+// or now for sessions without one
+func RosterDate(session *models.AttendanceSession) time.Time {
+	if session.SessionDate != nil {
+		return *session.SessionDate
+	}
+	return time.Now()
+}
+
+type RosterService struct {
+	enrollmentRepo *repository.EnrollmentRepository
+	excuseRepo     *repository.ExcuseRepository
+}
+
+func NewRosterService(enrollmentRepo *repository.EnrollmentRepository, excuseRepo *repository.ExcuseRepository) *RosterService {
+	return &RosterService{
+		enrollmentRepo: enrollmentRepo,
+		excuseRepo:     excuseRepo,
+	}
+}
+
+// GetSessionRoster lists every student enrolled in the session's class on
+// the session date as present, late, absent or excused, together with
+// unmatched check-ins. Students with an approved excuse request are excused
+// unless they attended.
+func (s *RosterService) GetSessionRoster(sessionID uint) (*models.SessionRoster, error) {
+	session, err := repository.GetAttendanceSessionWithEvent(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	attendances, err := repository.GetAttendancesBySessionID(int(sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	var students []models.Student
+	roles := map[uint]string{}
+	if session.ClassID != nil {
+		enrollments, err := s.enrollmentRepo.GetClassEnrollmentsOn(*session.ClassID, RosterDate(session))
+		if err != nil {
+			return nil, err
+		}
+		for _, enrollment := range enrollments {
+			if _, seen := roles[enrollment.StudentID]; seen || enrollment.Student == nil {
+				continue
+			}
+			students = append(students, *enrollment.Student)
+			roles[enrollment.StudentID] = enrollment.Role
+		}
+	}
+
+	excusedStudentIDs, err := s.excuseRepo.GetApprovedStudentIDs(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	roster := BuildRoster(session, students, attendances, excusedStudentIDs)
+	for i := range roster.Entries {
+		roster.Entries[i].Role = roles[roster.Entries[i].Student.ID]
+	}
+	return roster, nil
+}
+
+// BuildRoster combines class students, session attendances and approved
+// excuses into a roster
+func BuildRoster(session *models.AttendanceSession, students []models.Student, attendances []models.Attendance, excusedStudentIDs []uint) *models.SessionRoster {
+	roster := &models.SessionRoster{
+		SessionID: session.ID,
+		ClassID:   session.ClassID,
+		Entries:   make([]models.RosterEntry, 0, len(students)),
+		Unmatched: []models.Attendance{},
+		Guests:    []models.Attendance{},
+		Summary: map[string]int{
+			models.AttendanceStatusPresent: 0,
+			models.AttendanceStatusLate:    0,
+			models.AttendanceStatusAbsent:  0,
+			models.AttendanceStatusExcused: 0,
+		},
+	}
+
+	byStudent := make(map[uint]*models.Attendance, len(attendances))
+	for i := range attendances {
+		attendance := &attendances[i]
+		if attendance.StudentID == nil {
+			roster.Unmatched = append(roster.Unmatched, *attendance)
+			continue
+		}
+		// Keep the earliest record if a student somehow has several
+		if _, exists := byStudent[*attendance.StudentID]; !exists {
+			byStudent[*attendance.StudentID] = attendance
+		}
+	}
+
+	excused := make(map[uint]bool, len(excusedStudentIDs))
+	for _, studentID := range excusedStudentIDs {
+		excused[studentID] = true
+	}
+
+	inClass := make(map[uint]bool, len(students))
+	for _, student := range students {
+		inClass[student.ID] = true
+
+		entry := models.RosterEntry{Student: student, Status: models.AttendanceStatusAbsent}
+		if attendance, ok := byStudent[student.ID]; ok {
+			entry.Attendance = attendance
+			entry.Status = attendanceStatus(attendance)
+		}
+		if excused[student.ID] && entry.Status != models.AttendanceStatusPresent && entry.Status != models.AttendanceStatusLate {
+			entry.Status = models.AttendanceStatusExcused
+		}
+		roster.Entries = append(roster.Entries, entry)
+		roster.Summary[entry.Status]++
+	}
+
+	for i := range attendances {
+		attendance := attendances[i]
+		if attendance.StudentID != nil && !inClass[*attendance.StudentID] {
+			roster.Guests = append(roster.Guests, attendance)
+		}
+	}
+
+	return roster
+}
+
+// ResolveAttendanceStudent manually links a check-in to a student
+func ResolveAttendanceStudent(attendance *models.Attendance, studentID uint) error {
+	student, err := repository.GetStudentByID(studentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrStudentNotFound
+		}
+		return err
+	}
+
+	if attendance.SessionID != nil {
+		existing, err := repository.GetAttendanceBySessionAndStudent(*attendance.SessionID, student.ID)
+		if err == nil && existing.ID != attendance.ID {
+			return ErrStudentAlreadyCheckedIn
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
+	matchedBy := models.MatchedByManual
+	needsReview := false
+	attendance.StudentID = &student.ID
+	attendance.MatchedBy = &matchedBy
+	attendance.NeedsReview = &needsReview
+	attendance.Student = student
+	return repository.UpdateAttendance(attendance)
+}
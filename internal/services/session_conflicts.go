@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Conflict reasons: what two overlapping sessions share
+const (
+	ConflictTeacher = "teacher"
+	ConflictClass   = "class"
+	ConflictRoom    = "room"
+)
+
+// maxSessionLength is the longest a session may last; it bounds how far back
+// overlapping sessions are looked for
+const maxSessionLength = 24 * time.Hour
+
+// defaultSessionLength is the length of sessions without a duration, closing
+// time or minimum duration
+const defaultSessionLength = time.Hour
+
+// SessionConflictError lists the existing sessions a session overlaps
+type SessionConflictError struct {
+	Conflicts []models.SessionConflict
+}
+
+func (e *SessionConflictError) Error() string {
+	sessions := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		sessions = append(sessions, fmt.Sprintf("session %d (%s)", conflict.SessionID, strings.Join(conflict.Reasons, ", ")))
+	}
+	return ErrSessionConflict.Error() + ": " + strings.Join(sessions, "; ")
+}
+
+// Unwrap lets errors.Is match ErrSessionConflict
+func (e *SessionConflictError) Unwrap() error {
+	return ErrSessionConflict
+}
+
+// SessionTimes returns when a session starts and ends: it lasts
+// DurationMinutes, else until check-in closes, else MinDurationMinutes, else an hour
+func SessionTimes(session models.AttendanceSession) (time.Time, time.Time) {
+	start := *session.SessionDate
+	if session.DurationMinutes != nil && *session.DurationMinutes > 0 {
+		return start, start.Add(time.Duration(*session.DurationMinutes) * time.Minute)
+	}
+	if session.ClosesAt != nil && session.ClosesAt.After(start) {
+		return start, *session.ClosesAt
+	}
+	if session.MinDurationMinutes != nil && *session.MinDurationMinutes > 0 {
+		return start, start.Add(time.Duration(*session.MinDurationMinutes) * time.Minute)
+	}
+	return start, start.Add(defaultSessionLength)
+}
+
+// ValidateSessionDuration checks that a set duration lasts between a minute and a day
+func ValidateSessionDuration(session *models.AttendanceSession) error {
+	if session.DurationMinutes == nil {
+		return nil
+	}
+	if *session.DurationMinutes < 1 || time.Duration(*session.DurationMinutes)*time.Minute > maxSessionLength {
+		return ErrInvalidDuration
+	}
+	return nil
+}
+
+// CheckSessionConflicts returns the existing sessions that overlap session and
+// share its teacher, class or room
+func CheckSessionConflicts(session *models.AttendanceSession) ([]models.SessionConflict, error) {
+	if session.SessionDate == nil {
+		return nil, nil
+	}
+	start, end := SessionTimes(*session)
+	nearby, err := repository.GetAttendanceSessionsBetween(start.Add(-maxSessionLength), end)
+	if err != nil {
+		return nil, err
+	}
+	return FindSessionConflicts(session, nearby), nil
+}
+
+// CheckPlannedSessionConflicts returns the conflicts of sessions planned
+// together, such as the occurrences of a series: with existing sessions other
+// than the planned ones and those in replaced, and with each other
+func CheckPlannedSessionConflicts(planned, replaced []models.AttendanceSession) ([]models.SessionConflict, error) {
+	var from, to time.Time
+	for _, session := range planned {
+		if session.SessionDate == nil {
+			continue
+		}
+		start, end := SessionTimes(session)
+		if from.IsZero() || start.Before(from) {
+			from = start
+		}
+		if end.After(to) {
+			to = end
+		}
+	}
+	if from.IsZero() {
+		return nil, nil
+	}
+
+	existing, err := repository.GetAttendanceSessionsBetween(from.Add(-maxSessionLength), to)
+	if err != nil {
+		return nil, err
+	}
+	return FindPlannedSessionConflicts(planned, existing, replaced), nil
+}
+
+// FindPlannedSessionConflicts returns the sessions among existing, other than
+// the planned ones and those in replaced, that overlap a planned session and
+// share its teacher, class or room, followed by the planned sessions that
+// overlap each other. Each conflict names the planned session it overlaps as
+// its occurrence.
+func FindPlannedSessionConflicts(planned, existing, replaced []models.AttendanceSession) []models.SessionConflict {
+	skipped := make(map[uint]bool, len(planned)+len(replaced))
+	for _, sessions := range [][]models.AttendanceSession{planned, replaced} {
+		for _, session := range sessions {
+			if session.ID != 0 {
+				skipped[session.ID] = true
+			}
+		}
+	}
+	others := make([]models.AttendanceSession, 0, len(existing))
+	for _, session := range existing {
+		if !skipped[session.ID] {
+			others = append(others, session)
+		}
+	}
+
+	conflicts := []models.SessionConflict{}
+	for i := range planned {
+		if planned[i].SessionDate == nil {
+			continue
+		}
+		occurrence := *planned[i].SessionDate
+		found := FindSessionConflicts(&planned[i], others)
+		found = append(found, FindSessionConflicts(&planned[i], planned[i+1:])...)
+		for _, conflict := range found {
+			conflict.Occurrence = &occurrence
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+// GetSessionConflictsReport lists the pairs of sessions that overlap between
+// from and to and share a teacher, class or room (see BuildSessionConflictsReport)
+func GetSessionConflictsReport(from, to time.Time, teacherID *uint) ([]models.SessionConflictPair, error) {
+	sessions, err := repository.GetAttendanceSessionsBetween(from.Add(-maxSessionLength), to)
+	if err != nil {
+		return nil, err
+	}
+	return BuildSessionConflictsReport(sessions, from, to, teacherID), nil
+}
+
+// BuildSessionConflictsReport lists the pairs of sessions that overlap
+// between from and to. When teacherID is set only pairs involving that
+// teacher's sessions are listed, with their own session first; the other
+// session's ID is left out when it belongs to another teacher, so the report
+// tells them what clashes without exposing other teachers' sessions.
+func BuildSessionConflictsReport(sessions []models.AttendanceSession, from, to time.Time, teacherID *uint) []models.SessionConflictPair {
+	pairs := FindOverlappingSessions(sessions)
+	teachers := make(map[uint]*uint, len(sessions))
+	for _, session := range sessions {
+		teachers[session.ID] = session.TeacherID
+	}
+
+	report := make([]models.SessionConflictPair, 0, len(pairs))
+	for _, pair := range pairs {
+		if !pair.OverlapEnd.After(from) || !pair.OverlapStart.Before(to) {
+			continue
+		}
+		if teacherID != nil {
+			if !sameUint(teachers[pair.SessionID], teacherID) {
+				if !sameUint(teachers[pair.OtherSessionID], teacherID) {
+					continue
+				}
+				pair.SessionID, pair.OtherSessionID = pair.OtherSessionID, pair.SessionID
+			}
+			if !sameUint(teachers[pair.OtherSessionID], teacherID) {
+				pair.OtherSessionID = 0
+			}
+		}
+		report = append(report, pair)
+	}
+	return report
+}
+
+// FindSessionConflicts returns the sessions among others that overlap session
+// and share its teacher, class or room
+func FindSessionConflicts(session *models.AttendanceSession, others []models.AttendanceSession) []models.SessionConflict {
+	if session.SessionDate == nil {
+		return nil
+	}
+	start, end := SessionTimes(*session)
+
+	conflicts := []models.SessionConflict{}
+	for i := range others {
+		other := &others[i]
+		if other.SessionDate == nil || (session.ID != 0 && other.ID == session.ID) {
+			continue
+		}
+		otherStart, otherEnd := SessionTimes(*other)
+		if !otherStart.Before(end) || !start.Before(otherEnd) {
+			continue
+		}
+		if reasons := SessionConflictReasons(session, other); len(reasons) > 0 {
+			conflicts = append(conflicts, models.SessionConflict{
+				SessionID:   other.ID,
+				SessionDate: otherStart,
+				EndsAt:      otherEnd,
+				Reasons:     reasons,
+			})
+		}
+	}
+	return conflicts
+}
+
+// FindOverlappingSessions returns every pair of sessions that overlap in time
+// and share a teacher, class or room, ordered by when the overlap starts
+func FindOverlappingSessions(sessions []models.AttendanceSession) []models.SessionConflictPair {
+	dated := make([]models.AttendanceSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session.SessionDate != nil {
+			dated = append(dated, session)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool { return dated[i].SessionDate.Before(*dated[j].SessionDate) })
+
+	pairs := []models.SessionConflictPair{}
+	for i := range dated {
+		start, end := SessionTimes(dated[i])
+		for j := i + 1; j < len(dated); j++ {
+			otherStart, otherEnd := SessionTimes(dated[j])
+			if !otherStart.Before(end) {
+				break
+			}
+			reasons := SessionConflictReasons(&dated[i], &dated[j])
+			if len(reasons) == 0 {
+				continue
+			}
+
+			overlapEnd := end
+			if otherEnd.Before(end) {
+				overlapEnd = otherEnd
+			}
+			pairs = append(pairs, models.SessionConflictPair{
+				SessionID:      dated[i].ID,
+				OtherSessionID: dated[j].ID,
+				OverlapStart:   laterTime(start, otherStart),
+				OverlapEnd:     overlapEnd,
+				Reasons:        reasons,
+			})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].OverlapStart.Before(pairs[j].OverlapStart) })
+	return pairs
+}
+
+// SessionConflictReasons returns what two sessions share that keeps them from
+// taking place at the same time. Sessions booked in a room are compared by
+// room; others by location, ignoring case and extra spaces.
+func SessionConflictReasons(a, b *models.AttendanceSession) []string {
+	var reasons []string
+	if a.TeacherID != nil && sameUint(a.TeacherID, b.TeacherID) {
+		reasons = append(reasons, ConflictTeacher)
+	}
+	if a.ClassID != nil && sameUint(a.ClassID, b.ClassID) {
+		reasons = append(reasons, ConflictClass)
+	}
+	if room := sessionRoomKey(a); room != "" && room == sessionRoomKey(b) {
+		reasons = append(reasons, ConflictRoom)
+	}
+	return reasons
+}
+
+func sessionRoomKey(session *models.AttendanceSession) string {
+	if session.RoomID != nil {
+		return fmt.Sprintf("#%d", *session.RoomID)
+	}
+	if session.Location == nil {
+		return ""
+	}
+	return strings.ToLower(strings.Join(strings.Fields(*session.Location), " "))
+}
+
+func sameUint(a, b *uint) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
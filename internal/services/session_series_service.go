@@ -0,0 +1,407 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+	"gorm.io/gorm"
+)
+
+// MaxSeriesOccurrences caps the number of sessions a single series may generate
+const MaxSeriesOccurrences = 500
+
+const seriesDayLayout = "2006-01-02"
+
+type SessionSeriesService struct {
+	roomRepo *repository.RoomRepository
+}
+
+func NewSessionSeriesService(roomRepo *repository.RoomRepository) *SessionSeriesService {
+	return &SessionSeriesService{
+		roomRepo: roomRepo,
+	}
+}
+
+func GetSessionSeries(eventID *uint, teacherID *uint) ([]models.SessionSeries, error) {
+	return repository.GetSessionSeries(eventID, teacherID)
+}
+
+func GetSessionSeriesByID(id uint) (*models.SessionSeries, error) {
+	series, err := repository.GetSessionSeriesByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	return series, nil
+}
+
+// CreateSessionSeries stores a series together with one session per
+// occurrence, unless occurrences overlap other sessions of the same teacher,
+// class or room, or each other. With force it is stored anyway and the
+// overlapping sessions are returned as a warning.
+func (s *SessionSeriesService) CreateSessionSeries(series *models.SessionSeries, force bool) ([]models.SessionConflict, error) {
+	if series.Timezone == "" {
+		series.Timezone = config.DefaultTimezone()
+	}
+	if err := ValidateSessionSeries(series); err != nil {
+		return nil, err
+	}
+	if _, err := findRoom(s.roomRepo, series.RoomID); err != nil {
+		return nil, err
+	}
+
+	starts, err := ExpandSessionSeries(series, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]models.AttendanceSession, 0, len(starts))
+	for _, start := range starts {
+		sessions = append(sessions, BuildSeriesSession(series, start))
+	}
+
+	conflicts, err := CheckPlannedSessionConflicts(sessions, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 && !force {
+		return nil, &SessionConflictError{Conflicts: conflicts}
+	}
+
+	if err := repository.CreateSessionSeries(series, sessions); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// UpdateSessionSeries applies req to a series and regenerates its upcoming
+// sessions. Sessions that already started are left as they are, and upcoming
+// sessions with attendances are kept even when they no longer occur. Like
+// CreateSessionSeries it refuses occurrences that overlap other sessions
+// unless req.Force is set.
+func (s *SessionSeriesService) UpdateSessionSeries(id uint, req models.UpdateSessionSeriesRequest, now time.Time) (*models.SessionSeries, *models.SessionSeriesChanges, error) {
+	series, err := GetSessionSeriesByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if series.CancelledAt != nil {
+		return nil, nil, ErrSeriesCancelled
+	}
+
+	ApplySessionSeriesUpdate(series, req)
+	if err := ValidateSessionSeries(series); err != nil {
+		return nil, nil, err
+	}
+	if _, err := findRoom(s.roomRepo, series.RoomID); err != nil {
+		return nil, nil, err
+	}
+
+	starts, err := ExpandSessionSeries(series, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	upcoming, err := repository.GetSeriesSessionsAfter(series.ID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan := PlanSeriesSessions(series, upcoming, starts)
+	planned := append(append([]models.AttendanceSession{}, plan.Update...), plan.Create...)
+	conflicts, err := CheckPlannedSessionConflicts(planned, plan.Remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(conflicts) > 0 && !req.Force {
+		return nil, nil, &SessionConflictError{Conflicts: conflicts}
+	}
+
+	kept, err := repository.ApplySeriesPlan(series, plan.Update, plan.Create, plan.Remove)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, &models.SessionSeriesChanges{
+		Created:   len(plan.Create),
+		Updated:   len(plan.Update),
+		Removed:   len(plan.Remove) - len(kept),
+		Kept:      kept,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// CancelSessionSeries cancels a series and removes its upcoming sessions that
+// have no attendances yet. Past sessions are left as they are.
+func CancelSessionSeries(id uint, now time.Time) (*models.SessionSeries, *models.SessionSeriesChanges, error) {
+	series, err := GetSessionSeriesByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if series.CancelledAt != nil {
+		return nil, nil, ErrSeriesCancelled
+	}
+
+	upcoming, err := repository.GetSeriesSessionsAfter(series.ID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series.CancelledAt = &now
+	kept, err := repository.ApplySeriesPlan(series, nil, nil, upcoming)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, &models.SessionSeriesChanges{
+		Removed: len(upcoming) - len(kept),
+		Kept:    kept,
+	}, nil
+}
+
+// ApplySessionSeriesUpdate copies the fields set in req onto series
+func ApplySessionSeriesUpdate(series *models.SessionSeries, req models.UpdateSessionSeriesRequest) {
+	if req.ClassID != nil {
+		series.ClassID = req.ClassID
+	}
+	if req.TeacherID != nil {
+		series.TeacherID = req.TeacherID
+	}
+	if req.StartsAt != nil {
+		series.StartsAt = *req.StartsAt
+	}
+	if req.Timezone != nil {
+		series.Timezone = *req.Timezone
+	}
+	if req.RRule != nil {
+		series.RRule = *req.RRule
+	}
+	if req.ExDates != nil {
+		series.ExDates = models.StringList(*req.ExDates)
+	}
+	if req.OpensBeforeMinutes != nil {
+		series.OpensBeforeMinutes = req.OpensBeforeMinutes
+	}
+	if req.LateAfterMinutes != nil {
+		series.LateAfterMinutes = req.LateAfterMinutes
+	}
+	if req.ClosesAfterMinutes != nil {
+		series.ClosesAfterMinutes = req.ClosesAfterMinutes
+	}
+	if req.DurationMinutes != nil {
+		series.DurationMinutes = req.DurationMinutes
+	}
+	if req.MinDurationMinutes != nil {
+		series.MinDurationMinutes = req.MinDurationMinutes
+	}
+	if req.Capacity != nil {
+		series.Capacity = req.Capacity
+	}
+	if req.RoomID != nil {
+		series.RoomID = req.RoomID
+	}
+	if req.Location != nil {
+		series.Location = req.Location
+	}
+}
+
+// ValidateSessionSeries checks the time zone, recurrence rule, skipped days and
+// check-in window of a series
+func ValidateSessionSeries(series *models.SessionSeries) error {
+	if _, err := parseSeriesRule(series); err != nil {
+		return err
+	}
+	for _, day := range series.ExDates {
+		if _, err := time.Parse(seriesDayLayout, day); err != nil {
+			return fmt.Errorf("%w: exdate %q must be formatted as YYYY-MM-DD", ErrInvalidRecurrence, day)
+		}
+	}
+
+	// Check the window on a sample occurrence; offsets are the same for every one
+	sample := BuildSeriesSession(series, series.StartsAt)
+	if err := ValidateSessionDuration(&sample); err != nil {
+		return err
+	}
+	return ValidateCheckinWindow(&sample)
+}
+
+// ExpandSessionSeries returns the start of every occurrence of a series after
+// the given time, skipping its exdates. Occurrences keep their wall-clock time
+// in the series time zone across daylight saving changes.
+func ExpandSessionSeries(series *models.SessionSeries, after time.Time) ([]time.Time, error) {
+	rule, err := parseSeriesRule(series)
+	if err != nil {
+		return nil, err
+	}
+	location, _ := time.LoadLocation(series.Timezone)
+
+	skipped := make(map[string]bool, len(series.ExDates))
+	for _, day := range series.ExDates {
+		skipped[day] = true
+	}
+
+	var starts []time.Time
+	next := rule.Iterator()
+	for count := 0; ; count++ {
+		start, ok := next()
+		if !ok {
+			break
+		}
+		if count == MaxSeriesOccurrences {
+			return nil, fmt.Errorf("%w: more than %d occurrences", ErrInvalidRecurrence, MaxSeriesOccurrences)
+		}
+		if !start.After(after) || skipped[start.In(location).Format(seriesDayLayout)] {
+			continue
+		}
+		starts = append(starts, start)
+	}
+	return starts, nil
+}
+
+// BuildSeriesSession creates the session of a series occurrence starting at start
+func BuildSeriesSession(series *models.SessionSeries, start time.Time) models.AttendanceSession {
+	session := models.AttendanceSession{
+		EventID:   series.EventID,
+		ClassID:   series.ClassID,
+		TeacherID: series.TeacherID,
+		SeriesID:  seriesIDPtr(series),
+
+		DurationMinutes:    series.DurationMinutes,
+		MinDurationMinutes: series.MinDurationMinutes,
+		Capacity:           series.Capacity,
+		RoomID:             series.RoomID,
+		Location:           series.Location,
+	}
+	applySeriesSchedule(&session, series, start)
+	return session
+}
+
+// SeriesPlan lists how the upcoming sessions of a series change after an edit
+type SeriesPlan struct {
+	Update []models.AttendanceSession
+	Create []models.AttendanceSession
+	Remove []models.AttendanceSession
+}
+
+// PlanSeriesSessions matches the upcoming sessions of a series to its new
+// occurrences by day in the series time zone. Matched sessions are updated in
+// place so their attendances stay attached; unmatched occurrences get new
+// sessions and unmatched sessions are removed.
+func PlanSeriesSessions(series *models.SessionSeries, upcoming []models.AttendanceSession, starts []time.Time) SeriesPlan {
+	location, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		location = config.DefaultLocation()
+	}
+	dayOf := func(t time.Time) string { return t.In(location).Format(seriesDayLayout) }
+
+	byDay := make(map[string][]models.AttendanceSession)
+	for _, session := range upcoming {
+		if session.SessionDate != nil {
+			day := dayOf(*session.SessionDate)
+			byDay[day] = append(byDay[day], session)
+		}
+	}
+
+	var plan SeriesPlan
+	matched := make(map[uint]bool)
+	for _, start := range starts {
+		day := dayOf(start)
+		if len(byDay[day]) == 0 {
+			plan.Create = append(plan.Create, BuildSeriesSession(series, start))
+			continue
+		}
+
+		session := byDay[day][0]
+		byDay[day] = byDay[day][1:]
+		matched[session.ID] = true
+
+		session.EventID = series.EventID
+		session.ClassID = series.ClassID
+		session.TeacherID = series.TeacherID
+		session.DurationMinutes = series.DurationMinutes
+		session.MinDurationMinutes = series.MinDurationMinutes
+		session.Capacity = series.Capacity
+		session.RoomID = series.RoomID
+		session.Location = series.Location
+		applySeriesSchedule(&session, series, start)
+		plan.Update = append(plan.Update, session)
+	}
+
+	for _, session := range upcoming {
+		if !matched[session.ID] {
+			plan.Remove = append(plan.Remove, session)
+		}
+	}
+	return plan
+}
+
+// parseSeriesRule builds the recurrence rule of a series. Rules must be
+// bounded by COUNT or UNTIL, repeat daily or less often, and may not set the
+// time of day, which comes from StartsAt.
+func parseSeriesRule(series *models.SessionSeries) (*rrule.RRule, error) {
+	location, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidRecurrence, series.Timezone)
+	}
+
+	text := strings.TrimPrefix(strings.TrimSpace(series.RRule), "RRULE:")
+	if text == "" || strings.ContainsAny(text, "\r\n") {
+		return nil, fmt.Errorf("%w: rrule must be a single RRULE value", ErrInvalidRecurrence)
+	}
+	option, err := rrule.StrToROptionInLocation(text, location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+
+	switch option.Freq {
+	case rrule.DAILY, rrule.WEEKLY, rrule.MONTHLY, rrule.YEARLY:
+	default:
+		return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY", ErrInvalidRecurrence)
+	}
+	if option.Count == 0 && option.Until.IsZero() {
+		return nil, fmt.Errorf("%w: rrule must end with COUNT or UNTIL", ErrInvalidRecurrence)
+	}
+	if len(option.Byhour) > 0 || len(option.Byminute) > 0 || len(option.Bysecond) > 0 {
+		return nil, fmt.Errorf("%w: BYHOUR, BYMINUTE and BYSECOND are not supported, set the time with starts_at", ErrInvalidRecurrence)
+	}
+	if series.StartsAt.IsZero() {
+		return nil, fmt.Errorf("%w: starts_at is required", ErrInvalidRecurrence)
+	}
+
+	option.Dtstart = series.StartsAt.In(location)
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	return rule, nil
+}
+
+// applySeriesSchedule sets the date and check-in window of a series session
+func applySeriesSchedule(session *models.AttendanceSession, series *models.SessionSeries, start time.Time) {
+	offset := func(minutes *int, sign int) *time.Time {
+		if minutes == nil {
+			return nil
+		}
+		t := start.Add(time.Duration(sign**minutes) * time.Minute)
+		return &t
+	}
+
+	sessionDate := start
+	session.SessionDate = &sessionDate
+	session.OpensAt = offset(series.OpensBeforeMinutes, -1)
+	session.LateAfter = offset(series.LateAfterMinutes, 1)
+	session.ClosesAt = offset(series.ClosesAfterMinutes, 1)
+}
+
+func seriesIDPtr(series *models.SessionSeries) *uint {
+	if series.ID == 0 {
+		return nil
+	}
+	id := series.ID
+	return &id
+}
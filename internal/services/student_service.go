@@ -1,14 +1,389 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"hello-gin/config"
 	"hello-gin/internal/models"
 	"hello-gin/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
-func GetStudents() ([]models.Student, error) {
-	return repository.GetAllStudents()
+type StudentService struct {
+	studentRepo    *repository.StudentRepository
+	classRepo      *repository.ClassRepository
+	enrollmentRepo *repository.EnrollmentRepository
+}
+
+func NewStudentService(studentRepo *repository.StudentRepository, classRepo *repository.ClassRepository, enrollmentRepo *repository.EnrollmentRepository) *StudentService {
+	return &StudentService{
+		studentRepo:    studentRepo,
+		classRepo:      classRepo,
+		enrollmentRepo: enrollmentRepo,
+	}
+}
+
+// GetStudents retrieves all students
+func (s *StudentService) GetStudents() ([]models.Student, error) {
+	return s.studentRepo.GetAll()
+}
+
+// GetStudentByID retrieves a student with their class
+func (s *StudentService) GetStudentByID(id uint) (*models.Student, error) {
+	student, err := s.studentRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, err
+	}
+	return student, nil
+}
+
+// CreateStudent creates a student in an existing class with a code no other
+// student uses. The student is enrolled in the class from today.
+func (s *StudentService) CreateStudent(req *models.CreateStudentRequest) (*models.Student, error) {
+	student := &models.Student{}
+	ApplyStudentRequest(student, req)
+	if err := s.validate(student); err != nil {
+		return nil, err
+	}
+	if student.ClassID != nil {
+		today, _ := ParseEnrollmentDate(nil, time.Now())
+		student.Enrollments = []models.Enrollment{{
+			ClassID:      *student.ClassID,
+			Role:         models.EnrollmentRoleStudent,
+			EnrolledFrom: today,
+		}}
+	}
+
+	if err := s.studentRepo.Create(student); err != nil {
+		return nil, err
+	}
+	return student, nil
+}
+
+// UpdateStudent changes the fields set in req. A changed class_id transfers
+// the student to that class from today.
+func (s *StudentService) UpdateStudent(id uint, req *models.CreateStudentRequest) (*models.Student, error) {
+	student, err := s.GetStudentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	previousClassID := student.ClassID
+	ApplyStudentRequest(student, req)
+	if err := s.validate(student); err != nil {
+		return nil, err
+	}
+	newClassID := student.ClassID
+	student.ClassID = previousClassID
+
+	if newClassID == nil || sameUint(previousClassID, newClassID) {
+		if err := s.studentRepo.Update(student); err != nil {
+			return nil, err
+		}
+		return s.GetStudentByID(id)
+	}
+
+	var ended *models.Enrollment
+	if previousClassID != nil {
+		ended, err = s.enrollmentRepo.GetOpen(id, *previousClassID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	today, _ := ParseEnrollmentDate(nil, time.Now())
+	started, mainClassID, err := s.planMove(student, ended, *newClassID, today)
+	if err != nil {
+		return nil, err
+	}
+	// The other fields and the transfer are saved together
+	student.ClassID = mainClassID
+	if err := s.enrollmentRepo.UpdateStudentAndChange(student, ended, started); err != nil {
+		return nil, err
+	}
+	// Reload so a changed class is returned with the student
+	return s.GetStudentByID(id)
+}
+
+// DeleteStudent deletes a student; their attendances and excuses are kept
+func (s *StudentService) DeleteStudent(id uint) error {
+	if _, err := s.GetStudentByID(id); err != nil {
+		return err
+	}
+	return s.studentRepo.Delete(id)
+}
+
+// GetStudentEnrollments retrieves the enrollment history of a student, oldest first
+func (s *StudentService) GetStudentEnrollments(id uint) ([]models.Enrollment, error) {
+	if _, err := s.GetStudentByID(id); err != nil {
+		return nil, err
+	}
+	return s.enrollmentRepo.GetByStudentID(id)
+}
+
+// EnrollStudent enrolls a student in another class. It becomes the student's
+// main class when they have none.
+func (s *StudentService) EnrollStudent(id uint, req *models.EnrollStudentRequest) (*models.Enrollment, error) {
+	student, err := s.GetStudentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	role := strings.TrimSpace(req.Role)
+	if role == "" {
+		role = models.EnrollmentRoleStudent
+	}
+	if !models.IsEnrollmentRole(role) {
+		return nil, ErrInvalidEnrollmentRole
+	}
+	from, err := ParseEnrollmentDate(req.EnrolledFrom, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureCanEnroll(id, req.ClassID); err != nil {
+		return nil, err
+	}
+
+	started := &models.Enrollment{StudentID: id, ClassID: req.ClassID, Role: role, EnrolledFrom: from}
+	mainClassID := student.ClassID
+	if mainClassID == nil && role == models.EnrollmentRoleStudent {
+		mainClassID = &req.ClassID
+	}
+	if err := s.enrollmentRepo.Change(id, nil, started, mainClassID); err != nil {
+		return nil, err
+	}
+	return started, nil
+}
+
+// TransferStudent ends a student's enrollment in one class and enrolls them
+// in another from the effective date, keeping their role. Rosters of sessions
+// before that date still list them in the old class.
+func (s *StudentService) TransferStudent(id uint, req *models.TransferStudentRequest) (*models.Enrollment, error) {
+	student, err := s.GetStudentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ended, err := s.openEnrollment(student, req.FromClassID)
+	if err != nil {
+		return nil, err
+	}
+	on, err := ParseEnrollmentDate(req.EffectiveDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return s.moveToClass(student, ended, req.ToClassID, on)
+}
+
+// WithdrawStudent ends a student's enrollment in a class from the effective
+// date. When it was their main class, another class they are still enrolled
+// in as a student becomes the main class.
+func (s *StudentService) WithdrawStudent(id uint, req *models.WithdrawStudentRequest) (*models.Enrollment, error) {
+	student, err := s.GetStudentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ended, err := s.openEnrollment(student, req.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	on, err := ParseEnrollmentDate(req.EffectiveDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := endEnrollment(ended, on, models.EnrollmentEndWithdrawn); err != nil {
+		return nil, err
+	}
+
+	mainClassID := student.ClassID
+	if sameUint(mainClassID, &ended.ClassID) {
+		enrollments, err := s.enrollmentRepo.GetByStudentID(id)
+		if err != nil {
+			return nil, err
+		}
+		mainClassID = nil
+		for _, enrollment := range enrollments {
+			if enrollment.ID != ended.ID && enrollment.EnrolledTo == nil && enrollment.Role == models.EnrollmentRoleStudent {
+				classID := enrollment.ClassID
+				mainClassID = &classID
+			}
+		}
+	}
+
+	if err := s.enrollmentRepo.Change(id, ended, nil, mainClassID); err != nil {
+		return nil, err
+	}
+	return ended, nil
+}
+
+// moveToClass ends an enrollment, if any, and enrolls the student in classID
+// from the same day with the same role
+func (s *StudentService) moveToClass(student *models.Student, ended *models.Enrollment, classID uint, on time.Time) (*models.Enrollment, error) {
+	started, mainClassID, err := s.planMove(student, ended, classID, on)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enrollmentRepo.Change(student.ID, ended, started, mainClassID); err != nil {
+		return nil, err
+	}
+	return started, nil
+}
+
+// planMove ends an enrollment, if any, and returns the enrollment in classID
+// that replaces it together with the student's main class afterwards. The new
+// class becomes the main class when the old one was, or when the student had
+// none and enrolls as a student.
+func (s *StudentService) planMove(student *models.Student, ended *models.Enrollment, classID uint, on time.Time) (*models.Enrollment, *uint, error) {
+	if err := s.ensureCanEnroll(student.ID, classID); err != nil {
+		return nil, nil, err
+	}
+
+	role := models.EnrollmentRoleStudent
+	mainClassID := student.ClassID
+	if ended != nil {
+		if err := endEnrollment(ended, on, models.EnrollmentEndTransferred); err != nil {
+			return nil, nil, err
+		}
+		role = ended.Role
+		if sameUint(mainClassID, &ended.ClassID) {
+			mainClassID = &classID
+		}
+	}
+	if mainClassID == nil && role == models.EnrollmentRoleStudent {
+		mainClassID = &classID
+	}
+
+	started := &models.Enrollment{StudentID: student.ID, ClassID: classID, Role: role, EnrolledFrom: on}
+	return started, mainClassID, nil
+}
+
+// openEnrollment retrieves the current enrollment of a student in a class,
+// their main class when classID is nil
+func (s *StudentService) openEnrollment(student *models.Student, classID *uint) (*models.Enrollment, error) {
+	if classID == nil {
+		classID = student.ClassID
+	}
+	if classID == nil {
+		return nil, ErrNotEnrolled
+	}
+	enrollment, err := s.enrollmentRepo.GetOpen(student.ID, *classID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotEnrolled
+		}
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+// ensureCanEnroll checks that the class exists and the student is not
+// enrolled in it already
+func (s *StudentService) ensureCanEnroll(studentID, classID uint) error {
+	if _, err := s.classRepo.GetByID(classID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrClassNotFound
+		}
+		return err
+	}
+	_, err := s.enrollmentRepo.GetOpen(studentID, classID)
+	if err == nil {
+		return ErrAlreadyEnrolled
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// endEnrollment closes an enrollment on a date that is not before it started
+func endEnrollment(enrollment *models.Enrollment, on time.Time, reason string) error {
+	if on.Before(enrollment.EnrolledFrom) {
+		return fmt.Errorf("%w: the enrollment started on %s", ErrInvalidEnrollmentDate,
+			enrollment.EnrolledFrom.In(config.DefaultLocation()).Format("2006-01-02"))
+	}
+	enrollment.EnrolledTo = &on
+	enrollment.EndReason = &reason
+	return nil
+}
+
+// ParseEnrollmentDate reads an enrollment date given as YYYY-MM-DD (midnight
+// in the default time zone) or RFC3339. It defaults to the start of today and
+// must not be in the future.
+func ParseEnrollmentDate(value *string, now time.Time) (time.Time, error) {
+	location := config.DefaultLocation()
+	if value == nil || strings.TrimSpace(*value) == "" {
+		year, month, day := now.In(location).Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, location), nil
+	}
+
+	text := strings.TrimSpace(*value)
+	date, err := time.ParseInLocation("2006-01-02", text, location)
+	if err != nil {
+		date, err = time.Parse(time.RFC3339, text)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: use YYYY-MM-DD or RFC3339", ErrInvalidEnrollmentDate)
+		}
+	}
+	if date.After(now) {
+		return time.Time{}, fmt.Errorf("%w: it must not be in the future", ErrInvalidEnrollmentDate)
+	}
+	return date, nil
+}
+
+func (s *StudentService) validate(student *models.Student) error {
+	if student.ClassID != nil {
+		if _, err := s.classRepo.GetByID(*student.ClassID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrClassNotFound
+			}
+			return err
+		}
+	}
+
+	if student.StudentCode == nil || strings.TrimSpace(*student.StudentCode) == "" {
+		return nil
+	}
+	existing, err := s.studentRepo.GetByCode(*student.StudentCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != student.ID {
+		return ErrStudentCodeTaken
+	}
+	return nil
 }
 
-func CreateStudent(student *models.Student) error {
-	return repository.CreateStudent(student)
+// ApplyStudentRequest copies the fields set in req onto student
+func ApplyStudentRequest(student *models.Student, req *models.CreateStudentRequest) {
+	if req.StudentCode != nil {
+		student.StudentCode = req.StudentCode
+	}
+	if req.StudentName != nil {
+		student.StudentName = req.StudentName
+	}
+	if req.ClassID != nil {
+		student.ClassID = req.ClassID
+		student.Class = nil
+	}
+	if req.Phone != nil {
+		student.Phone = req.Phone
+	}
+	if req.Email != nil {
+		student.Email = req.Email
+	}
+	if req.WorkUnit != nil {
+		student.WorkUnit = req.WorkUnit
+	}
+	if req.DateOfBirth != nil {
+		student.DateOfBirth = req.DateOfBirth
+	}
 }
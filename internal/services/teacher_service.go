@@ -1,18 +1,127 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"hello-gin/internal/models"
 	"hello-gin/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
-func GetTeachers() ([]models.Teacher, error) {
-	return repository.GetAllTeachers()
+type TeacherService struct {
+	teacherRepo *repository.TeacherRepository
+}
+
+func NewTeacherService(teacherRepo *repository.TeacherRepository) *TeacherService {
+	return &TeacherService{
+		teacherRepo: teacherRepo,
+	}
+}
+
+// GetTeachers retrieves all teachers
+func (s *TeacherService) GetTeachers() ([]models.Teacher, error) {
+	return s.teacherRepo.GetAll()
+}
+
+// GetTeacherByID retrieves a teacher by ID
+func (s *TeacherService) GetTeacherByID(id uint) (*models.Teacher, error) {
+	teacher, err := s.teacherRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTeacherNotFound
+		}
+		return nil, err
+	}
+	return teacher, nil
+}
+
+// CreateTeacher creates a teacher with a code no other teacher uses
+func (s *TeacherService) CreateTeacher(req *models.CreateTeacherRequest) (*models.Teacher, error) {
+	teacher := &models.Teacher{}
+	ApplyTeacherRequest(teacher, req)
+	if err := s.ensureCodeFree(teacher); err != nil {
+		return nil, err
+	}
+
+	if err := s.teacherRepo.Create(teacher); err != nil {
+		return nil, err
+	}
+	return teacher, nil
+}
+
+// UpdateTeacher changes the fields set in req
+func (s *TeacherService) UpdateTeacher(id uint, req *models.CreateTeacherRequest) (*models.Teacher, error) {
+	teacher, err := s.GetTeacherByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ApplyTeacherRequest(teacher, req)
+	if err := s.ensureCodeFree(teacher); err != nil {
+		return nil, err
+	}
+
+	if err := s.teacherRepo.Update(teacher); err != nil {
+		return nil, err
+	}
+	return teacher, nil
+}
+
+// DeleteTeacher deletes a teacher who has no upcoming sessions; past sessions
+// keep their teacher ID
+func (s *TeacherService) DeleteTeacher(id uint) error {
+	if _, err := s.GetTeacherByID(id); err != nil {
+		return err
+	}
+
+	upcoming, err := s.teacherRepo.CountUpcomingSessions(id, time.Now())
+	if err != nil {
+		return err
+	}
+	if upcoming > 0 {
+		return fmt.Errorf("%w (%d)", ErrTeacherHasSessions, upcoming)
+	}
+	return s.teacherRepo.Delete(id)
 }
 
-func GetTeacherByID(id int) (*models.Teacher, error) {
-	return repository.GetTeacherByID(id)
+func (s *TeacherService) ensureCodeFree(teacher *models.Teacher) error {
+	if teacher.TeacherCode == nil || strings.TrimSpace(*teacher.TeacherCode) == "" {
+		return nil
+	}
+	existing, err := s.teacherRepo.GetByCode(*teacher.TeacherCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != teacher.ID {
+		return ErrTeacherCodeTaken
+	}
+	return nil
 }
 
-func CreateTeacher(teacher *models.Teacher) error {
-	return repository.CreateTeacher(teacher)
+// ApplyTeacherRequest copies the fields set in req onto teacher
+func ApplyTeacherRequest(teacher *models.Teacher, req *models.CreateTeacherRequest) {
+	if req.TeacherCode != nil {
+		teacher.TeacherCode = req.TeacherCode
+	}
+	if req.TeacherName != nil {
+		teacher.TeacherName = req.TeacherName
+	}
+	if req.Phone != nil {
+		teacher.Phone = req.Phone
+	}
+	if req.Email != nil {
+		teacher.Email = req.Email
+	}
+	if req.WorkUnit != nil {
+		teacher.WorkUnit = req.WorkUnit
+	}
+	if req.DateOfBirth != nil {
+		teacher.DateOfBirth = req.DateOfBirth
+	}
 }
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"errors"
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Deleting an event or a class moves it to the trash together with the
+// records deleted with it (an event's session series, sessions, attendances,
+// registrations and certificates, a class's enrollments and students).
+// Restoring it brings those back as well; records that were deleted on their
+// own before stay in the trash.
+
+// TrashResources lists the resources that have a trash, in the order the
+// automatic purge removes them: events go first because their sessions refer
+// to classes, teachers and rooms.
+var TrashResources = []string{"events", "students", "classes", "teachers", "rooms"}
+
+// trashPurgeLock is the Postgres advisory lock key held while expired records
+// are purged, so only one server instance purges them
+const trashPurgeLock int64 = 0x7472617368 // "trash"
+
+type TrashService struct {
+	trashRepo *repository.TrashRepository
+}
+
+func NewTrashService(trashRepo *repository.TrashRepository) *TrashService {
+	return &TrashService{
+		trashRepo: trashRepo,
+	}
+}
+
+// IsTrashResource reports whether resource has a trash
+func IsTrashResource(resource string) bool {
+	for _, name := range TrashResources {
+		if name == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// TrashPurgeAt is when the automatic purge removes a record deleted at
+// deletedAt, or nil when the automatic purge is disabled
+func TrashPurgeAt(deletedAt time.Time, retention time.Duration) *time.Time {
+	if retention <= 0 {
+		return nil
+	}
+	purgeAt := deletedAt.Add(retention)
+	return &purgeAt
+}
+
+// GetTrash retrieves the deleted records of a resource, most recently deleted first
+func (s *TrashService) GetTrash(resource string) ([]models.TrashItem, error) {
+	if !IsTrashResource(resource) {
+		return nil, ErrTrashResourceNotFound
+	}
+
+	items, err := s.trashRepo.GetDeleted(resource)
+	if err != nil {
+		return nil, err
+	}
+	retention := config.TrashRetention()
+	for i := range items {
+		items[i].PurgeAt = TrashPurgeAt(items[i].DeletedAt, retention)
+	}
+	return items, nil
+}
+
+// RestoreFromTrash restores a deleted record together with the records that
+// were deleted with it
+func (s *TrashService) RestoreFromTrash(resource string, id uint) (*models.TrashRestoreResponse, error) {
+	deletedAt, err := s.findDeleted(resource, id)
+	if err != nil {
+		return nil, err
+	}
+
+	parentDeleted, err := s.trashRepo.IsParentDeleted(resource, id)
+	if err != nil {
+		return nil, err
+	}
+	if parentDeleted {
+		return nil, ErrTrashParentDeleted
+	}
+	taken, err := s.trashRepo.IsUniqueTaken(resource, id)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrTrashRestoreConflict
+	}
+
+	restored, err := s.trashRepo.Restore(resource, id, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TrashRestoreResponse{Resource: resource, ID: id, Restored: restored}, nil
+}
+
+// PurgeFromTrash permanently deletes a deleted record. Purging an event also
+// removes its session series, sessions, attendances, registrations, excuse
+// requests and certificates.
+func (s *TrashService) PurgeFromTrash(resource string, id uint) error {
+	if _, err := s.findDeleted(resource, id); err != nil {
+		return err
+	}
+	return purgeError(s.trashRepo.Purge(resource, id))
+}
+
+// PurgeExpired permanently deletes the records that have been in the trash
+// longer than the retention period. Records still referred to by other
+// records are skipped until those are gone. It does nothing when another
+// server instance is purging already.
+func (s *TrashService) PurgeExpired(now time.Time, retention time.Duration) (int, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	purged := 0
+	_, err := s.trashRepo.WithAdvisoryLock(trashPurgeLock, func(repo *repository.TrashRepository) error {
+		for _, resource := range TrashResources {
+			ids, err := repo.GetDeletedBefore(resource, now.Add(-retention))
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := purgeError(repo.Purge(resource, id)); err != nil {
+					if errors.Is(err, ErrTrashInUse) {
+						log.Printf("⚠️  Trash purge skipped %s %d: %v", resource, id, err)
+						continue
+					}
+					return err
+				}
+				purged++
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// findDeleted retrieves when a record of a resource was deleted
+func (s *TrashService) findDeleted(resource string, id uint) (time.Time, error) {
+	if !IsTrashResource(resource) {
+		return time.Time{}, ErrTrashResourceNotFound
+	}
+	deletedAt, err := s.trashRepo.GetDeletedAt(resource, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, ErrNotInTrash
+		}
+		return time.Time{}, err
+	}
+	return deletedAt, nil
+}
+
+func purgeError(err error) error {
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return ErrTrashInUse
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+// Package web holds the server-rendered pages and their assets, embedded in the binary
+package web
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// DejaVu Sans covers Vietnamese, which the PDF core fonts do not
+//
+//go:embed fonts/*.ttf
+var fontFS embed.FS
+
+var templateFuncs = template.FuncMap{
+	// deref prints optional model fields, which are pointers
+	"deref": func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	},
+	// answer prints a submitted answer back into its input
+	"answer": func(answers map[string]interface{}, key string) string {
+		switch v := answers[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return ""
+		}
+	},
+	// answered reports whether a checkbox was ticked or a choice was picked
+	"answered": func(answers map[string]interface{}, key, choice string) bool {
+		switch v := answers[key].(type) {
+		case bool:
+			return v
+		case string:
+			return v == choice || (choice == "" && v != "")
+		case []string:
+			for _, picked := range v {
+				if picked == choice {
+					return true
+				}
+			}
+		}
+		return false
+	},
+	"formatTime": func(value *time.Time) string {
+		if value == nil {
+			return ""
+		}
+		return value.Local().Format("15:04 02/01/2006")
+	},
+}
+
+// Templates parses the embedded page templates, named by file name (e.g. "checkin_form.html")
+func Templates() *template.Template {
+	return template.Must(template.New("").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.html"))
+}
+
+// Assets serves the embedded static files (stylesheets, icons)
+func Assets() http.FileSystem {
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(static)
+}
+
+// Font returns an embedded TrueType font by file name (e.g. "DejaVuSansCondensed.ttf")
+func Font(name string) ([]byte, error) {
+	return fontFS.ReadFile("fonts/" + name)
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleBatchItem(clientID string, checkedInAt time.Time) models.BatchAttendanceItem {
+	return models.BatchAttendanceItem{
+		ClientID:        clientID,
+		CheckedInAt:     checkedInAt,
+		SessionID:       1,
+		StudentName:     "Nguyen Van A",
+		Email:           "a@example.com",
+		Phone:           "0123456789",
+		WorkUnit:        "Công ty ABC",
+		WorkUnitAddress: "123 Đường ABC",
+	}
+}
+
+// newBatchController builds an attendance controller whose check-ins never
+// reach the database
+func newBatchController(t *testing.T) *controllers.AttendanceController {
+	db, _, err := tests.SetupMockDB()
+	assert.NoError(t, err)
+	return controllers.NewAttendanceController(services.NewCheckInService(repository.NewRegistrationRepository(db)))
+}
+
+func TestSyncAttendances_RejectsItemsIndividually(t *testing.T) {
+	// Setup
+	r := tests.SetupTestGin()
+	r.POST("/attendances/batch", newBatchController(t).SyncAttendances)
+
+	invalid := sampleBatchItem("not-a-uuid", time.Now())
+	missingEmail := sampleBatchItem("6f1c2a9e-4b7d-4e8a-9c3f-1d2e3f4a5b6c", time.Now())
+	missingEmail.Email = ""
+	future := sampleBatchItem("0d4b8e1a-2c3f-4a5b-8c7d-9e0f1a2b3c4d", time.Now().Add(time.Hour))
+
+	// Create request
+	requestBody, _ := json.Marshal(models.BatchAttendanceRequest{
+		Items: []models.BatchAttendanceItem{invalid, missingEmail, future},
+	})
+	req, _ := http.NewRequest("POST", "/attendances/batch", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data    []models.BatchItemResult `json:"data"`
+		Summary map[string]int           `json:"summary"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Len(t, response.Data, 3)
+	for i, item := range []models.BatchAttendanceItem{invalid, missingEmail, future} {
+		assert.Equal(t, item.ClientID, response.Data[i].ClientID)
+		assert.Equal(t, models.BatchResultRejected, response.Data[i].Result)
+		assert.NotEmpty(t, response.Data[i].Reason)
+	}
+	assert.Equal(t, services.ErrCheckinInFuture.Error(), response.Data[2].Reason)
+	assert.Equal(t, 3, response.Summary[models.BatchResultRejected])
+	assert.Equal(t, 0, response.Summary[models.BatchResultCreated])
+}
+
+func TestSyncAttendances_EmptyBatch(t *testing.T) {
+	// Setup
+	r := tests.SetupTestGin()
+	r.POST("/attendances/batch", newBatchController(t).SyncAttendances)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.BatchAttendanceRequest{Items: []models.BatchAttendanceItem{}})
+	req, _ := http.NewRequest("POST", "/attendances/batch", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"hello-gin/config"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkAttendanceSession_OtherTeacherForbidden(t *testing.T) {
+	// Setup
+	db, dbMock, err := tests.SetupMockDB()
+	assert.NoError(t, err)
+	previous := config.DB
+	config.DB = db
+	defer func() { config.DB = previous }()
+
+	dbMock.ExpectQuery(`SELECT \* FROM "attendance_sessions"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "teacher_id", "class_id"}).AddRow(5, 8, 10))
+
+	controller := controllers.NewAttendanceSessionController(
+		services.NewAttendanceSessionService(repository.NewRoomRepository(db)),
+		services.NewRosterService(repository.NewEnrollmentRepository(db), repository.NewExcuseRepository(db)),
+	)
+
+	own := uint(3)
+	claims := &models.AuthClaims{UserID: 2, Role: models.RoleTeacher, TeacherID: &own}
+	r := tests.SetupTestGin()
+	r.POST("/attendance-sessions/:id/marks", withClaims(claims), controller.MarkAttendanceSession)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.BulkMarkAttendanceRequest{
+		Marks: []models.AttendanceMark{{StudentID: 1, Status: models.AttendanceStatusPresent}},
+	})
+	req, _ := http.NewRequest("POST", "/attendance-sessions/5/marks", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
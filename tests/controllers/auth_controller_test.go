@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogin_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	controller := controllers.NewAuthController(mockService)
+
+	tokens := &models.TokenResponse{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		ExpiresIn:    900,
+	}
+
+	// Setup mock expectations
+	mockService.On("Login", mock.AnythingOfType("*models.LoginRequest")).Return(tokens, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/auth/login", controller.Login)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.LoginRequest{Username: "admin", Password: "secret"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Logged in successfully", response["message"])
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "access", data["access_token"])
+	assert.Equal(t, "refresh", data["refresh_token"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	controller := controllers.NewAuthController(mockService)
+
+	// Setup mock expectations
+	mockService.On("Login", mock.AnythingOfType("*models.LoginRequest")).Return((*models.TokenResponse)(nil), services.ErrInvalidCredentials)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/auth/login", controller.Login)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.LoginRequest{Username: "admin", Password: "wrong"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Login failed", response["error"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestLogin_MissingFields(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	controller := controllers.NewAuthController(mockService)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/auth/login", controller.Login)
+
+	// Create request without password
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer([]byte(`{"username":"admin"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Login", mock.Anything)
+}
+
+func TestRefreshToken_Invalid(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	controller := controllers.NewAuthController(mockService)
+
+	// Setup mock expectations
+	mockService.On("RefreshToken", "expired").Return((*models.TokenResponse)(nil), services.ErrInvalidToken)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/auth/refresh", controller.RefreshToken)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.RefreshTokenRequest{RefreshToken: "expired"})
+	req, _ := http.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCalendarFeed_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCalendarService)
+	controller := controllers.NewCalendarController(mockService)
+	mockService.On("GetCalendarFeed", services.CalendarScopeEvents, uint(1), "abc").Return([]byte("BEGIN:VCALENDAR\r\n"), nil)
+
+	r := tests.SetupTestGin()
+	r.GET("/calendar/:scope/:file", controller.GetCalendarFeed)
+
+	req, _ := http.NewRequest("GET", "/calendar/events/1.ics?token=abc", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "BEGIN:VCALENDAR\r\n", w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCalendarFeed_InvalidToken(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCalendarService)
+	controller := controllers.NewCalendarController(mockService)
+	mockService.On("GetCalendarFeed", services.CalendarScopeEvents, uint(1), "wrong").Return(nil, services.ErrInvalidCalendarToken)
+
+	r := tests.SetupTestGin()
+	r.GET("/calendar/:scope/:file", controller.GetCalendarFeed)
+
+	req, _ := http.NewRequest("GET", "/calendar/events/1.ics?token=wrong", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetTeacherCalendarLink_OtherTeacherForbidden(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCalendarService)
+	controller := controllers.NewCalendarController(mockService)
+
+	teacherID := uint(2)
+	claims := &models.AuthClaims{UserID: 5, Role: models.RoleTeacher, TeacherID: &teacherID}
+
+	r := tests.SetupTestGin()
+	r.GET("/teachers/:id/calendar", withClaims(claims), controller.GetTeacherCalendarLink)
+
+	req, _ := http.NewRequest("GET", "/teachers/3/calendar", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "GetCalendarFeedLink")
+}
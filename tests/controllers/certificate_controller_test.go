@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"encoding/json"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAttendanceCertificate_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCertificateService)
+	controller := controllers.NewCertificateController(mockService)
+
+	certificate := &models.Certificate{ID: 1, RecipientName: "Nguyen Van A", Code: "7KQ2M-XR4PD"}
+
+	// Setup mock expectations
+	mockService.On("GetAttendanceCertificate", uint(5)).Return(certificate, []byte("%PDF-1.3"), nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/attendances/:id/certificate", controller.GetAttendanceCertificate)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/attendances/5/certificate", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="Nguyen_Van_A-7KQ2M-XR4PD.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "%PDF-1.3", w.Body.String())
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAttendanceCertificate_NotEligible(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCertificateService)
+	controller := controllers.NewCertificateController(mockService)
+
+	// Setup mock expectations
+	mockService.On("GetAttendanceCertificate", uint(5)).Return(nil, nil, services.ErrNotEligibleForCertificate)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/attendances/:id/certificate", controller.GetAttendanceCertificate)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/attendances/5/certificate", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Not eligible for a certificate", response["error"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestGetEventCertificates_Zip(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCertificateService)
+	controller := controllers.NewCertificateController(mockService)
+
+	// Setup mock expectations
+	mockService.On("GetEventCertificates", uint(3)).Return([]byte("PK"), 12, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/events/:id/certificates", controller.GetEventCertificates)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/events/3/certificates", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, "12", w.Header().Get("X-Certificate-Count"))
+	assert.Equal(t, `attachment; filename="event-3-certificates.zip"`, w.Header().Get("Content-Disposition"))
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestVerifyCertificate_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCertificateService)
+	controller := controllers.NewCertificateController(mockService)
+
+	eventName := "Workshop AI"
+	certificate := &models.Certificate{
+		RecipientName: "Nguyen Van A",
+		Email:         "a@example.com",
+		Dates:         "20/08/2025",
+		Hours:         6.5,
+		Code:          "7KQ2M-XR4PD",
+		IssuedAt:      time.Date(2025, 8, 21, 9, 0, 0, 0, time.UTC),
+		Event:         &models.Event{EventName: &eventName},
+	}
+
+	// Setup mock expectations
+	mockService.On("VerifyCertificate", "7KQ2M-XR4PD").Return(certificate, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/certificates/verify/:code", controller.VerifyCertificate)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/certificates/verify/7KQ2M-XR4PD", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "Nguyen Van A", data["recipient_name"])
+	assert.Equal(t, "Workshop AI", data["event_name"])
+	assert.Equal(t, 6.5, data["hours"])
+	assert.NotContains(t, w.Body.String(), "a@example.com")
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestVerifyCertificate_NotFound(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockCertificateService)
+	controller := controllers.NewCertificateController(mockService)
+
+	// Setup mock expectations
+	mockService.On("VerifyCertificate", "UNKNOWN").Return(nil, services.ErrCertificateNotFound)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/certificates/verify/:code", controller.VerifyCertificate)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/certificates/verify/UNKNOWN", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateClass_CodeTaken(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockClassService)
+	controller := controllers.NewClassController(mockService)
+	mockService.On("CreateClass", mock.AnythingOfType("*models.CreateClassRequest")).Return(nil, services.ErrClassCodeTaken)
+
+	r := tests.SetupTestGin()
+	r.POST("/classes", controller.CreateClass)
+
+	req, _ := http.NewRequest("POST", "/classes", bytes.NewBufferString(`{"class_code":"LOP001","class_name":"K65"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateClass_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockClassService)
+	controller := controllers.NewClassController(mockService)
+
+	code, name := "LOP001", "K66"
+	mockService.On("UpdateClass", uint(2), &models.UpdateClassRequest{ClassName: &name}).
+		Return(&models.Class{ID: 2, ClassCode: &code, ClassName: &name}, nil)
+
+	r := tests.SetupTestGin()
+	r.PATCH("/classes/:id", controller.UpdateClass)
+
+	req, _ := http.NewRequest("PATCH", "/classes/2", bytes.NewBufferString(`{"class_name":"K66"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetClassByID_NotFound(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockClassService)
+	controller := controllers.NewClassController(mockService)
+	mockService.On("GetClassByID", uint(2)).Return(nil, services.ErrClassNotFound)
+
+	r := tests.SetupTestGin()
+	r.GET("/classes/:id", controller.GetClassByID)
+
+	req, _ := http.NewRequest("GET", "/classes/2", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteClass_HasStudents(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockClassService)
+	controller := controllers.NewClassController(mockService)
+	mockService.On("DeleteClass", uint(2), false).Return(int64(0), fmt.Errorf("%w (30 students)", services.ErrClassHasStudents))
+
+	r := tests.SetupTestGin()
+	r.DELETE("/classes/:id", controller.DeleteClass)
+
+	req, _ := http.NewRequest("DELETE", "/classes/2", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteClass_Cascade(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockClassService)
+	controller := controllers.NewClassController(mockService)
+	mockService.On("DeleteClass", uint(2), true).Return(int64(30), nil)
+
+	r := tests.SetupTestGin()
+	r.DELETE("/classes/:id", controller.DeleteClass)
+
+	req, _ := http.NewRequest("DELETE", "/classes/2?cascade=true", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(30), response["deleted_students"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteClass_InvalidCascade(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockClassService)
+	controller := controllers.NewClassController(mockService)
+
+	r := tests.SetupTestGin()
+	r.DELETE("/classes/:id", controller.DeleteClass)
+
+	req, _ := http.NewRequest("DELETE", "/classes/2?cascade=maybe", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "DeleteClass", mock.Anything, mock.Anything)
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"hello-gin/internal/controllers"
 	"hello-gin/internal/models"
+	"hello-gin/internal/services"
 	"hello-gin/tests"
 	mockServices "hello-gin/tests/services"
 	"net/http"
@@ -283,6 +285,45 @@ func TestCreateEvent_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestCreateEvent_InvalidFormSchema(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	// Create sample request
+	request := tests.CreateSampleCreateEventRequest()
+
+	// Setup mock expectations
+	schemaErr := fmt.Errorf("%w: field \"faculty\" needs choices", services.ErrInvalidFormSchema)
+	mockService.On("CreateEvent", mock.AnythingOfType("*models.CreateEventRequest")).Return((*models.Event)(nil), schemaErr)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/events", controller.CreateEvent)
+
+	// Create request body
+	requestBody, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/events", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Invalid form schema", response["error"])
+	assert.Equal(t, schemaErr.Error(), response["message"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
 func TestDeleteEvent_Success(t *testing.T) {
 	// Setup
 	mockService := new(mockServices.MockEventService)
@@ -314,3 +355,324 @@ func TestDeleteEvent_Success(t *testing.T) {
 	// Verify mock expectations
 	mockService.AssertExpectations(t)
 }
+
+func TestSetEventStatus_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	// Setup mock expectations
+	event := &models.Event{ID: 1, Status: models.EventStatusPublished}
+	mockService.On("SetEventStatus", uint(1), models.EventStatusPublished).Return(event, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.PUT("/events/:id/status", controller.SetEventStatus)
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/events/1/status", bytes.NewBufferString(`{"status":"published"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Event is now published", response["message"])
+	assert.Equal(t, models.EventStatusPublished, response["data"].(map[string]interface{})["status"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestSetEventStatus_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"transition not allowed", fmt.Errorf("%w: draft -> closed", services.ErrInvalidEventTransition), http.StatusConflict},
+		{"unknown status", services.ErrInvalidEventStatus, http.StatusBadRequest},
+		{"event not found", services.ErrEventNotFound, http.StatusNotFound},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockEventService)
+		controller := controllers.NewEventController(mockService)
+		mockService.On("SetEventStatus", uint(1), models.EventStatusClosed).Return((*models.Event)(nil), tc.err)
+
+		r := tests.SetupTestGin()
+		r.PUT("/events/:id/status", controller.SetEventStatus)
+
+		req, _ := http.NewRequest("PUT", "/events/1/status", bytes.NewBufferString(`{"status":"closed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestSetEventStatus_MissingStatus(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	r := tests.SetupTestGin()
+	r.PUT("/events/:id/status", controller.SetEventStatus)
+
+	req, _ := http.NewRequest("PUT", "/events/1/status", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "SetEventStatus", mock.Anything, mock.Anything)
+}
+
+func TestEventActive_MapsToStatus(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	// Setup mock expectations
+	event := &models.Event{ID: 1, Status: models.EventStatusOngoing}
+	mockService.On("SetEventActive", uint(1), true).Return(event, nil)
+
+	r := tests.SetupTestGin()
+	r.PUT("/events/:id/active", controller.EventActive)
+
+	req, _ := http.NewRequest("PUT", "/events/1/active", bytes.NewBufferString(`{"active":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetEventStatusLog_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	// Setup mock expectations
+	logs := []models.EventStatusLog{
+		{ID: 1, EventID: 1, FromStatus: models.EventStatusDraft, ToStatus: models.EventStatusPublished, Source: models.EventStatusSourceManual},
+		{ID: 2, EventID: 1, FromStatus: models.EventStatusPublished, ToStatus: models.EventStatusOngoing, Source: models.EventStatusSourceScheduler, Reason: "start_date reached"},
+	}
+	mockService.On("GetEventStatusLog", uint(1)).Return(logs, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/events/:id/status-log", controller.GetEventStatusLog)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/events/1/status-log", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), response["count"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestGetEventStatusLog_NotFound(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+	mockService.On("GetEventStatusLog", uint(9)).Return(nil, services.ErrEventNotFound)
+
+	r := tests.SetupTestGin()
+	r.GET("/events/:id/status-log", controller.GetEventStatusLog)
+
+	req, _ := http.NewRequest("GET", "/events/9/status-log", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCloneEvent_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	// Setup mock expectations
+	clone := tests.CreateSampleEvent()
+	clone.ID = 2
+	clone.Status = models.EventStatusDraft
+	clone.Sessions = []models.AttendanceSession{{ID: 20}, {ID: 21}}
+	mockService.On("CloneEvent", uint(1), mock.MatchedBy(func(req *models.CloneEventRequest) bool {
+		return req.OffsetDays != nil && *req.OffsetDays == 182
+	})).Return(clone, []models.SessionConflict{}, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/events/:id/clone", controller.CloneEvent)
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/events/1/clone", bytes.NewBufferString(`{"offset_days":182}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Event cloned successfully", response["message"])
+	assert.Equal(t, float64(2), response["count"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestCloneEvent_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"no dates", services.ErrInvalidCloneRequest, http.StatusBadRequest},
+		{"event not found", services.ErrEventNotFound, http.StatusNotFound},
+		{"session conflict", &services.SessionConflictError{Conflicts: []models.SessionConflict{{SessionID: 7}}}, http.StatusConflict},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockEventService)
+		controller := controllers.NewEventController(mockService)
+		mockService.On("CloneEvent", uint(1), mock.AnythingOfType("*models.CloneEventRequest")).Return(nil, nil, tc.err)
+
+		r := tests.SetupTestGin()
+		r.POST("/events/:id/clone", controller.CloneEvent)
+
+		req, _ := http.NewRequest("POST", "/events/1/clone", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestGetEventWithSessions_TeacherSeesOwnSessions(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	own, other := uint(4), uint(5)
+	event := &models.Event{
+		ID: 1,
+		Sessions: []models.AttendanceSession{
+			{ID: 10, TeacherID: &own, Attendances: []models.Attendance{{ID: 100}}},
+			{ID: 11, TeacherID: &other, Attendances: []models.Attendance{{ID: 101}}},
+			{ID: 12},
+		},
+	}
+	mockService.On("GetEventByIDWithSessions", uint(1)).Return(event, nil)
+
+	claims := &models.AuthClaims{UserID: 2, Role: models.RoleTeacher, TeacherID: &own}
+	r := tests.SetupTestGin()
+	r.GET("/events/:id/sessions", withClaims(claims), controller.GetEventWithSessions)
+
+	req, _ := http.NewRequest("GET", "/events/1/sessions", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data models.Event `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	if assert.Len(t, response.Data.Sessions, 1) {
+		assert.Equal(t, uint(10), response.Data.Sessions[0].ID)
+		assert.Len(t, response.Data.Sessions[0].Attendances, 1)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetEventWithSessions_KioskGetsNoAttendees(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockEventService)
+	controller := controllers.NewEventController(mockService)
+
+	event := &models.Event{
+		ID:       1,
+		Sessions: []models.AttendanceSession{{ID: 10, Attendances: []models.Attendance{{ID: 100}}}},
+	}
+	mockService.On("GetEventByIDWithSessions", uint(1)).Return(event, nil)
+
+	claims := &models.AuthClaims{UserID: 6, Role: models.RoleKiosk}
+	r := tests.SetupTestGin()
+	r.GET("/events/:id/sessions", withClaims(claims), controller.GetEventWithSessions)
+
+	req, _ := http.NewRequest("GET", "/events/1/sessions", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data models.Event `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	if assert.Len(t, response.Data.Sessions, 1) {
+		assert.Empty(t, response.Data.Sessions[0].Attendances)
+	}
+
+	mockService.AssertExpectations(t)
+}
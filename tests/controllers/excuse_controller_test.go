@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/middleware"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withClaims stands in for AuthRequired by putting the given user in the context
+func withClaims(claims *models.AuthClaims) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.ContextClaimsKey, claims)
+		c.Next()
+	}
+}
+
+func sampleExcuse(studentID, teacherID uint) *models.ExcuseRequest {
+	return &models.ExcuseRequest{
+		ID:        1,
+		SessionID: 5,
+		StudentID: studentID,
+		Reason:    "Sick",
+		Status:    models.ExcuseStatusPending,
+		Session:   &models.AttendanceSession{ID: 5, TeacherID: &teacherID},
+	}
+}
+
+func TestCreateExcuse_StudentSubmitsForThemselves(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	studentID := uint(7)
+	claims := &models.AuthClaims{UserID: 3, Role: models.RoleStudent, StudentID: &studentID}
+
+	// Setup mock expectations
+	mockService.On("SubmitExcuse",
+		mock.MatchedBy(func(req *models.CreateExcuseRequest) bool {
+			return req.StudentID == studentID && req.SessionID == 5 && req.Reason == "Sick"
+		}),
+		(*multipart.FileHeader)(nil),
+		mock.MatchedBy(func(userID *uint) bool { return userID != nil && *userID == 3 }),
+	).Return(sampleExcuse(studentID, 2), nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/excuses", withClaims(claims), controller.CreateExcuse)
+
+	// Create request; the student_id of another student must be ignored
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("session_id", "5")
+	writer.WriteField("student_id", "99")
+	writer.WriteField("reason", "Sick")
+	writer.Close()
+	req, _ := http.NewRequest("POST", "/excuses", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Excuse request submitted successfully", response["message"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateExcuse_AlreadyExists(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	studentID := uint(7)
+	claims := &models.AuthClaims{UserID: 3, Role: models.RoleStudent, StudentID: &studentID}
+
+	// Setup mock expectations
+	mockService.On("SubmitExcuse", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, services.ErrExcuseAlreadyExists)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/excuses", withClaims(claims), controller.CreateExcuse)
+
+	// Create request
+	requestBody, _ := json.Marshal(map[string]interface{}{"session_id": 5, "reason": "Sick"})
+	req, _ := http.NewRequest("POST", "/excuses", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateExcuse_ManagerMustPassStudent(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	claims := &models.AuthClaims{UserID: 1, Role: models.RoleAdmin}
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/excuses", withClaims(claims), controller.CreateExcuse)
+
+	// Create request
+	requestBody, _ := json.Marshal(map[string]interface{}{"session_id": 5, "reason": "Sick"})
+	req, _ := http.NewRequest("POST", "/excuses", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "SubmitExcuse", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetExcuses_TeacherIsScopedToOwnSessions(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	teacherID := uint(2)
+	claims := &models.AuthClaims{UserID: 4, Role: models.RoleTeacher, TeacherID: &teacherID}
+
+	// Setup mock expectations
+	mockService.On("GetExcuses", mock.MatchedBy(func(filter models.ExcuseFilter) bool {
+		return filter.Status == models.ExcuseStatusPending && filter.TeacherID != nil && *filter.TeacherID == teacherID
+	})).Return([]models.ExcuseRequest{*sampleExcuse(7, teacherID)}, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/excuses", withClaims(claims), controller.GetExcuses)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/excuses?status=pending", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), response["count"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestGetExcuseByID_OtherStudentForbidden(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	studentID := uint(8)
+	claims := &models.AuthClaims{UserID: 3, Role: models.RoleStudent, StudentID: &studentID}
+
+	// Setup mock expectations
+	mockService.On("GetExcuseByID", uint(1)).Return(sampleExcuse(7, 2), nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/excuses/:id", withClaims(claims), controller.GetExcuseByID)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/excuses/1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestApproveExcuse_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	teacherID := uint(2)
+	claims := &models.AuthClaims{UserID: 4, Role: models.RoleTeacher, TeacherID: &teacherID}
+
+	approved := sampleExcuse(7, teacherID)
+	approved.Status = models.ExcuseStatusApproved
+
+	// Setup mock expectations
+	mockService.On("GetExcuseByID", uint(1)).Return(sampleExcuse(7, teacherID), nil)
+	mockService.On("ReviewExcuse", uint(1), true,
+		mock.MatchedBy(func(req *models.ReviewExcuseRequest) bool { return req.Note != nil && *req.Note == "OK" }),
+		mock.MatchedBy(func(userID *uint) bool { return userID != nil && *userID == 4 }),
+	).Return(approved, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.PUT("/excuses/:id/approve", withClaims(claims), controller.ApproveExcuse)
+
+	// Create request
+	requestBody, _ := json.Marshal(map[string]string{"note": "OK"})
+	req, _ := http.NewRequest("PUT", "/excuses/1/approve", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Excuse request approved", response["message"])
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, models.ExcuseStatusApproved, data["status"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestApproveExcuse_OtherTeacherForbidden(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	teacherID := uint(3)
+	claims := &models.AuthClaims{UserID: 4, Role: models.RoleTeacher, TeacherID: &teacherID}
+
+	// Setup mock expectations
+	mockService.On("GetExcuseByID", uint(1)).Return(sampleExcuse(7, 2), nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.PUT("/excuses/:id/approve", withClaims(claims), controller.ApproveExcuse)
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/excuses/1/approve", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "ReviewExcuse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRejectExcuse_AlreadyReviewed(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockExcuseService)
+	controller := controllers.NewExcuseController(mockService)
+
+	claims := &models.AuthClaims{UserID: 1, Role: models.RoleAdmin}
+
+	// Setup mock expectations
+	mockService.On("GetExcuseByID", uint(1)).Return(sampleExcuse(7, 2), nil)
+	mockService.On("ReviewExcuse", uint(1), false, mock.Anything, mock.Anything).
+		Return(nil, services.ErrExcuseAlreadyReviewed)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.PUT("/excuses/:id/reject", withClaims(claims), controller.RejectExcuse)
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/excuses/1/reject", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
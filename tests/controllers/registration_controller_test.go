@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegister_Waitlisted(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	registration := &models.Registration{
+		ID:          1,
+		EventID:     3,
+		FullName:    "Nguyen Van A",
+		Email:       "a@example.com",
+		Status:      models.RegistrationStatusWaitlisted,
+		CancelToken: "token",
+	}
+
+	// Setup mock expectations
+	mockService.On("Register", uint(3), mock.AnythingOfType("*models.CreateRegistrationRequest")).Return(registration, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/events/:id/registrations", controller.Register)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.CreateRegistrationRequest{FullName: "Nguyen Van A", Email: "a@example.com"})
+	req, _ := http.NewRequest("POST", "/events/3/registrations", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Event is full, added to the waitlist", response["message"])
+	assert.Equal(t, "token", response["cancel_token"])
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, models.RegistrationStatusWaitlisted, data["status"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestRegister_InvalidEmail(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/events/:id/registrations", controller.Register)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.CreateRegistrationRequest{FullName: "Nguyen Van A", Email: "not-an-email"})
+	req, _ := http.NewRequest("POST", "/events/3/registrations", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+}
+
+func TestRegister_AlreadyRegistered(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	// Setup mock expectations
+	mockService.On("Register", uint(3), mock.Anything).Return(nil, services.ErrAlreadyRegistered)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/events/:id/registrations", controller.Register)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.CreateRegistrationRequest{FullName: "Nguyen Van A", Email: "a@example.com"})
+	req, _ := http.NewRequest("POST", "/events/3/registrations", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestRegister_EventNotOpen(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	// Setup mock expectations
+	mockService.On("Register", uint(3), mock.Anything).Return(nil, services.ErrEventNotOpenForRegistration)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/events/:id/registrations", controller.Register)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.CreateRegistrationRequest{FullName: "Nguyen Van A", Email: "a@example.com"})
+	req, _ := http.NewRequest("POST", "/events/3/registrations", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Registration closed", response["error"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestCancelRegistration_PromotesWaitlist(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	cancelled := &models.Registration{ID: 1, Status: models.RegistrationStatusCancelled}
+	promoted := []models.Registration{{ID: 2, Status: models.RegistrationStatusRegistered}}
+
+	// Setup mock expectations
+	mockService.On("CancelRegistration", "token").Return(cancelled, promoted, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/registrations/cancel", controller.CancelRegistration)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.CancelRegistrationRequest{CancelToken: "token"})
+	req, _ := http.NewRequest("POST", "/registrations/cancel", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1), response["promoted"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteRegistration_PromotedCount(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	cancelled := &models.Registration{ID: 1, Status: models.RegistrationStatusCancelled}
+	promoted := []models.Registration{
+		{ID: 2, Status: models.RegistrationStatusRegistered},
+		{ID: 3, Status: models.RegistrationStatusRegistered},
+	}
+
+	// Setup mock expectations
+	mockService.On("CancelRegistrationByID", uint(1)).Return(cancelled, promoted, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.DELETE("/registrations/:id", controller.DeleteRegistration)
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/registrations/1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), response["promoted"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestCancelRegistration_UnknownToken(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	// Setup mock expectations
+	mockService.On("CancelRegistration", "unknown").Return(nil, nil, services.ErrRegistrationNotFound)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.POST("/registrations/cancel", controller.CancelRegistration)
+
+	// Create request
+	requestBody, _ := json.Marshal(models.CancelRegistrationRequest{CancelToken: "unknown"})
+	req, _ := http.NewRequest("POST", "/registrations/cancel", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
+
+func TestGetNoShows_BySession(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRegistrationService)
+	controller := controllers.NewRegistrationController(mockService)
+
+	noShows := []models.Registration{{ID: 4, Status: models.RegistrationStatusRegistered}}
+
+	// Setup mock expectations
+	mockService.On("GetNoShows", uint(3), mock.MatchedBy(func(sessionID *uint) bool {
+		return sessionID != nil && *sessionID == 7
+	})).Return(noShows, nil)
+
+	// Setup Gin
+	r := tests.SetupTestGin()
+	r.GET("/events/:id/no-shows", controller.GetNoShows)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/events/3/no-shows?session_id=7", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1), response["count"])
+
+	// Verify mock expectations
+	mockService.AssertExpectations(t)
+}
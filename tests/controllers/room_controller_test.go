@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRooms_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRoomService)
+	controller := controllers.NewRoomController(mockService)
+
+	rooms := []models.Room{{ID: 1, Name: "A101"}, {ID: 2, Name: "A102"}}
+	mockService.On("GetRooms").Return(rooms, nil)
+
+	r := tests.SetupTestGin()
+	r.GET("/rooms", controller.GetRooms)
+
+	req, _ := http.NewRequest("GET", "/rooms", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), response["count"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateRoom_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRoomService)
+	controller := controllers.NewRoomController(mockService)
+
+	capacity := 60
+	room := &models.Room{ID: 1, Name: "A101", Capacity: &capacity}
+	mockService.On("CreateRoom", mock.AnythingOfType("*models.RoomRequest")).Return(room, nil)
+
+	r := tests.SetupTestGin()
+	r.POST("/rooms", controller.CreateRoom)
+
+	req, _ := http.NewRequest("POST", "/rooms", bytes.NewBufferString(`{"name":"A101","capacity":60}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Room created successfully", response["message"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateRoom_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"missing name", fmt.Errorf("%w: name is required", services.ErrInvalidRoom), http.StatusBadRequest},
+		{"name taken", services.ErrRoomNameTaken, http.StatusConflict},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockRoomService)
+		controller := controllers.NewRoomController(mockService)
+		mockService.On("CreateRoom", mock.AnythingOfType("*models.RoomRequest")).Return(nil, tc.err)
+
+		r := tests.SetupTestGin()
+		r.POST("/rooms", controller.CreateRoom)
+
+		req, _ := http.NewRequest("POST", "/rooms", bytes.NewBufferString(`{"building":"B"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestDeleteRoom_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"upcoming sessions", fmt.Errorf("%w (3)", services.ErrRoomInUse), http.StatusConflict},
+		{"room not found", services.ErrRoomNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockRoomService)
+		controller := controllers.NewRoomController(mockService)
+		mockService.On("DeleteRoom", uint(1)).Return(tc.err)
+
+		r := tests.SetupTestGin()
+		r.DELETE("/rooms/:id", controller.DeleteRoom)
+
+		req, _ := http.NewRequest("DELETE", "/rooms/1", nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestGetAvailableRooms_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRoomService)
+	controller := controllers.NewRoomController(mockService)
+
+	from := time.Date(2025, 9, 2, 1, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 9, 2, 3, 0, 0, 0, time.UTC)
+	mockService.On("GetAvailableRooms", mock.MatchedBy(from.Equal), mock.MatchedBy(to.Equal), 40).
+		Return([]models.Room{{ID: 2, Name: "A102"}}, nil)
+
+	r := tests.SetupTestGin()
+	r.GET("/rooms/available", controller.GetAvailableRooms)
+
+	req, _ := http.NewRequest("GET", "/rooms/available?from=2025-09-02T08:00:00%2B07:00&to=2025-09-02T10:00:00%2B07:00&min_capacity=40", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), response["count"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAvailableRooms_InvalidRange(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockRoomService)
+	controller := controllers.NewRoomController(mockService)
+
+	r := tests.SetupTestGin()
+	r.GET("/rooms/available", controller.GetAvailableRooms)
+
+	for _, query := range []string{
+		"",
+		"?from=2025-09-02T10:00:00Z&to=2025-09-02T08:00:00Z",
+		"?from=2025-09-02&to=next-tuesday",
+		"?from=2025-09-02&to=2025-09-02&min_capacity=-1",
+	} {
+		req, _ := http.NewRequest("GET", "/rooms/available"+query, nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockService.AssertNotCalled(t, "GetAvailableRooms", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStudentByID_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	name := "Nguyen Van A"
+	mockService.On("GetStudentByID", uint(7)).Return(&models.Student{ID: 7, StudentName: &name}, nil)
+
+	r := tests.SetupTestGin()
+	r.GET("/students/:id", controller.GetStudentByID)
+
+	req, _ := http.NewRequest("GET", "/students/7", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Nguyen Van A", response["data"].(map[string]interface{})["student_name"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStudentByID_NotFound(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+	mockService.On("GetStudentByID", uint(7)).Return(nil, services.ErrStudentNotFound)
+
+	r := tests.SetupTestGin()
+	r.GET("/students/:id", controller.GetStudentByID)
+
+	req, _ := http.NewRequest("GET", "/students/7", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStudentByID_OtherStudent(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	studentID := uint(8)
+	claims := &models.AuthClaims{UserID: 3, Role: models.RoleStudent, StudentID: &studentID}
+
+	r := tests.SetupTestGin()
+	r.GET("/students/:id", withClaims(claims), controller.GetStudentByID)
+
+	req, _ := http.NewRequest("GET", "/students/7", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "GetStudentByID", mock.Anything)
+}
+
+func TestUpdateStudent_PartialUpdate(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	email := "a.nguyen@example.com"
+	mockService.On("UpdateStudent", uint(7), mock.MatchedBy(func(req *models.CreateStudentRequest) bool {
+		return req.Email != nil && *req.Email == email && req.StudentName == nil && req.ClassID == nil
+	})).Return(&models.Student{ID: 7, Email: &email}, nil)
+
+	r := tests.SetupTestGin()
+	r.PATCH("/students/:id", controller.UpdateStudent)
+
+	req, _ := http.NewRequest("PATCH", "/students/7", bytes.NewBufferString(`{"email":"a.nguyen@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateStudent_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"student not found", services.ErrStudentNotFound, http.StatusNotFound},
+		{"class not found", services.ErrClassNotFound, http.StatusBadRequest},
+		{"code taken", services.ErrStudentCodeTaken, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockStudentService)
+		controller := controllers.NewStudentController(mockService)
+		mockService.On("UpdateStudent", uint(7), mock.AnythingOfType("*models.CreateStudentRequest")).Return(nil, tc.err)
+
+		r := tests.SetupTestGin()
+		r.PATCH("/students/:id", controller.UpdateStudent)
+
+		req, _ := http.NewRequest("PATCH", "/students/7", bytes.NewBufferString(`{"student_code":"SV001","class_id":2}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestDeleteStudent_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+	mockService.On("DeleteStudent", uint(7)).Return(nil)
+
+	r := tests.SetupTestGin()
+	r.DELETE("/students/:id", controller.DeleteStudent)
+
+	req, _ := http.NewRequest("DELETE", "/students/7", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStudentEnrollments_OtherStudent(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	studentID := uint(8)
+	claims := &models.AuthClaims{UserID: 3, Role: models.RoleStudent, StudentID: &studentID}
+
+	r := tests.SetupTestGin()
+	r.GET("/students/:id/enrollments", withClaims(claims), controller.GetStudentEnrollments)
+
+	req, _ := http.NewRequest("GET", "/students/7/enrollments", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "GetStudentEnrollments", mock.Anything)
+}
+
+func TestTransferStudent_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	enrollment := &models.Enrollment{ID: 4, StudentID: 7, ClassID: 2, Role: models.EnrollmentRoleStudent}
+	mockService.On("TransferStudent", uint(7), mock.MatchedBy(func(req *models.TransferStudentRequest) bool {
+		return req.ToClassID == 2 && req.FromClassID == nil && req.EffectiveDate != nil && *req.EffectiveDate == "2025-10-01"
+	})).Return(enrollment, nil)
+
+	r := tests.SetupTestGin()
+	r.POST("/students/:id/transfer", controller.TransferStudent)
+
+	req, _ := http.NewRequest("POST", "/students/7/transfer", bytes.NewBufferString(`{"to_class_id":2,"effective_date":"2025-10-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Student transferred successfully", response["message"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTransferStudent_MissingClass(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	r := tests.SetupTestGin()
+	r.POST("/students/:id/transfer", controller.TransferStudent)
+
+	req, _ := http.NewRequest("POST", "/students/7/transfer", bytes.NewBufferString(`{"effective_date":"2025-10-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "TransferStudent", mock.Anything, mock.Anything)
+}
+
+func TestEnrollmentErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not enrolled", services.ErrNotEnrolled, http.StatusConflict},
+		{"already enrolled", services.ErrAlreadyEnrolled, http.StatusConflict},
+		{"future date", services.ErrInvalidEnrollmentDate, http.StatusBadRequest},
+		{"class not found", services.ErrClassNotFound, http.StatusBadRequest},
+		{"student not found", services.ErrStudentNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockStudentService)
+		controller := controllers.NewStudentController(mockService)
+		mockService.On("WithdrawStudent", uint(7), mock.AnythingOfType("*models.WithdrawStudentRequest")).Return(nil, tc.err)
+
+		r := tests.SetupTestGin()
+		r.POST("/students/:id/withdraw", controller.WithdrawStudent)
+
+		// The body is optional
+		req, _ := http.NewRequest("POST", "/students/7/withdraw", nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestEnrollStudent_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockStudentService)
+	controller := controllers.NewStudentController(mockService)
+
+	enrollment := &models.Enrollment{ID: 5, StudentID: 7, ClassID: 3, Role: models.EnrollmentRoleAuditor}
+	mockService.On("EnrollStudent", uint(7), mock.MatchedBy(func(req *models.EnrollStudentRequest) bool {
+		return req.ClassID == 3 && req.Role == models.EnrollmentRoleAuditor
+	})).Return(enrollment, nil)
+
+	r := tests.SetupTestGin()
+	r.POST("/students/:id/enrollments", controller.EnrollStudent)
+
+	req, _ := http.NewRequest("POST", "/students/7/enrollments", bytes.NewBufferString(`{"class_id":3,"role":"auditor"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTeachers_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTeacherService)
+	controller := controllers.NewTeacherController(mockService)
+	mockService.On("GetTeachers").Return([]models.Teacher{{ID: 1}}, nil)
+
+	r := tests.SetupTestGin()
+	r.GET("/teachers", controller.GetTeachers)
+
+	req, _ := http.NewRequest("GET", "/teachers", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateTeacher_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"teacher not found", services.ErrTeacherNotFound, http.StatusNotFound},
+		{"code taken", services.ErrTeacherCodeTaken, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockTeacherService)
+		controller := controllers.NewTeacherController(mockService)
+		mockService.On("UpdateTeacher", uint(3), mock.AnythingOfType("*models.CreateTeacherRequest")).Return(nil, tc.err)
+
+		r := tests.SetupTestGin()
+		r.PATCH("/teachers/:id", controller.UpdateTeacher)
+
+		req, _ := http.NewRequest("PATCH", "/teachers/3", bytes.NewBufferString(`{"teacher_code":"GV002"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestDeleteTeacher_HasSessions(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTeacherService)
+	controller := controllers.NewTeacherController(mockService)
+	mockService.On("DeleteTeacher", uint(3)).Return(fmt.Errorf("%w (4)", services.ErrTeacherHasSessions))
+
+	r := tests.SetupTestGin()
+	r.DELETE("/teachers/:id", controller.DeleteTeacher)
+
+	req, _ := http.NewRequest("DELETE", "/teachers/3", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteTeacher_InvalidID(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTeacherService)
+	controller := controllers.NewTeacherController(mockService)
+
+	r := tests.SetupTestGin()
+	r.DELETE("/teachers/:id", controller.DeleteTeacher)
+
+	req, _ := http.NewRequest("DELETE", "/teachers/abc", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "DeleteTeacher", mock.Anything)
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hello-gin/internal/controllers"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrash_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTrashService)
+	controller := controllers.NewTrashController(mockService)
+
+	deletedAt := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	items := []models.TrashItem{{ID: 3, Label: "Go Workshop", DeletedAt: deletedAt}}
+	mockService.On("GetTrash", "events").Return(items, nil)
+
+	r := tests.SetupTestGin()
+	r.GET("/trash/:resource", controller.GetTrash)
+
+	req, _ := http.NewRequest("GET", "/trash/events", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), response["count"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetTrash_UnknownResource(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTrashService)
+	controller := controllers.NewTrashController(mockService)
+	mockService.On("GetTrash", "users").Return(nil, services.ErrTrashResourceNotFound)
+
+	r := tests.SetupTestGin()
+	r.GET("/trash/:resource", controller.GetTrash)
+
+	req, _ := http.NewRequest("GET", "/trash/users", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRestoreFromTrash_Success(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTrashService)
+	controller := controllers.NewTrashController(mockService)
+
+	result := &models.TrashRestoreResponse{
+		Resource: "events",
+		ID:       3,
+		Restored: map[string]int64{"attendance_sessions": 2, "attendances": 40, "registrations": 0},
+	}
+	mockService.On("RestoreFromTrash", "events", uint(3)).Return(result, nil)
+
+	r := tests.SetupTestGin()
+	r.POST("/trash/:resource/:id/restore", controller.RestoreFromTrash)
+
+	req, _ := http.NewRequest("POST", "/trash/events/3/restore", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data models.TrashRestoreResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(40), response.Data.Restored["attendances"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRestoreFromTrash_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not in trash", services.ErrNotInTrash, http.StatusNotFound},
+		{"code taken", services.ErrTrashRestoreConflict, http.StatusConflict},
+		{"class still deleted", services.ErrTrashParentDeleted, http.StatusConflict},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockTrashService)
+		controller := controllers.NewTrashController(mockService)
+		mockService.On("RestoreFromTrash", "students", uint(7)).Return(nil, tc.err)
+
+		r := tests.SetupTestGin()
+		r.POST("/trash/:resource/:id/restore", controller.RestoreFromTrash)
+
+		req, _ := http.NewRequest("POST", "/trash/students/7/restore", nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestPurgeFromTrash(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"purged", nil, http.StatusOK},
+		{"still referenced", services.ErrTrashInUse, http.StatusConflict},
+		{"not in trash", services.ErrNotInTrash, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		// Setup
+		mockService := new(mockServices.MockTrashService)
+		controller := controllers.NewTrashController(mockService)
+		mockService.On("PurgeFromTrash", "teachers", uint(2)).Return(tc.err)
+
+		r := tests.SetupTestGin()
+		r.DELETE("/trash/:resource/:id", controller.PurgeFromTrash)
+
+		req, _ := http.NewRequest("DELETE", "/trash/teachers/2", nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		mockService.AssertExpectations(t)
+	}
+}
+
+func TestPurgeFromTrash_InvalidID(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockTrashService)
+	controller := controllers.NewTrashController(mockService)
+
+	r := tests.SetupTestGin()
+	r.DELETE("/trash/:resource/:id", controller.PurgeFromTrash)
+
+	req, _ := http.NewRequest("DELETE", "/trash/rooms/abc", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "PurgeFromTrash")
+}
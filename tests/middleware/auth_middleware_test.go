@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"encoding/json"
+	"hello-gin/internal/middleware"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	mockServices "hello-gin/tests/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupProtectedRouter(authService *mockServices.MockAuthService) *gin.Engine {
+	r := tests.SetupTestGin()
+	api := r.Group("/api")
+	api.Use(middleware.AuthRequired(authService, "GET /api/health"))
+	api.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	})
+	api.GET("/events", func(c *gin.Context) {
+		claims, _ := middleware.CurrentClaims(c)
+		c.JSON(http.StatusOK, gin.H{"username": claims.Username})
+	})
+	return r
+}
+
+func TestAuthRequired_PublicRoute(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	r := setupProtectedRouter(mockService)
+
+	// Create request without token
+	req, _ := http.NewRequest("GET", "/api/health", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertNotCalled(t, "ParseAccessToken")
+}
+
+func TestAuthRequired_MissingToken(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	r := setupProtectedRouter(mockService)
+
+	// Create request without token
+	req, _ := http.NewRequest("GET", "/api/events", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Unauthorized")
+}
+
+func TestAuthRequired_InvalidToken(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	mockService.On("ParseAccessToken", "bad").Return((*models.AuthClaims)(nil), services.ErrInvalidToken)
+	r := setupProtectedRouter(mockService)
+
+	// Create request with invalid token
+	req, _ := http.NewRequest("GET", "/api/events", nil)
+	req.Header.Set("Authorization", "Bearer bad")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthRequired_ValidToken(t *testing.T) {
+	// Setup
+	mockService := new(mockServices.MockAuthService)
+	mockService.On("ParseAccessToken", "good").Return(&models.AuthClaims{UserID: 1, Username: "admin"}, nil)
+	r := setupProtectedRouter(mockService)
+
+	// Create request with valid token
+	req, _ := http.NewRequest("GET", "/api/events", nil)
+	req.Header.Set("Authorization", "Bearer good")
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "admin")
+	mockService.AssertExpectations(t)
+}
+
+func setupRoleRouter(claims *models.AuthClaims) *gin.Engine {
+	r := tests.SetupTestGin()
+	r.DELETE("/events/:id", func(c *gin.Context) {
+		c.Set(middleware.ContextClaimsKey, claims)
+		c.Next()
+	}, middleware.RequireRoles(models.RoleAdmin, models.RoleOrganizer), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Event deleted successfully"})
+	})
+	return r
+}
+
+func TestRequireRoles_Allowed(t *testing.T) {
+	// Setup
+	r := setupRoleRouter(&models.AuthClaims{UserID: 1, Role: models.RoleOrganizer})
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/events/1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRequireRoles_Forbidden(t *testing.T) {
+	// Setup
+	r := setupRoleRouter(&models.AuthClaims{UserID: 2, Role: models.RoleTeacher})
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/events/1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Forbidden", response["error"])
+	assert.NotEmpty(t, response["message"])
+}
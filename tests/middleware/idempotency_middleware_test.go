@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"bytes"
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/middleware"
+	"hello-gin/internal/models"
+	"hello-gin/tests"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyStore is an in-memory IdempotencyStoreInterface for tests
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+var _ interfaces.IdempotencyStoreInterface = (*memoryIdempotencyStore)(nil)
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*models.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Begin(scope, key, requestHash string, ttl time.Duration) (*models.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[scope+"|"+key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return existing, false, nil
+	}
+	record := &models.IdempotencyRecord{Scope: scope, Key: key, RequestHash: requestHash, ExpiresAt: time.Now().Add(ttl)}
+	s.records[scope+"|"+key] = record
+	return record, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(record *models.IdempotencyRecord, statusCode int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record.Completed = true
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.ResponseBody = body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(record *models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, record.Scope+"|"+record.Key)
+	return nil
+}
+
+func setupIdempotentRouter(store interfaces.IdempotencyStoreInterface, status int, calls *int) *gin.Engine {
+	r := tests.SetupTestGin()
+	r.Use(middleware.Idempotency(store, time.Hour))
+	r.POST("/events", func(c *gin.Context) {
+		*calls++
+		c.JSON(status, gin.H{"call": *calls})
+	})
+	return r
+}
+
+func postWithKey(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/events", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	// Setup
+	calls := 0
+	r := setupIdempotentRouter(newMemoryIdempotencyStore(), http.StatusCreated, &calls)
+
+	// Execute
+	first := postWithKey(r, "key-1", `{"event_name":"Workshop"}`)
+	second := postWithKey(r, "key-1", `{"event_name":"Workshop"}`)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Empty(t, first.Header().Get(middleware.IdempotencyReplayedHeader))
+}
+
+func TestIdempotency_DifferentBodyRejected(t *testing.T) {
+	// Setup
+	calls := 0
+	r := setupIdempotentRouter(newMemoryIdempotencyStore(), http.StatusCreated, &calls)
+
+	// Execute
+	postWithKey(r, "key-1", `{"event_name":"Workshop"}`)
+	w := postWithKey(r, "key-1", `{"event_name":"Seminar"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	// Setup
+	calls := 0
+	r := setupIdempotentRouter(newMemoryIdempotencyStore(), http.StatusCreated, &calls)
+
+	// Execute
+	postWithKey(r, "", `{"event_name":"Workshop"}`)
+	postWithKey(r, "", `{"event_name":"Workshop"}`)
+
+	// Assert
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_ServerErrorNotStored(t *testing.T) {
+	// Setup
+	calls := 0
+	r := setupIdempotentRouter(newMemoryIdempotencyStore(), http.StatusInternalServerError, &calls)
+
+	// Execute
+	postWithKey(r, "key-1", `{}`)
+	w := postWithKey(r, "key-1", `{}`)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, w.Header().Get(middleware.IdempotencyReplayedHeader))
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	// Setup: the recovery middleware of gin.Default turns the panic into a 500
+	calls := 0
+	r := tests.SetupTestGin()
+	r.Use(middleware.Idempotency(newMemoryIdempotencyStore(), time.Hour))
+	r.POST("/events", func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("handler failed")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	// Execute
+	first := postWithKey(r, "key-1", `{}`)
+	second := postWithKey(r, "key-1", `{}`)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	// Setup: a reservation without a stored response yet
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	r := setupIdempotentRouter(store, http.StatusCreated, &calls)
+
+	first := postWithKey(r, "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	for _, record := range store.records {
+		record.Completed = false
+	}
+
+	// Execute
+	w := postWithKey(r, "key-1", `{}`)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAttendanceExport_AddsAnswerColumns(t *testing.T) {
+	schema := models.FormSchema{
+		{Key: "job_title", Label: "Job title", Type: models.FormFieldText},
+		{Key: "topics", Label: "Topics", Type: models.FormFieldMultiSelect, Choices: []string{"AI", "Web"}},
+		{Key: "consent", Label: "Consent", Type: models.FormFieldCheckbox},
+	}
+	name, status := "Nguyen Van A", models.AttendanceStatusPresent
+	sessionID := uint(3)
+	checkedIn := time.Date(2025, 8, 20, 8, 30, 0, 0, time.UTC)
+	attendances := []models.Attendance{{
+		SessionID:   &sessionID,
+		StudentName: &name,
+		Status:      &status,
+		CheckedInAt: &checkedIn,
+		// Answers read back from JSONB hold lists as []interface{}
+		Answers: models.FormAnswers{"job_title": "Engineer", "topics": []interface{}{"AI", "Web"}, "consent": true},
+	}}
+
+	rows := services.BuildAttendanceExport(schema, attendances)
+
+	assert.Len(t, rows, 2)
+	header, row := rows[0], rows[1]
+	assert.Equal(t, []string{"Job title", "Topics", "Consent"}, header[len(header)-3:])
+	assert.Equal(t, []string{"Engineer", "AI; Web", "Yes"}, row[len(row)-3:])
+	assert.Equal(t, "3", row[0])
+	assert.Equal(t, "Nguyen Van A", row[3])
+	assert.Equal(t, "2025-08-20T08:30:00Z", row[10])
+}
+
+func TestBuildAttendanceExport_OrdersByCheckin(t *testing.T) {
+	first, second := "First", "Second"
+	early := time.Date(2025, 8, 20, 8, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+	attendances := []models.Attendance{
+		{StudentName: &second, CheckedInAt: &late},
+		{StudentName: &first, CheckedInAt: &early},
+	}
+
+	rows := services.BuildAttendanceExport(nil, attendances)
+
+	assert.Equal(t, "First", rows[1][3])
+	assert.Equal(t, "Second", rows[2][3])
+}
+
+func TestBuildAttendanceExport_EscapesFormulas(t *testing.T) {
+	schema := models.FormSchema{
+		{Key: "note", Label: "Note", Type: models.FormFieldText},
+		{Key: "topics", Label: "Topics", Type: models.FormFieldMultiSelect, Choices: []string{"@AI", "Web"}},
+		{Key: "age", Label: "Age", Type: models.FormFieldNumber},
+	}
+	name, workUnit, phone := "=HYPERLINK(\"http://evil\")", "-2+3", "+84901234567"
+	attendances := []models.Attendance{{
+		StudentName: &name,
+		WorkUnit:    &workUnit,
+		Phone:       &phone,
+		Answers:     models.FormAnswers{"note": "\tcmd", "topics": []interface{}{"@AI", "Web"}, "age": float64(-1)},
+	}}
+
+	rows := services.BuildAttendanceExport(schema, attendances)
+
+	row := rows[1]
+	assert.Equal(t, "'=HYPERLINK(\"http://evil\")", row[3])
+	assert.Equal(t, "'+84901234567", row[5])
+	assert.Equal(t, "'-2+3", row[6])
+	assert.Equal(t, []string{"'\tcmd", "'@AI; Web", "-1"}, row[len(row)-3:])
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"errors"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestBuildAttendanceMarks_LastMarkWins(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	session := &models.AttendanceSession{ID: 1, ClassID: uintPtr(10)}
+	students := []models.Student{
+		{ID: 1, Email: stringPtr(" An@Example.com ")},
+		{ID: 2},
+	}
+	marks := []models.AttendanceMark{
+		{StudentID: 1, Status: models.AttendanceStatusPresent},
+		{StudentID: 2, Status: models.AttendanceStatusLate},
+		{StudentID: 1, Status: models.AttendanceStatusAbsent, Note: stringPtr("Left early")},
+	}
+
+	attendances, err := services.BuildAttendanceMarks(session, students, marks, 7, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, attendances, 2)
+	assert.Equal(t, uint(1), *attendances[0].StudentID)
+	assert.Equal(t, models.AttendanceStatusAbsent, *attendances[0].Status)
+	assert.Equal(t, "Left early", *attendances[0].Note)
+	assert.Nil(t, attendances[0].CheckedInAt)
+	assert.Equal(t, "an@example.com", *attendances[0].EmailNormalized)
+	assert.Equal(t, uint(7), *attendances[0].MarkedByUserID)
+
+	assert.Equal(t, uint(2), *attendances[1].StudentID)
+	assert.Equal(t, now, *attendances[1].CheckedInAt)
+	assert.Nil(t, attendances[1].EmailNormalized)
+}
+
+func TestBuildAttendanceMarks_StudentNotInClass(t *testing.T) {
+	session := &models.AttendanceSession{ID: 1, ClassID: uintPtr(10)}
+	students := []models.Student{{ID: 1}}
+	marks := []models.AttendanceMark{
+		{StudentID: 1, Status: models.AttendanceStatusPresent},
+		{StudentID: 9, Status: models.AttendanceStatusPresent},
+	}
+
+	attendances, err := services.BuildAttendanceMarks(session, students, marks, 7, time.Now())
+
+	assert.True(t, errors.Is(err, services.ErrStudentNotInClass))
+	assert.Contains(t, err.Error(), "9")
+	assert.Nil(t, attendances)
+}
+
+func TestApplyMark_UpdatesExistingAttendance(t *testing.T) {
+	checkedInAt := time.Date(2025, 3, 10, 7, 55, 0, 0, time.UTC)
+	markedAt := checkedInAt.Add(time.Hour)
+	present := models.AttendanceStatusPresent
+	late := models.AttendanceStatusLate
+	needsReview := true
+	existing := &models.Attendance{ID: 100, CheckedInAt: &checkedInAt, Status: &present, NeedsReview: &needsReview}
+
+	reviewed := false
+	manual := models.MatchedByManual
+	existing.ApplyMark(&models.Attendance{
+		StudentID:   uintPtr(1),
+		Status:      &late,
+		MatchedBy:   &manual,
+		NeedsReview: &reviewed,
+		CheckedInAt: &markedAt,
+		MarkedAt:    &markedAt,
+	})
+
+	assert.Equal(t, uint(100), existing.ID)
+	assert.Equal(t, uint(1), *existing.StudentID)
+	assert.Equal(t, models.AttendanceStatusLate, *existing.Status)
+	assert.False(t, *existing.NeedsReview)
+	assert.Equal(t, checkedInAt, *existing.CheckedInAt) // keeps the real check-in time
+	assert.Equal(t, markedAt, *existing.MarkedAt)
+}
+
+func TestApplyMark_AbsentClearsCheckin(t *testing.T) {
+	checkedInAt := time.Date(2025, 3, 10, 7, 55, 0, 0, time.UTC)
+	checkedOutAt := checkedInAt.Add(90 * time.Minute)
+	duration := 90
+	present := models.AttendanceStatusPresent
+	existing := &models.Attendance{
+		ID:              100,
+		StudentID:       uintPtr(1),
+		Status:          &present,
+		CheckedInAt:     &checkedInAt,
+		CheckedOutAt:    &checkedOutAt,
+		DurationMinutes: &duration,
+	}
+
+	for _, status := range []string{models.AttendanceStatusAbsent, models.AttendanceStatusExcused} {
+		status := status
+		existing.ApplyMark(&models.Attendance{StudentID: uintPtr(1), Status: &status})
+
+		assert.Equal(t, status, *existing.Status)
+		assert.Nil(t, existing.CheckedInAt)
+		assert.Nil(t, existing.CheckedOutAt)
+		assert.Nil(t, existing.DurationMinutes)
+	}
+}
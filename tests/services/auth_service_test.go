@@ -0,0 +1,72 @@
+package services
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var testJWTConfig = config.JWTConfig{
+	Secret:     []byte("test-secret"),
+	Issuer:     "hello-gin-test",
+	AccessTTL:  15 * time.Minute,
+	RefreshTTL: time.Hour,
+}
+
+func signTestToken(t *testing.T, secret []byte, tokenType string, expiresAt time.Time) string {
+	claims := models.AuthClaims{
+		UserID:    7,
+		Username:  "teacher01",
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testJWTConfig.Issuer,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestParseAccessToken_Valid(t *testing.T) {
+	authService := services.NewAuthService(nil, testJWTConfig)
+	token := signTestToken(t, testJWTConfig.Secret, models.TokenTypeAccess, time.Now().Add(time.Minute))
+
+	claims, err := authService.ParseAccessToken(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), claims.UserID)
+	assert.Equal(t, "teacher01", claims.Username)
+}
+
+func TestParseAccessToken_RejectsRefreshToken(t *testing.T) {
+	authService := services.NewAuthService(nil, testJWTConfig)
+	token := signTestToken(t, testJWTConfig.Secret, models.TokenTypeRefresh, time.Now().Add(time.Minute))
+
+	_, err := authService.ParseAccessToken(token)
+
+	assert.ErrorIs(t, err, services.ErrInvalidToken)
+}
+
+func TestParseAccessToken_Expired(t *testing.T) {
+	authService := services.NewAuthService(nil, testJWTConfig)
+	token := signTestToken(t, testJWTConfig.Secret, models.TokenTypeAccess, time.Now().Add(-time.Minute))
+
+	_, err := authService.ParseAccessToken(token)
+
+	assert.ErrorIs(t, err, services.ErrInvalidToken)
+}
+
+func TestParseAccessToken_WrongSecret(t *testing.T) {
+	authService := services.NewAuthService(nil, testJWTConfig)
+	token := signTestToken(t, []byte("other-secret"), models.TokenTypeAccess, time.Now().Add(time.Minute))
+
+	_, err := authService.ParseAccessToken(token)
+
+	assert.ErrorIs(t, err, services.ErrInvalidToken)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleCalendarSession() models.AttendanceSession {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	closes := start.Add(90 * time.Minute)
+	eventName, className, location := "Go Workshop", "K22, Group A", "Room A101; Building B"
+	return models.AttendanceSession{
+		ID:          42,
+		UpdatedAt:   time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+		SessionDate: &start,
+		ClosesAt:    &closes,
+		Location:    &location,
+		Event:       &models.Event{EventName: &eventName},
+		Class:       &models.Class{ClassName: &className},
+	}
+}
+
+func TestCalendarFeedToken(t *testing.T) {
+	secret := []byte("test-secret")
+	token := services.CalendarFeedToken(secret, services.CalendarScopeEvents, 1)
+
+	assert.NoError(t, services.ValidateCalendarFeedToken(secret, services.CalendarScopeEvents, 1, token))
+	assert.ErrorIs(t, services.ValidateCalendarFeedToken(secret, services.CalendarScopeEvents, 2, token), services.ErrInvalidCalendarToken)
+	assert.ErrorIs(t, services.ValidateCalendarFeedToken(secret, services.CalendarScopeClasses, 1, token), services.ErrInvalidCalendarToken)
+	assert.ErrorIs(t, services.ValidateCalendarFeedToken([]byte("other"), services.CalendarScopeEvents, 1, token), services.ErrInvalidCalendarToken)
+	assert.ErrorIs(t, services.ValidateCalendarFeedToken(secret, services.CalendarScopeEvents, 1, ""), services.ErrInvalidCalendarToken)
+}
+
+func TestCalendarFeedLink(t *testing.T) {
+	link := services.CalendarFeedLink(services.CalendarScopeTeachers, 3)
+
+	assert.Contains(t, link.URL, "/teachers/3.ics?token=")
+	assert.True(t, strings.HasPrefix(link.WebcalURL, "webcal://"))
+}
+
+func TestBuildCalendar(t *testing.T) {
+	undated := models.AttendanceSession{ID: 43}
+
+	calendar := string(services.BuildCalendar("Go Workshop", []models.AttendanceSession{sampleCalendarSession(), undated}, "attendance.example.com"))
+
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VEVENT"))
+	assert.Contains(t, calendar, "UID:session-42@attendance.example.com\r\n")
+	assert.Contains(t, calendar, "DTSTART:20250901T010000Z\r\n")
+	assert.Contains(t, calendar, "DTEND:20250901T023000Z\r\n")
+	assert.Contains(t, calendar, "DTSTAMP:20250820T100000Z\r\n")
+	assert.Contains(t, calendar, `SUMMARY:Go Workshop – K22\, Group A`)
+	assert.Contains(t, calendar, `LOCATION:Room A101\; Building B`)
+	assert.Contains(t, calendar, "URL:")
+	assert.Contains(t, calendar, "/42\r\n")
+}
+
+func TestBuildCalendar_Room(t *testing.T) {
+	session := sampleCalendarSession()
+	building, latitude, longitude := "Building B", 10.7769, 106.7009
+	session.Room = &models.Room{Name: "A101", Building: &building, Latitude: &latitude, Longitude: &longitude}
+	session.Location = nil
+
+	calendar := string(services.BuildCalendar("Go Workshop", []models.AttendanceSession{session}, "example.com"))
+
+	assert.Contains(t, calendar, `LOCATION:A101\, Building B`+"\r\n")
+	assert.Contains(t, calendar, "GEO:10.776900;106.700900\r\n")
+}
+
+func TestBuildCalendar_FoldsLongLines(t *testing.T) {
+	session := sampleCalendarSession()
+	long := strings.Repeat("Phòng hội thảo lớn, ", 10)
+	session.Location = &long
+
+	calendar := string(services.BuildCalendar("Go Workshop", []models.AttendanceSession{session}, "example.com"))
+
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	assert.Contains(t, unfolded, `LOCATION:`+strings.ReplaceAll(long, ",", `\,`))
+}
+
+func TestSessionTimes(t *testing.T) {
+	session := sampleCalendarSession()
+
+	_, end := services.SessionTimes(session)
+	assert.Equal(t, session.SessionDate.Add(90*time.Minute), end)
+
+	duration := 120
+	session.DurationMinutes = &duration
+	_, end = services.SessionTimes(session)
+	assert.Equal(t, session.SessionDate.Add(120*time.Minute), end)
+	session.DurationMinutes = nil
+
+	session.ClosesAt = nil
+	minutes := 45
+	session.MinDurationMinutes = &minutes
+	_, end = services.SessionTimes(session)
+	assert.Equal(t, session.SessionDate.Add(45*time.Minute), end)
+
+	session.MinDurationMinutes = nil
+	_, end = services.SessionTimes(session)
+	assert.Equal(t, session.SessionDate.Add(time.Hour), end)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"bytes"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func certificateAttendance(id uint, studentID *uint, email string, day time.Time, minutes int, partial bool) models.Attendance {
+	name, normalized := "Nguyen Van A", email
+	checkedIn := day
+	return models.Attendance{
+		ID:              id,
+		StudentID:       studentID,
+		StudentName:     &name,
+		Email:           &email,
+		EmailNormalized: &normalized,
+		CheckedInAt:     &checkedIn,
+		DurationMinutes: &minutes,
+		IsPartial:       &partial,
+	}
+}
+
+func TestCollectCertificateAttendees_GroupsSessionsPerPerson(t *testing.T) {
+	day1 := time.Date(2025, 8, 20, 8, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	attendances := []models.Attendance{
+		certificateAttendance(2, uintPtr(7), "a@example.com", day2, 150, false),
+		certificateAttendance(1, uintPtr(7), "a@example.com", day1, 240, false),
+		certificateAttendance(3, nil, "b@example.com", day1, 60, true),
+		{ID: 4, StudentID: uintPtr(9)}, // Marked absent
+	}
+
+	attendees := services.CollectCertificateAttendees(attendances)
+
+	assert.Len(t, attendees, 2)
+	student := attendees[0]
+	if student.Key != "student:7" {
+		student = attendees[1]
+	}
+	assert.Equal(t, "student:7", student.Key)
+	assert.Equal(t, uint(1), student.AttendanceID)
+	assert.Equal(t, 390, student.TotalMinutes)
+	assert.Len(t, student.Dates, 2)
+	assert.Equal(t, "20/08/2025, 21/08/2025", services.FormatCertificateDates(student.Dates))
+}
+
+func TestIsEligibleForCertificate(t *testing.T) {
+	minHours := 4.0
+	event := &models.Event{CertificateMinHours: &minHours}
+	dates := []time.Time{time.Now()}
+
+	assert.True(t, services.IsEligibleForCertificate(event, models.CertificateAttendee{Dates: dates, TotalMinutes: 240}))
+	assert.False(t, services.IsEligibleForCertificate(event, models.CertificateAttendee{Dates: dates, TotalMinutes: 200}))
+	assert.False(t, services.IsEligibleForCertificate(event, models.CertificateAttendee{Dates: dates, TotalMinutes: 300, PartialSessions: 1}))
+	assert.True(t, services.IsEligibleForCertificate(&models.Event{}, models.CertificateAttendee{Dates: dates}))
+	assert.False(t, services.IsEligibleForCertificate(&models.Event{}, models.CertificateAttendee{}))
+}
+
+func TestValidateCertificateTemplate(t *testing.T) {
+	assert.NoError(t, services.ValidateCertificateTemplate("{name} attended {event} on {dates} ({hours} hours)"))
+	assert.ErrorIs(t, services.ValidateCertificateTemplate("{name} attended {venue}"), services.ErrInvalidCertificateTemplate)
+}
+
+func TestRenderCertificateText(t *testing.T) {
+	eventName := "Workshop AI"
+	template := "{name} attended {event} on {dates}, {hours} hours"
+	event := &models.Event{EventName: &eventName, CertificateTemplate: &template}
+	certificate := &models.Certificate{RecipientName: "Nguyễn Văn A", Dates: "20/08/2025", Hours: 6.5}
+
+	assert.Equal(t, "Nguyễn Văn A attended Workshop AI on 20/08/2025, 6.5 hours", services.RenderCertificateText(event, certificate))
+	assert.Equal(t, "has attended Workshop AI on 20/08/2025, for a total of 6.5 hours.",
+		services.RenderCertificateText(&models.Event{EventName: &eventName}, certificate))
+}
+
+func TestFormatCertificateDates_LongRange(t *testing.T) {
+	start := time.Date(2025, 8, 18, 8, 0, 0, 0, time.Local)
+	dates := []time.Time{start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2), start.AddDate(0, 0, 3)}
+
+	assert.Equal(t, "18/08/2025 – 21/08/2025", services.FormatCertificateDates(dates))
+}
+
+func TestCertificateHours(t *testing.T) {
+	assert.Equal(t, 6.5, services.CertificateHours(390))
+	assert.Equal(t, 1.3, services.CertificateHours(77))
+}
+
+func TestRenderCertificatePDF(t *testing.T) {
+	eventName := "Hội thảo AI"
+	certificate := &models.Certificate{
+		RecipientName: "Nguyễn Văn A",
+		Dates:         "20/08/2025",
+		Hours:         6.5,
+		Code:          "7KQ2M-XR4PD",
+		IssuedAt:      time.Date(2025, 8, 21, 9, 0, 0, 0, time.UTC),
+	}
+
+	pdf, err := services.RenderCertificatePDF(&models.Event{EventName: &eventName}, certificate, "http://localhost:8080/api/certificates/verify/7KQ2M-XR4PD")
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+}
+
+func TestCertificateFileName(t *testing.T) {
+	certificate := &models.Certificate{RecipientName: "Nguyễn Văn A / K65", Code: "7KQ2M-XR4PD"}
+
+	assert.Equal(t, "Nguyễn_Văn_A__K65-7KQ2M-XR4PD.pdf", services.CertificateFileName(certificate))
+}
+
+func TestUniqueFileName_SuffixesDuplicates(t *testing.T) {
+	taken := make(map[string]bool)
+
+	assert.Equal(t, "A-CODE.pdf", services.UniqueFileName("A-CODE.pdf", taken))
+	assert.Equal(t, "A-CODE-2.pdf", services.UniqueFileName("A-CODE.pdf", taken))
+	assert.Equal(t, "A-CODE-3.pdf", services.UniqueFileName("A-CODE.pdf", taken))
+	assert.Equal(t, "B-CODE.pdf", services.UniqueFileName("B-CODE.pdf", taken))
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var checkinSecret = []byte("checkin-secret")
+
+func TestCheckinToken_ValidWithinStep(t *testing.T) {
+	now := time.Unix(1_700_000_010, 0)
+	token, expiresAt := services.GenerateCheckinToken(checkinSecret, 1, 30*time.Second, now)
+
+	assert.Equal(t, time.Unix(1_700_000_010, 0).Truncate(30*time.Second).Add(30*time.Second).Unix(), expiresAt.Unix())
+	assert.NoError(t, services.ValidateCheckinToken(checkinSecret, 1, token, 30*time.Second, 5*time.Second, now.Add(10*time.Second)))
+}
+
+func TestCheckinToken_AcceptedWithinSkew(t *testing.T) {
+	now := time.Unix(1_700_000_010, 0)
+	token, expiresAt := services.GenerateCheckinToken(checkinSecret, 1, 30*time.Second, now)
+
+	err := services.ValidateCheckinToken(checkinSecret, 1, token, 30*time.Second, 5*time.Second, expiresAt.Add(4*time.Second))
+
+	assert.NoError(t, err)
+}
+
+func TestCheckinToken_ExpiredAfterSkew(t *testing.T) {
+	now := time.Unix(1_700_000_010, 0)
+	token, expiresAt := services.GenerateCheckinToken(checkinSecret, 1, 30*time.Second, now)
+
+	err := services.ValidateCheckinToken(checkinSecret, 1, token, 30*time.Second, 5*time.Second, expiresAt.Add(6*time.Second))
+
+	assert.ErrorIs(t, err, services.ErrExpiredCheckinToken)
+}
+
+func TestCheckinToken_RejectsOtherSession(t *testing.T) {
+	now := time.Unix(1_700_000_010, 0)
+	token, _ := services.GenerateCheckinToken(checkinSecret, 1, 30*time.Second, now)
+
+	err := services.ValidateCheckinToken(checkinSecret, 2, token, 30*time.Second, 5*time.Second, now)
+
+	assert.ErrorIs(t, err, services.ErrInvalidCheckinToken)
+}
+
+func TestCheckinToken_RejectsMalformed(t *testing.T) {
+	now := time.Unix(1_700_000_010, 0)
+
+	for _, token := range []string{"", "abc", "123.", "x.y"} {
+		err := services.ValidateCheckinToken(checkinSecret, 1, token, 30*time.Second, 5*time.Second, now)
+		assert.ErrorIs(t, err, services.ErrInvalidCheckinToken, token)
+	}
+}
+
+func TestCheckinTokenSettings_EventOverrides(t *testing.T) {
+	ttlSeconds, skewSeconds := 60, 0
+	event := &models.Event{CheckinTokenTTLSeconds: &ttlSeconds, CheckinTokenSkewSeconds: &skewSeconds}
+
+	ttl, skew := services.CheckinTokenSettings(event)
+
+	assert.Equal(t, 60*time.Second, ttl)
+	assert.Equal(t, time.Duration(0), skew)
+}
+
+func TestCheckinTokenSettings_DefaultSkewCanBeZero(t *testing.T) {
+	t.Setenv("CHECKIN_TOKEN_SKEW", "0s")
+
+	_, skew := services.CheckinTokenSettings(&models.Event{})
+
+	assert.Equal(t, time.Duration(0), skew)
+}
+
+func TestCheckinTokenSettings_NegativeDefaultSkewIgnored(t *testing.T) {
+	t.Setenv("CHECKIN_TOKEN_SKEW", "-5s")
+
+	_, skew := services.CheckinTokenSettings(&models.Event{})
+
+	assert.Equal(t, 5*time.Second, skew)
+}
+
+func TestCheckinFormTicket_ValidWithinTTL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ticket := services.GenerateCheckinFormTicket(checkinSecret, 1, "nonce", now)
+
+	nonce, err := services.ValidateCheckinFormTicket(checkinSecret, 1, ticket, time.Minute, now.Add(50*time.Second))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "nonce", nonce)
+}
+
+func TestCheckinFormTicket_Expired(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ticket := services.GenerateCheckinFormTicket(checkinSecret, 1, "nonce", now)
+
+	_, err := services.ValidateCheckinFormTicket(checkinSecret, 1, ticket, time.Minute, now.Add(61*time.Second))
+
+	assert.ErrorIs(t, err, services.ErrExpiredCheckinToken)
+}
+
+func TestCheckinFormTicket_RejectsOtherSession(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ticket := services.GenerateCheckinFormTicket(checkinSecret, 1, "nonce", now)
+
+	_, err := services.ValidateCheckinFormTicket(checkinSecret, 2, ticket, time.Minute, now)
+
+	assert.ErrorIs(t, err, services.ErrInvalidCheckinToken)
+}
+
+func TestCheckinFormTicket_RejectsSwappedNonce(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ticket := services.GenerateCheckinFormTicket(checkinSecret, 1, "nonce", now)
+	swapped := strings.Replace(ticket, ".nonce.", ".other.", 1)
+
+	_, err := services.ValidateCheckinFormTicket(checkinSecret, 1, swapped, time.Minute, now)
+
+	assert.ErrorIs(t, err, services.ErrInvalidCheckinToken)
+}
+
+func TestValidateSessionCheckinToken_RejectsFormTicket(t *testing.T) {
+	now := time.Now()
+	session := &models.AttendanceSession{ID: 1}
+	ticket := services.GenerateCheckinFormTicket(config.CheckinTokenSecret(), 1, "nonce", now)
+
+	err := services.ValidateSessionCheckinToken(session, ticket, now)
+
+	assert.ErrorIs(t, err, services.ErrInvalidCheckinToken)
+}
+
+func TestCheckinFormTicket_RejectsMalformed(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	rotating, _ := services.GenerateCheckinToken(checkinSecret, 1, 30*time.Second, now)
+
+	for _, ticket := range []string{"", "form.", "form.abc.def", "form.1700000000", "form.1700000000..sig", rotating} {
+		_, err := services.ValidateCheckinFormTicket(checkinSecret, 1, ticket, time.Minute, now)
+		assert.ErrorIs(t, err, services.ErrInvalidCheckinToken, ticket)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleWindowSession() *models.AttendanceSession {
+	opensAt := time.Date(2025, 8, 20, 8, 0, 0, 0, time.UTC)
+	lateAfter := opensAt.Add(15 * time.Minute)
+	closesAt := opensAt.Add(2 * time.Hour)
+	return &models.AttendanceSession{OpensAt: &opensAt, LateAfter: &lateAfter, ClosesAt: &closesAt}
+}
+
+func TestEvaluateCheckinWindow(t *testing.T) {
+	session := sampleWindowSession()
+
+	cases := []struct {
+		name   string
+		at     time.Time
+		status string
+		err    error
+	}{
+		{"before opening", session.OpensAt.Add(-time.Minute), models.AttendanceStatusRejected, services.ErrSessionNotOpen},
+		{"on time", session.OpensAt.Add(5 * time.Minute), models.AttendanceStatusPresent, nil},
+		{"exactly at late threshold", *session.LateAfter, models.AttendanceStatusPresent, nil},
+		{"late", session.LateAfter.Add(time.Minute), models.AttendanceStatusLate, nil},
+		{"after closing", session.ClosesAt.Add(time.Second), models.AttendanceStatusRejected, services.ErrSessionClosed},
+	}
+
+	for _, tc := range cases {
+		status, err := services.EvaluateCheckinWindow(session, tc.at)
+		assert.Equal(t, tc.status, status, tc.name)
+		assert.ErrorIs(t, err, tc.err, tc.name)
+	}
+}
+
+func TestEvaluateCheckinWindow_NoBounds(t *testing.T) {
+	status, err := services.EvaluateCheckinWindow(&models.AttendanceSession{}, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AttendanceStatusPresent, status)
+}
+
+func TestValidateCheckinWindow_OutOfOrder(t *testing.T) {
+	session := sampleWindowSession()
+	lateAfter := session.ClosesAt.Add(time.Minute)
+	session.LateAfter = &lateAfter
+
+	assert.ErrorIs(t, services.ValidateCheckinWindow(session), services.ErrInvalidCheckinWindow)
+	assert.NoError(t, services.ValidateCheckinWindow(sampleWindowSession()))
+}
+
+func TestSummarizeAttendanceStatuses(t *testing.T) {
+	late := models.AttendanceStatusLate
+	attendances := []models.Attendance{{}, {Status: &late}, {Status: &late}}
+
+	summary := services.SummarizeAttendanceStatuses(attendances)
+
+	assert.Equal(t, 1, summary[models.AttendanceStatusPresent])
+	assert.Equal(t, 2, summary[models.AttendanceStatusLate])
+	assert.Len(t, services.FilterAttendancesByStatus(attendances, late), 2)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCheckout(t *testing.T) {
+	checkedIn := time.Date(2025, 8, 20, 8, 0, 0, 0, time.UTC)
+	minDuration := 60
+	session := &models.AttendanceSession{MinDurationMinutes: &minDuration}
+
+	t.Run("full attendance", func(t *testing.T) {
+		attendance := &models.Attendance{CheckedInAt: &checkedIn}
+
+		err := services.ApplyCheckout(attendance, session, checkedIn.Add(90*time.Minute))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, *attendance.DurationMinutes)
+		assert.False(t, *attendance.IsPartial)
+		assert.Equal(t, checkedIn.Add(90*time.Minute), *attendance.CheckedOutAt)
+	})
+
+	t.Run("left early", func(t *testing.T) {
+		attendance := &models.Attendance{CheckedInAt: &checkedIn}
+
+		err := services.ApplyCheckout(attendance, session, checkedIn.Add(45*time.Minute))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 45, *attendance.DurationMinutes)
+		assert.True(t, *attendance.IsPartial)
+	})
+
+	t.Run("no minimum duration", func(t *testing.T) {
+		attendance := &models.Attendance{CheckedInAt: &checkedIn}
+
+		err := services.ApplyCheckout(attendance, &models.AttendanceSession{}, checkedIn.Add(5*time.Minute))
+
+		assert.NoError(t, err)
+		assert.False(t, *attendance.IsPartial)
+	})
+
+	t.Run("clock before check-in", func(t *testing.T) {
+		attendance := &models.Attendance{CheckedInAt: &checkedIn}
+
+		err := services.ApplyCheckout(attendance, session, checkedIn.Add(-time.Minute))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, *attendance.DurationMinutes)
+	})
+
+	t.Run("never checked in", func(t *testing.T) {
+		attendance := &models.Attendance{}
+
+		err := services.ApplyCheckout(attendance, session, checkedIn)
+
+		assert.ErrorIs(t, err, services.ErrNotCheckedIn)
+		assert.Nil(t, attendance.CheckedOutAt)
+	})
+}
+
+func TestBuildAttendanceReport(t *testing.T) {
+	checkedIn := time.Date(2025, 8, 20, 8, 0, 0, 0, time.UTC)
+	alice, bob := "Alice", "Bob"
+	bobEmail := "bob@example.com"
+	sixty, thirty := 60, 30
+	partial, full := true, false
+	absent := models.AttendanceStatusAbsent
+
+	attendances := []models.Attendance{
+		{ID: 1, StudentID: uintPtr(1), StudentName: &alice, CheckedInAt: &checkedIn, DurationMinutes: &sixty, IsPartial: &full},
+		{ID: 2, StudentID: uintPtr(1), StudentName: &alice, CheckedInAt: &checkedIn, DurationMinutes: &thirty, IsPartial: &partial},
+		{ID: 3, StudentID: uintPtr(1), StudentName: &alice, CheckedInAt: &checkedIn},
+		{ID: 4, StudentName: &bob, Email: &bobEmail, EmailNormalized: &bobEmail, CheckedInAt: &checkedIn, DurationMinutes: &sixty, IsPartial: &full},
+		{ID: 5, StudentName: &bob, Email: &bobEmail, EmailNormalized: &bobEmail, CheckedInAt: &checkedIn, DurationMinutes: &thirty, IsPartial: &full},
+		{ID: 6, StudentID: uintPtr(2), Status: &absent},
+	}
+
+	report := services.BuildAttendanceReport(attendances)
+
+	assert.Len(t, report, 2)
+	assert.Equal(t, "Alice", report[0].StudentName)
+	assert.Equal(t, 3, report[0].SessionsAttended)
+	assert.Equal(t, 90, report[0].TotalMinutes)
+	assert.Equal(t, 1, report[0].PartialSessions)
+	assert.Equal(t, 1, report[0].NotCheckedOut)
+	assert.Equal(t, "Bob", report[1].StudentName)
+	assert.Equal(t, 2, report[1].SessionsAttended)
+	assert.Equal(t, 90, report[1].TotalMinutes)
+	assert.Equal(t, 0, report[1].PartialSessions)
+}
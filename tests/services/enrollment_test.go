@@ -0,0 +1,72 @@
+package services
+
+import (
+	"hello-gin/config"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnrollmentDate(t *testing.T) {
+	location := config.DefaultLocation()
+	now := time.Date(2025, 10, 15, 20, 30, 0, 0, time.UTC)
+
+	// Defaults to the start of today in the default time zone
+	today, err := services.ParseEnrollmentDate(nil, now)
+	assert.NoError(t, err)
+	year, month, day := now.In(location).Date()
+	assert.True(t, today.Equal(time.Date(year, month, day, 0, 0, 0, 0, location)))
+
+	date := "2025-09-01"
+	parsed, err := services.ParseEnrollmentDate(&date, now)
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2025, 9, 1, 0, 0, 0, 0, location)))
+
+	timestamp := "2025-09-01T08:00:00+07:00"
+	parsed, err = services.ParseEnrollmentDate(&timestamp, now)
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)))
+
+	future := "2025-11-01"
+	_, err = services.ParseEnrollmentDate(&future, now)
+	assert.ErrorIs(t, err, services.ErrInvalidEnrollmentDate)
+
+	invalid := "01/09/2025"
+	_, err = services.ParseEnrollmentDate(&invalid, now)
+	assert.ErrorIs(t, err, services.ErrInvalidEnrollmentDate)
+}
+
+func TestEnrollmentActiveOn(t *testing.T) {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	enrollment := models.Enrollment{EnrolledFrom: from, EnrolledTo: &to}
+
+	assert.False(t, enrollment.ActiveOn(from.Add(-time.Minute)))
+	assert.True(t, enrollment.ActiveOn(from))
+	assert.True(t, enrollment.ActiveOn(to.Add(-time.Minute)))
+	// EnrolledTo is the first day in the new class
+	assert.False(t, enrollment.ActiveOn(to))
+
+	enrollment.EnrolledTo = nil
+	assert.True(t, enrollment.ActiveOn(to.AddDate(1, 0, 0)))
+}
+
+func TestIsEnrollmentRole(t *testing.T) {
+	assert.True(t, models.IsEnrollmentRole(models.EnrollmentRoleStudent))
+	assert.True(t, models.IsEnrollmentRole(models.EnrollmentRoleAuditor))
+	assert.True(t, models.IsEnrollmentRole(models.EnrollmentRoleAssistant))
+	assert.False(t, models.IsEnrollmentRole("teacher"))
+}
+
+func TestRosterDate(t *testing.T) {
+	sessionDate := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	session := &models.AttendanceSession{SessionDate: &sessionDate}
+	assert.Equal(t, sessionDate, services.RosterDate(session))
+
+	before := time.Now()
+	undated := services.RosterDate(&models.AttendanceSession{})
+	assert.False(t, undated.Before(before))
+}
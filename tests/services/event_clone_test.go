@@ -0,0 +1,102 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleCloneEvent() *models.Event {
+	name, location := "Go Workshop", "Room A101"
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	end := start.Add(14 * 24 * time.Hour)
+	second := start.Add(7 * 24 * time.Hour)
+	opens := second.Add(-15 * time.Minute)
+	return &models.Event{
+		ID:         1,
+		EventName:  &name,
+		StartDate:  &start,
+		EndDate:    &end,
+		Status:     models.EventStatusClosed,
+		FormSchema: models.FormSchema{{Key: "faculty", Label: "Faculty", Type: models.FormFieldText}},
+		Sessions: []models.AttendanceSession{
+			{ID: 10, EventID: uintPtr(1), ClassID: uintPtr(2), TeacherID: uintPtr(3), SessionDate: &start, Location: &location, SeriesID: uintPtr(5),
+				Attendances: []models.Attendance{{ID: 100}}},
+			{ID: 11, EventID: uintPtr(1), ClassID: uintPtr(2), SessionDate: &second, OpensAt: &opens},
+		},
+	}
+}
+
+func TestBuildEventClone_StartDate(t *testing.T) {
+	event := sampleCloneEvent()
+	newStart := time.Date(2026, 2, 2, 1, 0, 0, 0, time.UTC)
+
+	clone, err := services.BuildEventClone(event, &models.CloneEventRequest{StartDate: &newStart})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Go Workshop (copy)", *clone.EventName)
+	assert.Equal(t, models.EventStatusDraft, clone.Status)
+	assert.Equal(t, uint(0), clone.ID)
+	assert.True(t, clone.StartDate.Equal(newStart))
+	assert.True(t, clone.EndDate.Equal(newStart.Add(14*24*time.Hour)))
+	assert.Equal(t, event.FormSchema, clone.FormSchema)
+
+	require.Len(t, clone.Sessions, 2)
+	first, second := clone.Sessions[0], clone.Sessions[1]
+	assert.Equal(t, uint(0), first.ID)
+	assert.Nil(t, first.EventID)
+	assert.Nil(t, first.SeriesID)
+	assert.Empty(t, first.Attendances)
+	assert.Equal(t, uint(2), *first.ClassID)
+	assert.Equal(t, uint(3), *first.TeacherID)
+	assert.Equal(t, "Room A101", *first.Location)
+	assert.True(t, first.SessionDate.Equal(newStart))
+	assert.True(t, second.SessionDate.Equal(newStart.Add(7*24*time.Hour)))
+	assert.True(t, second.OpensAt.Equal(newStart.Add(7*24*time.Hour-15*time.Minute)))
+	assert.Nil(t, second.ClosesAt)
+}
+
+func TestBuildEventClone_OffsetDays(t *testing.T) {
+	event := sampleCloneEvent()
+	days := 182
+	name := "Go Workshop - Spring"
+
+	clone, err := services.BuildEventClone(event, &models.CloneEventRequest{OffsetDays: &days, EventName: &name})
+
+	require.NoError(t, err)
+	assert.Equal(t, name, *clone.EventName)
+	assert.True(t, clone.StartDate.Equal(event.StartDate.AddDate(0, 0, days)))
+	assert.True(t, clone.Sessions[1].SessionDate.Equal(event.Sessions[1].SessionDate.AddDate(0, 0, days)))
+}
+
+func TestBuildEventClone_AlignsFirstSessionWithoutStartDate(t *testing.T) {
+	event := sampleCloneEvent()
+	event.StartDate = nil
+	newStart := time.Date(2026, 2, 2, 1, 0, 0, 0, time.UTC)
+
+	clone, err := services.BuildEventClone(event, &models.CloneEventRequest{StartDate: &newStart})
+
+	require.NoError(t, err)
+	assert.Nil(t, clone.StartDate)
+	assert.True(t, clone.Sessions[0].SessionDate.Equal(newStart))
+}
+
+func TestBuildEventClone_InvalidRequest(t *testing.T) {
+	event := sampleCloneEvent()
+	days := 7
+	start := time.Now()
+
+	_, err := services.BuildEventClone(event, &models.CloneEventRequest{})
+	assert.ErrorIs(t, err, services.ErrInvalidCloneRequest)
+
+	_, err = services.BuildEventClone(event, &models.CloneEventRequest{OffsetDays: &days, StartDate: &start})
+	assert.ErrorIs(t, err, services.ErrInvalidCloneRequest)
+
+	undated := &models.Event{Sessions: []models.AttendanceSession{{ID: 1}}}
+	_, err = services.BuildEventClone(undated, &models.CloneEventRequest{StartDate: &start})
+	assert.ErrorIs(t, err, services.ErrInvalidCloneRequest)
+}
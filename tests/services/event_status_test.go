@@ -0,0 +1,159 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEventTransition(t *testing.T) {
+	cases := []struct {
+		from, to string
+		err      error
+	}{
+		{models.EventStatusDraft, models.EventStatusPublished, nil},
+		{models.EventStatusDraft, models.EventStatusArchived, nil},
+		{models.EventStatusPublished, models.EventStatusDraft, nil},
+		{models.EventStatusPublished, models.EventStatusOngoing, nil},
+		{models.EventStatusOngoing, models.EventStatusClosed, nil},
+		{models.EventStatusClosed, models.EventStatusOngoing, nil},
+		{models.EventStatusClosed, models.EventStatusArchived, nil},
+		{models.EventStatusDraft, models.EventStatusOngoing, services.ErrInvalidEventTransition},
+		{models.EventStatusOngoing, models.EventStatusDraft, services.ErrInvalidEventTransition},
+		{models.EventStatusOngoing, models.EventStatusOngoing, services.ErrInvalidEventTransition},
+		{models.EventStatusArchived, models.EventStatusClosed, services.ErrInvalidEventTransition},
+		{models.EventStatusDraft, "cancelled", services.ErrInvalidEventStatus},
+	}
+
+	for _, tc := range cases {
+		err := services.ValidateEventTransition(tc.from, tc.to)
+		if tc.err == nil {
+			assert.NoError(t, err, tc.from+" -> "+tc.to)
+		} else {
+			assert.ErrorIs(t, err, tc.err, tc.from+" -> "+tc.to)
+		}
+	}
+}
+
+func TestEnsureEventOngoing(t *testing.T) {
+	assert.NoError(t, services.EnsureEventOngoing(&models.Event{Status: models.EventStatusOngoing}))
+	assert.NoError(t, services.EnsureEventOngoing(nil))
+	for _, status := range []string{models.EventStatusDraft, models.EventStatusPublished, models.EventStatusClosed, models.EventStatusArchived} {
+		assert.ErrorIs(t, services.EnsureEventOngoing(&models.Event{Status: status}), services.ErrEventNotOngoing, status)
+	}
+}
+
+func TestEnsureEventAcceptsOfflineCheckins(t *testing.T) {
+	assert.NoError(t, services.EnsureEventAcceptsOfflineCheckins(&models.Event{Status: models.EventStatusOngoing}))
+	assert.NoError(t, services.EnsureEventAcceptsOfflineCheckins(&models.Event{Status: models.EventStatusClosed}))
+	assert.ErrorIs(t, services.EnsureEventAcceptsOfflineCheckins(&models.Event{Status: models.EventStatusPublished}), services.ErrEventNotOngoing)
+	assert.ErrorIs(t, services.EnsureEventAcceptsOfflineCheckins(&models.Event{Status: models.EventStatusArchived}), services.ErrEventNotOngoing)
+}
+
+func TestEventActiveSteps(t *testing.T) {
+	assert.Equal(t, []string{models.EventStatusPublished, models.EventStatusOngoing}, services.EventActiveSteps(models.EventStatusDraft, true))
+	assert.Equal(t, []string{models.EventStatusOngoing}, services.EventActiveSteps(models.EventStatusPublished, true))
+	assert.Equal(t, []string{models.EventStatusOngoing}, services.EventActiveSteps(models.EventStatusClosed, true))
+	assert.Empty(t, services.EventActiveSteps(models.EventStatusOngoing, true))
+	assert.Equal(t, []string{models.EventStatusClosed}, services.EventActiveSteps(models.EventStatusOngoing, false))
+	assert.Equal(t, []string{models.EventStatusClosed}, services.EventActiveSteps(models.EventStatusPublished, false))
+	assert.Empty(t, services.EventActiveSteps(models.EventStatusDraft, false))
+	assert.Empty(t, services.EventActiveSteps(models.EventStatusClosed, false))
+}
+
+func TestSetEventActive_PublishesDraftFirst(t *testing.T) {
+	// Setup
+	db, dbMock, err := tests.SetupMockDB()
+	assert.NoError(t, err)
+	service := services.NewEventService(repository.NewEventRepository(db))
+
+	dbMock.ExpectQuery(`SELECT \* FROM "events"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, models.EventStatusDraft))
+	for _, step := range [][2]string{
+		{models.EventStatusDraft, models.EventStatusPublished},
+		{models.EventStatusPublished, models.EventStatusOngoing},
+	} {
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(`UPDATE "events"`).
+			WithArgs(step[1], sqlmock.AnyArg(), sqlmock.AnyArg(), 1, step[0]).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectQuery(`INSERT INTO "event_status_logs"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		dbMock.ExpectCommit()
+	}
+
+	// Execute
+	event, err := service.SetEventActive(1, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.EventStatusOngoing, event.Status)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestEventRepositoryUpdate_LeavesStatus(t *testing.T) {
+	// Setup
+	db, dbMock, err := tests.SetupMockDB()
+	assert.NoError(t, err)
+	name := "Go Workshop"
+	event := &models.Event{ID: 1, EventName: &name, Status: models.EventStatusPublished}
+
+	// status and status_changed_at would follow end_date
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(`UPDATE "events" SET .*"end_date"=\$\d+,"checkin_token_ttl_seconds"=`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	// Execute
+	err = repository.NewEventRepository(db).Update(event)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestScheduledEventStatus(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC) // 08:00 in Asia/Ho_Chi_Minh
+	end := start.Add(8 * time.Hour)
+	publishedAt := start.Add(-24 * time.Hour)
+	reopenedAt := end.Add(time.Hour)
+	hcm, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+
+	cases := []struct {
+		name      string
+		status    string
+		changedAt *time.Time
+		now       time.Time
+		want      string
+	}{
+		{"published before start", models.EventStatusPublished, &publishedAt, start.Add(-time.Minute), ""},
+		{"published at start", models.EventStatusPublished, &publishedAt, start, models.EventStatusOngoing},
+		{"start given in another time zone", models.EventStatusPublished, &publishedAt, time.Date(2025, 9, 1, 8, 0, 0, 0, hcm), models.EventStatusOngoing},
+		{"published after start", models.EventStatusPublished, nil, start.Add(time.Hour), models.EventStatusOngoing},
+		{"published after end", models.EventStatusPublished, &publishedAt, end.Add(time.Minute), models.EventStatusClosed},
+		{"ongoing before end", models.EventStatusOngoing, &start, end.Add(-time.Minute), ""},
+		{"ongoing at end", models.EventStatusOngoing, &start, end, models.EventStatusClosed},
+		{"reopened after end", models.EventStatusOngoing, &reopenedAt, reopenedAt.Add(time.Hour), ""},
+		{"draft after start", models.EventStatusDraft, nil, start.Add(time.Hour), ""},
+		{"closed after end", models.EventStatusClosed, nil, end.Add(time.Hour), ""},
+	}
+
+	for _, tc := range cases {
+		event := &models.Event{Status: tc.status, StartDate: &start, EndDate: &end, StatusChangedAt: tc.changedAt}
+		status, _, due := services.ScheduledEventStatus(event, tc.now)
+		assert.Equal(t, tc.want, status, tc.name)
+		assert.Equal(t, tc.want != "", due, tc.name)
+	}
+}
+
+func TestScheduledEventStatus_NoDates(t *testing.T) {
+	_, _, due := services.ScheduledEventStatus(&models.Event{Status: models.EventStatusPublished}, time.Now())
+
+	assert.False(t, due)
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func studentEventSchema() models.FormSchema {
+	return models.FormSchema{
+		{Key: "student_code", Label: "Student code", Type: models.FormFieldText, Required: true},
+		{Key: "faculty", Label: "Faculty", Type: models.FormFieldSelect, Required: true, Choices: []string{"Computer Science", "Mathematics"}},
+		{Key: "year", Label: "Year", Type: models.FormFieldNumber},
+		{Key: "topics", Label: "Topics", Type: models.FormFieldMultiSelect, Choices: []string{"AI", "Web", "Security"}},
+		{Key: "consent", Label: "I agree to be photographed", Type: models.FormFieldCheckbox, Required: true},
+	}
+}
+
+func TestValidateFormSchema_Valid(t *testing.T) {
+	assert.NoError(t, services.ValidateFormSchema(studentEventSchema()))
+	assert.NoError(t, services.ValidateFormSchema(nil))
+}
+
+func TestValidateFormSchema_Rejects(t *testing.T) {
+	cases := map[string]models.FormSchema{
+		"bad key":        {{Key: "Job Title", Label: "Job title", Type: models.FormFieldText}},
+		"duplicate key":  {{Key: "title", Label: "Title", Type: models.FormFieldText}, {Key: "title", Label: "Title again", Type: models.FormFieldText}},
+		"missing label":  {{Key: "title", Type: models.FormFieldText}},
+		"unknown type":   {{Key: "title", Label: "Title", Type: "color"}},
+		"select choices": {{Key: "size", Label: "Size", Type: models.FormFieldSelect}},
+		"text choices":   {{Key: "title", Label: "Title", Type: models.FormFieldText, Choices: []string{"A"}}},
+	}
+
+	for name, schema := range cases {
+		assert.ErrorIs(t, services.ValidateFormSchema(schema), services.ErrInvalidFormSchema, name)
+	}
+}
+
+func TestValidateFormAnswers_NormalizesAnswers(t *testing.T) {
+	answers := models.FormAnswers{
+		"student_code": "  SV001 ",
+		"faculty":      "Mathematics",
+		"year":         "3",
+		"topics":       []interface{}{"AI", "Security", "AI"},
+		"consent":      "on",
+	}
+
+	cleaned, err := services.ValidateFormAnswers(studentEventSchema(), answers)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FormAnswers{
+		"student_code": "SV001",
+		"faculty":      "Mathematics",
+		"year":         float64(3),
+		"topics":       []string{"AI", "Security"},
+		"consent":      true,
+	}, cleaned)
+}
+
+func TestValidateFormAnswers_ReportsEachField(t *testing.T) {
+	answers := models.FormAnswers{
+		"faculty": "Physics",
+		"year":    "third",
+		"topics":  []interface{}{"Cooking"},
+		"consent": false,
+		"extra":   "value",
+	}
+
+	_, err := services.ValidateFormAnswers(studentEventSchema(), answers)
+
+	var formErr *services.FormValidationError
+	assert.ErrorAs(t, err, &formErr)
+	assert.ErrorIs(t, err, services.ErrInvalidFormAnswers)
+	assert.Equal(t, "is required", formErr.Fields["student_code"])
+	assert.Contains(t, formErr.Fields["faculty"], "not one of the choices")
+	assert.Equal(t, "must be a number", formErr.Fields["year"])
+	assert.Contains(t, formErr.Fields["topics"], "not one of the choices")
+	assert.Equal(t, "is required", formErr.Fields["consent"])
+	assert.Equal(t, "is not a field of this form", formErr.Fields["extra"])
+}
+
+func TestValidateFormAnswers_OptionalFieldsMayBeEmpty(t *testing.T) {
+	schema := models.FormSchema{
+		{Key: "job_title", Label: "Job title", Type: models.FormFieldText},
+		{Key: "email_cc", Label: "CC email", Type: models.FormFieldEmail},
+	}
+
+	cleaned, err := services.ValidateFormAnswers(schema, models.FormAnswers{"job_title": " "})
+
+	assert.NoError(t, err)
+	assert.Nil(t, cleaned)
+
+	_, err = services.ValidateFormAnswers(schema, models.FormAnswers{"email_cc": "not-an-email"})
+	assert.ErrorIs(t, err, services.ErrInvalidFormAnswers)
+}
+
+func TestValidateCheckinForm_WithoutSchemaRequiresWorkUnit(t *testing.T) {
+	event := &models.Event{}
+
+	_, err := services.ValidateCheckinForm(event, &models.CreateAttendanceRequest{WorkUnit: "Công ty ABC"})
+
+	var formErr *services.FormValidationError
+	assert.ErrorAs(t, err, &formErr)
+	assert.Equal(t, map[string]string{"work_unit_address": "is required"}, formErr.Fields)
+
+	answers, err := services.ValidateCheckinForm(event, &models.CreateAttendanceRequest{WorkUnit: "Công ty ABC", WorkUnitAddress: "123 Đường ABC"})
+	assert.NoError(t, err)
+	assert.Nil(t, answers)
+}
+
+func TestValidateCheckinForm_WithSchemaIgnoresWorkUnit(t *testing.T) {
+	event := &models.Event{FormSchema: models.FormSchema{
+		{Key: "job_title", Label: "Job title", Type: models.FormFieldText, Required: true},
+	}}
+
+	answers, err := services.ValidateCheckinForm(event, &models.CreateAttendanceRequest{
+		Answers: models.FormAnswers{"job_title": "Engineer"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FormAnswers{"job_title": "Engineer"}, answers)
+}
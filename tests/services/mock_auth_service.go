@@ -0,0 +1,48 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuthService is a mock implementation of AuthServiceInterface
+type MockAuthService struct {
+	mock.Mock
+}
+
+// Ensure MockAuthService implements AuthServiceInterface
+var _ interfaces.AuthServiceInterface = (*MockAuthService)(nil)
+
+func (m *MockAuthService) Login(req *models.LoginRequest) (*models.TokenResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TokenResponse), args.Error(1)
+}
+
+func (m *MockAuthService) RefreshToken(refreshToken string) (*models.TokenResponse, error) {
+	args := m.Called(refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TokenResponse), args.Error(1)
+}
+
+func (m *MockAuthService) ParseAccessToken(accessToken string) (*models.AuthClaims, error) {
+	args := m.Called(accessToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuthClaims), args.Error(1)
+}
+
+func (m *MockAuthService) GetUserByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
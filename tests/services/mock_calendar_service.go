@@ -0,0 +1,32 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCalendarService is a mock implementation of CalendarServiceInterface
+type MockCalendarService struct {
+	mock.Mock
+}
+
+// Ensure MockCalendarService implements CalendarServiceInterface
+var _ interfaces.CalendarServiceInterface = (*MockCalendarService)(nil)
+
+func (m *MockCalendarService) GetCalendarFeedLink(scope string, id uint) (*models.CalendarFeedResponse, error) {
+	args := m.Called(scope, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CalendarFeedResponse), args.Error(1)
+}
+
+func (m *MockCalendarService) GetCalendarFeed(scope string, id uint, token string) ([]byte, error) {
+	args := m.Called(scope, id, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
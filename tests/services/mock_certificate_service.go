@@ -0,0 +1,40 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCertificateService is a mock implementation of CertificateServiceInterface
+type MockCertificateService struct {
+	mock.Mock
+}
+
+// Ensure MockCertificateService implements CertificateServiceInterface
+var _ interfaces.CertificateServiceInterface = (*MockCertificateService)(nil)
+
+func (m *MockCertificateService) GetAttendanceCertificate(attendanceID uint) (*models.Certificate, []byte, error) {
+	args := m.Called(attendanceID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.Certificate), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *MockCertificateService) GetEventCertificates(eventID uint) ([]byte, int, error) {
+	args := m.Called(eventID)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]byte), args.Int(1), args.Error(2)
+}
+
+func (m *MockCertificateService) VerifyCertificate(code string) (*models.Certificate, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certificate), args.Error(1)
+}
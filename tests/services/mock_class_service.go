@@ -0,0 +1,50 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClassService is a mock implementation of ClassServiceInterface
+type MockClassService struct {
+	mock.Mock
+}
+
+// Ensure MockClassService implements ClassServiceInterface
+var _ interfaces.ClassServiceInterface = (*MockClassService)(nil)
+
+func (m *MockClassService) GetClasses() ([]models.Class, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Class), args.Error(1)
+}
+
+func (m *MockClassService) GetClassByID(id uint) (*models.Class, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Class), args.Error(1)
+}
+
+func (m *MockClassService) CreateClass(req *models.CreateClassRequest) (*models.Class, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Class), args.Error(1)
+}
+
+func (m *MockClassService) UpdateClass(id uint, req *models.UpdateClassRequest) (*models.Class, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Class), args.Error(1)
+}
+
+func (m *MockClassService) DeleteClass(id uint, cascade bool) (int64, error) {
+	args := m.Called(id, cascade)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -52,6 +52,14 @@ func (m *MockEventService) UpdateEvent(id uint, req *models.CreateEventRequest)
 	return args.Get(0).(*models.Event), args.Error(1)
 }
 
+func (m *MockEventService) CloneEvent(id uint, req *models.CloneEventRequest) (*models.Event, []models.SessionConflict, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.Event), args.Get(1).([]models.SessionConflict), args.Error(2)
+}
+
 func (m *MockEventService) DeleteEvent(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -62,10 +70,26 @@ func (m *MockEventService) GetActiveEvents() ([]models.Event, error) {
 	return args.Get(0).([]models.Event), args.Error(1)
 }
 
-func (m *MockEventService) SetEventActive(id uint, isActive bool) (*models.Event, error) {
-	args := m.Called(id, isActive)
+func (m *MockEventService) SetEventStatus(id uint, status string) (*models.Event, error) {
+	args := m.Called(id, status)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Event), args.Error(1)
 }
+
+func (m *MockEventService) SetEventActive(id uint, active bool) (*models.Event, error) {
+	args := m.Called(id, active)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
+func (m *MockEventService) GetEventStatusLog(id uint) ([]models.EventStatusLog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EventStatusLog), args.Error(1)
+}
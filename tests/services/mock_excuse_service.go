@@ -0,0 +1,51 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"mime/multipart"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExcuseService is a mock implementation of ExcuseServiceInterface
+type MockExcuseService struct {
+	mock.Mock
+}
+
+// Ensure MockExcuseService implements ExcuseServiceInterface
+var _ interfaces.ExcuseServiceInterface = (*MockExcuseService)(nil)
+
+func (m *MockExcuseService) GetExcuses(filter models.ExcuseFilter) ([]models.ExcuseRequest, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.ExcuseRequest), args.Error(1)
+}
+
+func (m *MockExcuseService) GetExcuseByID(id uint) (*models.ExcuseRequest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ExcuseRequest), args.Error(1)
+}
+
+func (m *MockExcuseService) GetExcuseHistory(id uint) ([]models.ExcuseRequestEvent, error) {
+	args := m.Called(id)
+	return args.Get(0).([]models.ExcuseRequestEvent), args.Error(1)
+}
+
+func (m *MockExcuseService) SubmitExcuse(req *models.CreateExcuseRequest, attachment *multipart.FileHeader, submittedBy *uint) (*models.ExcuseRequest, error) {
+	args := m.Called(req, attachment, submittedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ExcuseRequest), args.Error(1)
+}
+
+func (m *MockExcuseService) ReviewExcuse(id uint, approve bool, req *models.ReviewExcuseRequest, reviewerID *uint) (*models.ExcuseRequest, error) {
+	args := m.Called(id, approve, req, reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ExcuseRequest), args.Error(1)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRegistrationService is a mock implementation of RegistrationServiceInterface
+type MockRegistrationService struct {
+	mock.Mock
+}
+
+// Ensure MockRegistrationService implements RegistrationServiceInterface
+var _ interfaces.RegistrationServiceInterface = (*MockRegistrationService)(nil)
+
+func (m *MockRegistrationService) Register(eventID uint, req *models.CreateRegistrationRequest) (*models.Registration, error) {
+	args := m.Called(eventID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Registration), args.Error(1)
+}
+
+func (m *MockRegistrationService) CancelRegistration(cancelToken string) (*models.Registration, []models.Registration, error) {
+	args := m.Called(cancelToken)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.Registration), args.Get(1).([]models.Registration), args.Error(2)
+}
+
+func (m *MockRegistrationService) CancelRegistrationByID(id uint) (*models.Registration, []models.Registration, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.Registration), args.Get(1).([]models.Registration), args.Error(2)
+}
+
+func (m *MockRegistrationService) GetRegistrations(eventID uint, sessionID *uint, status string) ([]models.Registration, error) {
+	args := m.Called(eventID, sessionID, status)
+	return args.Get(0).([]models.Registration), args.Error(1)
+}
+
+func (m *MockRegistrationService) GetNoShows(eventID uint, sessionID *uint) ([]models.Registration, error) {
+	args := m.Called(eventID, sessionID)
+	return args.Get(0).([]models.Registration), args.Error(1)
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoomService is a mock implementation of RoomServiceInterface
+type MockRoomService struct {
+	mock.Mock
+}
+
+// Ensure MockRoomService implements RoomServiceInterface
+var _ interfaces.RoomServiceInterface = (*MockRoomService)(nil)
+
+func (m *MockRoomService) GetRooms() ([]models.Room, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Room), args.Error(1)
+}
+
+func (m *MockRoomService) GetRoomByID(id uint) (*models.Room, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Room), args.Error(1)
+}
+
+func (m *MockRoomService) CreateRoom(req *models.RoomRequest) (*models.Room, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Room), args.Error(1)
+}
+
+func (m *MockRoomService) UpdateRoom(id uint, req *models.RoomRequest) (*models.Room, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Room), args.Error(1)
+}
+
+func (m *MockRoomService) DeleteRoom(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRoomService) GetAvailableRooms(from, to time.Time, minCapacity int) ([]models.Room, error) {
+	args := m.Called(from, to, minCapacity)
+	return args.Get(0).([]models.Room), args.Error(1)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStudentService is a mock implementation of StudentServiceInterface
+type MockStudentService struct {
+	mock.Mock
+}
+
+// Ensure MockStudentService implements StudentServiceInterface
+var _ interfaces.StudentServiceInterface = (*MockStudentService)(nil)
+
+func (m *MockStudentService) GetStudents() ([]models.Student, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Student), args.Error(1)
+}
+
+func (m *MockStudentService) GetStudentByID(id uint) (*models.Student, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Student), args.Error(1)
+}
+
+func (m *MockStudentService) CreateStudent(req *models.CreateStudentRequest) (*models.Student, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Student), args.Error(1)
+}
+
+func (m *MockStudentService) UpdateStudent(id uint, req *models.CreateStudentRequest) (*models.Student, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Student), args.Error(1)
+}
+
+func (m *MockStudentService) DeleteStudent(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockStudentService) GetStudentEnrollments(id uint) ([]models.Enrollment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Enrollment), args.Error(1)
+}
+
+func (m *MockStudentService) EnrollStudent(id uint, req *models.EnrollStudentRequest) (*models.Enrollment, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Enrollment), args.Error(1)
+}
+
+func (m *MockStudentService) TransferStudent(id uint, req *models.TransferStudentRequest) (*models.Enrollment, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Enrollment), args.Error(1)
+}
+
+func (m *MockStudentService) WithdrawStudent(id uint, req *models.WithdrawStudentRequest) (*models.Enrollment, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Enrollment), args.Error(1)
+}
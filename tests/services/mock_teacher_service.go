@@ -0,0 +1,50 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTeacherService is a mock implementation of TeacherServiceInterface
+type MockTeacherService struct {
+	mock.Mock
+}
+
+// Ensure MockTeacherService implements TeacherServiceInterface
+var _ interfaces.TeacherServiceInterface = (*MockTeacherService)(nil)
+
+func (m *MockTeacherService) GetTeachers() ([]models.Teacher, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Teacher), args.Error(1)
+}
+
+func (m *MockTeacherService) GetTeacherByID(id uint) (*models.Teacher, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Teacher), args.Error(1)
+}
+
+func (m *MockTeacherService) CreateTeacher(req *models.CreateTeacherRequest) (*models.Teacher, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Teacher), args.Error(1)
+}
+
+func (m *MockTeacherService) UpdateTeacher(id uint, req *models.CreateTeacherRequest) (*models.Teacher, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Teacher), args.Error(1)
+}
+
+func (m *MockTeacherService) DeleteTeacher(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
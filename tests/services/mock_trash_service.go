@@ -0,0 +1,37 @@
+package services
+
+import (
+	"hello-gin/internal/interfaces"
+	"hello-gin/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTrashService is a mock implementation of TrashServiceInterface
+type MockTrashService struct {
+	mock.Mock
+}
+
+// Ensure MockTrashService implements TrashServiceInterface
+var _ interfaces.TrashServiceInterface = (*MockTrashService)(nil)
+
+func (m *MockTrashService) GetTrash(resource string) ([]models.TrashItem, error) {
+	args := m.Called(resource)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TrashItem), args.Error(1)
+}
+
+func (m *MockTrashService) RestoreFromTrash(resource string, id uint) (*models.TrashRestoreResponse, error) {
+	args := m.Called(resource, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TrashRestoreResponse), args.Error(1)
+}
+
+func (m *MockTrashService) PurgeFromTrash(resource string, id uint) error {
+	args := m.Called(resource, id)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"hello-gin/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "student@example.com", services.NormalizeEmail("  Student@Example.COM "))
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"0123 456 789":    "0123456789",
+		"+84 123 456 789": "0123456789",
+		"0084123456789":   "0123456789",
+		"(012) 345-6789":  "0123456789",
+		"":                "",
+	}
+
+	for input, expected := range cases {
+		assert.Equal(t, expected, services.NormalizePhone(input), input)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/repository"
+	"hello-gin/internal/services"
+	"hello-gin/tests"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNoShows(t *testing.T) {
+	checkedIn := time.Date(2025, 8, 20, 8, 0, 0, 0, time.UTC)
+	aliceEmail, bobPhone := "alice@example.com", "0912345678"
+
+	registrations := []models.Registration{
+		{ID: 1, EmailNormalized: "alice@example.com", Status: models.RegistrationStatusRegistered},                        // event-wide, attended session 2
+		{ID: 2, SessionID: uintPtr(1), EmailNormalized: "alice@example.com", Status: models.RegistrationStatusRegistered}, // session 1, no check-in there
+		{ID: 3, SessionID: uintPtr(2), EmailNormalized: "bob@example.com", PhoneNormalized: &bobPhone, Status: models.RegistrationStatusRegistered},
+		{ID: 4, EmailNormalized: "carol@example.com", Status: models.RegistrationStatusRegistered},
+		{ID: 5, EmailNormalized: "dave@example.com", Status: models.RegistrationStatusWaitlisted},
+	}
+	attendances := []models.Attendance{
+		{ID: 10, SessionID: uintPtr(2), CheckedInAt: &checkedIn, EmailNormalized: &aliceEmail},
+		{ID: 11, SessionID: uintPtr(2), CheckedInAt: &checkedIn, PhoneNormalized: &bobPhone}, // bob checked in with another email
+		{ID: 12, SessionID: uintPtr(1), PhoneNormalized: &bobPhone},                          // marked absent, no check-in
+	}
+
+	noShows := services.FindNoShows(registrations, attendances)
+
+	ids := make([]uint, 0, len(noShows))
+	for _, registration := range noShows {
+		ids = append(ids, registration.ID)
+	}
+	assert.Equal(t, []uint{2, 4}, ids)
+}
+
+func TestFindNoShows_NoRegistrations(t *testing.T) {
+	noShows := services.FindNoShows(nil, nil)
+
+	assert.NotNil(t, noShows)
+	assert.Empty(t, noShows)
+}
+
+func TestRegister_DraftEventRejected(t *testing.T) {
+	assertRegisterRejected(t, models.EventStatusDraft)
+}
+
+func TestRegister_ClosedEventRejected(t *testing.T) {
+	assertRegisterRejected(t, models.EventStatusClosed)
+}
+
+// assertRegisterRejected registers for an event with the given status and
+// expects the registration to be refused before anything is written
+func assertRegisterRejected(t *testing.T, status string) {
+	// Setup
+	db, dbMock, err := tests.SetupMockDB()
+	assert.NoError(t, err)
+	service := services.NewRegistrationService(repository.NewRegistrationRepository(db), repository.NewEventRepository(db))
+
+	dbMock.ExpectQuery(`SELECT \* FROM "events"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(3, status))
+
+	// Execute
+	registration, err := service.Register(3, &models.CreateRegistrationRequest{FullName: "Nguyen Van A", Email: "a@example.com"})
+
+	// Assert
+	assert.Nil(t, registration)
+	assert.ErrorIs(t, err, services.ErrEventNotOpenForRegistration)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestEnsureEventOpenForRegistration(t *testing.T) {
+	for status, open := range map[string]bool{
+		models.EventStatusDraft:     false,
+		models.EventStatusPublished: true,
+		models.EventStatusOngoing:   true,
+		models.EventStatusClosed:    false,
+		models.EventStatusArchived:  false,
+	} {
+		err := services.EnsureEventOpenForRegistration(&models.Event{Status: status})
+		if open {
+			assert.NoError(t, err, status)
+		} else {
+			assert.ErrorIs(t, err, services.ErrEventNotOpenForRegistration, status)
+		}
+	}
+}
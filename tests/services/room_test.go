@@ -0,0 +1,82 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestValidateRoom(t *testing.T) {
+	valid := models.Room{Name: "A101", Capacity: intPtr(60), Latitude: floatPtr(10.7769), Longitude: floatPtr(106.7009)}
+	assert.NoError(t, services.ValidateRoom(&valid))
+
+	invalid := []models.Room{
+		{},
+		{Name: "A101", Capacity: intPtr(0)},
+		{Name: "A101", Latitude: floatPtr(10.7769)},
+		{Name: "A101", Latitude: floatPtr(91), Longitude: floatPtr(0)},
+		{Name: "A101", Latitude: floatPtr(0), Longitude: floatPtr(-181)},
+	}
+	for _, room := range invalid {
+		assert.ErrorIs(t, services.ValidateRoom(&room), services.ErrInvalidRoom)
+	}
+}
+
+func TestApplyRoomRequest(t *testing.T) {
+	building := "Building B"
+	room := models.Room{Name: "A101", Building: &building, Capacity: intPtr(60)}
+	name := "  A102 "
+
+	services.ApplyRoomRequest(&room, &models.RoomRequest{Name: &name, Capacity: intPtr(40)})
+
+	assert.Equal(t, "A102", room.Name)
+	assert.Equal(t, &building, room.Building)
+	assert.Equal(t, 40, *room.Capacity)
+	assert.Equal(t, "A102, Building B", room.Label())
+}
+
+func TestFreeRooms(t *testing.T) {
+	tuesday := time.Date(2025, 9, 2, 1, 0, 0, 0, time.UTC)
+	rooms := []models.Room{
+		{ID: 1, Name: "A101", Capacity: intPtr(60)},
+		{ID: 2, Name: "A102", Capacity: intPtr(30)},
+		{ID: 3, Name: "Hall"},
+		{ID: 4, Name: "A103", Capacity: intPtr(60)},
+	}
+	earlier := tuesday.Add(-2 * time.Hour)
+	later := tuesday.Add(2 * time.Hour)
+	sessions := []models.AttendanceSession{
+		{ID: 10, RoomID: uintPtr(1), SessionDate: &tuesday, DurationMinutes: intPtr(30)},
+		{ID: 11, RoomID: uintPtr(3), SessionDate: &earlier, DurationMinutes: intPtr(120)}, // Ends when the range starts
+		{ID: 12, RoomID: uintPtr(4), SessionDate: &later},                                 // Starts when the range ends
+	}
+
+	free := services.FreeRooms(rooms, sessions, tuesday, tuesday.Add(2*time.Hour), 40)
+
+	var names []string
+	for _, room := range free {
+		names = append(names, room.Name)
+	}
+	assert.Equal(t, []string{"Hall", "A103"}, names)
+}
+
+func TestSessionCapacity(t *testing.T) {
+	session := &models.AttendanceSession{}
+	assert.Nil(t, services.SessionCapacity(session))
+
+	session.Room = &models.Room{Capacity: intPtr(30)}
+	assert.Equal(t, 30, *services.SessionCapacity(session))
+
+	session.Capacity = intPtr(20)
+	assert.Equal(t, 20, *services.SessionCapacity(session))
+
+	session.Capacity = intPtr(50)
+	assert.Equal(t, 30, *services.SessionCapacity(session))
+}
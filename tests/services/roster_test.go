@@ -0,0 +1,62 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestBuildRoster(t *testing.T) {
+	late := models.AttendanceStatusLate
+	session := &models.AttendanceSession{ID: 1, ClassID: uintPtr(10)}
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}}
+	attendances := []models.Attendance{
+		{ID: 100, StudentID: uintPtr(1)},
+		{ID: 101, StudentID: uintPtr(2), Status: &late},
+		{ID: 102},                        // unmatched check-in
+		{ID: 103, StudentID: uintPtr(9)}, // student from another class
+	}
+
+	roster := services.BuildRoster(session, students, attendances, nil)
+
+	assert.Len(t, roster.Entries, 3)
+	assert.Equal(t, models.AttendanceStatusPresent, roster.Entries[0].Status)
+	assert.Equal(t, models.AttendanceStatusLate, roster.Entries[1].Status)
+	assert.Equal(t, models.AttendanceStatusAbsent, roster.Entries[2].Status)
+	assert.Nil(t, roster.Entries[2].Attendance)
+
+	assert.Len(t, roster.Unmatched, 1)
+	assert.Equal(t, uint(102), roster.Unmatched[0].ID)
+	assert.Len(t, roster.Guests, 1)
+	assert.Equal(t, uint(103), roster.Guests[0].ID)
+
+	assert.Equal(t, 1, roster.Summary[models.AttendanceStatusPresent])
+	assert.Equal(t, 1, roster.Summary[models.AttendanceStatusLate])
+	assert.Equal(t, 1, roster.Summary[models.AttendanceStatusAbsent])
+	assert.Equal(t, 0, roster.Summary[models.AttendanceStatusExcused])
+}
+
+func TestBuildRoster_ApprovedExcuse(t *testing.T) {
+	absent := models.AttendanceStatusAbsent
+	session := &models.AttendanceSession{ID: 1, ClassID: uintPtr(10)}
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	attendances := []models.Attendance{
+		{ID: 100, StudentID: uintPtr(1)},                  // attended despite the excuse
+		{ID: 101, StudentID: uintPtr(2), Status: &absent}, // marked absent by the teacher
+	}
+
+	roster := services.BuildRoster(session, students, attendances, []uint{1, 2, 3})
+
+	assert.Equal(t, models.AttendanceStatusPresent, roster.Entries[0].Status)
+	assert.Equal(t, models.AttendanceStatusExcused, roster.Entries[1].Status)
+	assert.Equal(t, models.AttendanceStatusExcused, roster.Entries[2].Status)
+	assert.Equal(t, models.AttendanceStatusAbsent, roster.Entries[3].Status)
+	assert.Equal(t, 2, roster.Summary[models.AttendanceStatusExcused])
+	assert.Equal(t, 1, roster.Summary[models.AttendanceStatusAbsent])
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conflictSession(id uint, start time.Time, minutes int, teacherID, classID *uint, location string) models.AttendanceSession {
+	session := models.AttendanceSession{
+		ID:              id,
+		TeacherID:       teacherID,
+		ClassID:         classID,
+		SessionDate:     &start,
+		DurationMinutes: intPtr(minutes),
+	}
+	if location != "" {
+		session.Location = &location
+	}
+	return session
+}
+
+func TestSessionConflictReasons(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	a := conflictSession(1, start, 60, uintPtr(3), uintPtr(2), "Room  A101")
+	b := conflictSession(2, start, 60, uintPtr(3), uintPtr(4), "room a101")
+	c := conflictSession(3, start, 60, nil, nil, "")
+	d := conflictSession(4, start, 60, nil, nil, "")
+
+	assert.Equal(t, []string{services.ConflictTeacher, services.ConflictRoom}, services.SessionConflictReasons(&a, &b))
+	assert.Empty(t, services.SessionConflictReasons(&a, &c))
+	assert.Empty(t, services.SessionConflictReasons(&c, &d), "sessions without teacher, class or room never conflict")
+
+	c.RoomID, d.RoomID = uintPtr(7), uintPtr(7)
+	assert.Equal(t, []string{services.ConflictRoom}, services.SessionConflictReasons(&c, &d))
+	a.RoomID = uintPtr(8)
+	assert.Equal(t, []string{services.ConflictTeacher}, services.SessionConflictReasons(&a, &b), "booked rooms are compared by room")
+}
+
+func TestFindSessionConflicts(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	session := conflictSession(0, start, 90, uintPtr(3), uintPtr(2), "")
+	others := []models.AttendanceSession{
+		conflictSession(10, start.Add(60*time.Minute), 60, uintPtr(3), nil, ""),  // Same teacher, overlaps
+		conflictSession(11, start.Add(90*time.Minute), 60, uintPtr(3), nil, ""),  // Starts when the session ends
+		conflictSession(12, start.Add(-30*time.Minute), 45, nil, uintPtr(2), ""), // Same class, overlaps
+		conflictSession(13, start, 60, uintPtr(4), uintPtr(5), ""),               // Nothing shared
+	}
+
+	conflicts := services.FindSessionConflicts(&session, others)
+
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, uint(10), conflicts[0].SessionID)
+	assert.Equal(t, []string{services.ConflictTeacher}, conflicts[0].Reasons)
+	assert.Equal(t, start.Add(120*time.Minute), conflicts[0].EndsAt)
+	assert.Equal(t, uint(12), conflicts[1].SessionID)
+	assert.Equal(t, []string{services.ConflictClass}, conflicts[1].Reasons)
+}
+
+func TestFindSessionConflicts_IgnoresItself(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	session := conflictSession(10, start, 60, uintPtr(3), nil, "")
+
+	assert.Empty(t, services.FindSessionConflicts(&session, []models.AttendanceSession{session}))
+}
+
+func TestFindPlannedSessionConflicts(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	planned := []models.AttendanceSession{
+		conflictSession(20, start, 60, uintPtr(3), nil, ""),                  // Upcoming session being moved
+		conflictSession(0, start.Add(24*time.Hour), 60, uintPtr(3), nil, ""), // New occurrence
+		conflictSession(0, start.Add(24*time.Hour+30*time.Minute), 60, uintPtr(3), nil, ""),
+	}
+	existing := []models.AttendanceSession{
+		conflictSession(20, start, 60, uintPtr(3), nil, ""),                     // The moved session itself
+		conflictSession(21, start.Add(30*time.Minute), 60, uintPtr(3), nil, ""), // Removed by the same change
+		conflictSession(30, start.Add(15*time.Minute), 60, uintPtr(3), nil, ""), // Another session of the teacher
+	}
+	replaced := []models.AttendanceSession{existing[1]}
+
+	conflicts := services.FindPlannedSessionConflicts(planned, existing, replaced)
+
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, uint(30), conflicts[0].SessionID)
+	assert.Equal(t, start, *conflicts[0].Occurrence)
+	assert.Equal(t, uint(0), conflicts[1].SessionID, "occurrences of the series overlap each other")
+	assert.Equal(t, start.Add(24*time.Hour+30*time.Minute), conflicts[1].SessionDate)
+	assert.Equal(t, start.Add(24*time.Hour), *conflicts[1].Occurrence)
+}
+
+func TestFindOverlappingSessions(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	sessions := []models.AttendanceSession{
+		conflictSession(3, start.Add(2*time.Hour), 60, nil, nil, "Hall"),
+		conflictSession(1, start, 180, nil, nil, "Hall"),
+		conflictSession(2, start.Add(time.Hour), 30, uintPtr(3), nil, ""),
+		conflictSession(4, start.Add(time.Hour), 60, uintPtr(3), nil, "Lab"),
+	}
+
+	pairs := services.FindOverlappingSessions(sessions)
+
+	require.Len(t, pairs, 2)
+	assert.Equal(t, models.SessionConflictPair{
+		SessionID:      2,
+		OtherSessionID: 4,
+		OverlapStart:   start.Add(time.Hour),
+		OverlapEnd:     start.Add(90 * time.Minute),
+		Reasons:        []string{services.ConflictTeacher},
+	}, pairs[0])
+	assert.Equal(t, models.SessionConflictPair{
+		SessionID:      1,
+		OtherSessionID: 3,
+		OverlapStart:   start.Add(2 * time.Hour),
+		OverlapEnd:     start.Add(3 * time.Hour),
+		Reasons:        []string{services.ConflictRoom},
+	}, pairs[1])
+}
+
+func TestBuildSessionConflictsReport_HidesOtherTeachersSessions(t *testing.T) {
+	start := time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)
+	classID := uintPtr(7)
+	sessions := []models.AttendanceSession{
+		// Another teacher's session clashes with the class of the teacher's session 2
+		conflictSession(1, start, 120, uintPtr(4), classID, ""),
+		conflictSession(2, start.Add(time.Hour), 60, uintPtr(3), classID, ""),
+		conflictSession(3, start.Add(time.Hour), 30, uintPtr(3), nil, ""),
+	}
+
+	all := services.BuildSessionConflictsReport(sessions, start, start.Add(4*time.Hour), nil)
+	own := services.BuildSessionConflictsReport(sessions, start, start.Add(4*time.Hour), uintPtr(3))
+
+	require.Len(t, all, 2)
+	assert.Equal(t, uint(1), all[0].SessionID)
+	assert.Equal(t, uint(2), all[0].OtherSessionID)
+
+	require.Len(t, own, 2)
+	assert.Equal(t, uint(2), own[0].SessionID)
+	assert.Zero(t, own[0].OtherSessionID)
+	assert.Equal(t, []string{services.ConflictClass}, own[0].Reasons)
+	assert.Equal(t, uint(2), own[1].SessionID)
+	assert.Equal(t, uint(3), own[1].OtherSessionID)
+	assert.Equal(t, []string{services.ConflictTeacher}, own[1].Reasons)
+}
+
+func TestValidateSessionDuration(t *testing.T) {
+	session := &models.AttendanceSession{}
+	assert.NoError(t, services.ValidateSessionDuration(session))
+
+	session.DurationMinutes = intPtr(1440)
+	assert.NoError(t, services.ValidateSessionDuration(session))
+
+	for _, minutes := range []int{0, -30, 1441} {
+		session.DurationMinutes = intPtr(minutes)
+		assert.ErrorIs(t, services.ValidateSessionDuration(session), services.ErrInvalidDuration)
+	}
+}
+
+func TestSessionConflictError(t *testing.T) {
+	err := error(&services.SessionConflictError{Conflicts: []models.SessionConflict{
+		{SessionID: 12, Reasons: []string{services.ConflictTeacher, services.ConflictRoom}},
+	}})
+
+	assert.ErrorIs(t, err, services.ErrSessionConflict)
+	var conflictErr *services.SessionConflictError
+	require.True(t, errors.As(err, &conflictErr))
+	assert.Contains(t, err.Error(), "session 12 (teacher, room)")
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"hello-gin/internal/models"
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func sampleSeries() *models.SessionSeries {
+	location, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	return &models.SessionSeries{
+		ID:        7,
+		EventID:   uintPtr(1),
+		ClassID:   uintPtr(2),
+		TeacherID: uintPtr(3),
+		StartsAt:  time.Date(2025, 9, 1, 8, 0, 0, 0, location), // Monday
+		Timezone:  "Asia/Ho_Chi_Minh",
+		RRule:     "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+
+		OpensBeforeMinutes: intPtr(15),
+		LateAfterMinutes:   intPtr(10),
+		ClosesAfterMinutes: intPtr(90),
+	}
+}
+
+func localDays(starts []time.Time, location *time.Location) []string {
+	days := make([]string, 0, len(starts))
+	for _, start := range starts {
+		days = append(days, start.In(location).Format("2006-01-02 15:04"))
+	}
+	return days
+}
+
+func TestExpandSessionSeries_Weekly(t *testing.T) {
+	series := sampleSeries()
+
+	starts, err := services.ExpandSessionSeries(series, time.Time{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"2025-09-01 08:00", "2025-09-03 08:00", "2025-09-08 08:00", "2025-09-10 08:00",
+	}, localDays(starts, series.StartsAt.Location()))
+}
+
+func TestExpandSessionSeries_SkipsExDates(t *testing.T) {
+	series := sampleSeries()
+	series.ExDates = models.StringList{"2025-09-03"}
+
+	starts, err := services.ExpandSessionSeries(series, time.Time{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"2025-09-01 08:00", "2025-09-08 08:00", "2025-09-10 08:00",
+	}, localDays(starts, series.StartsAt.Location()))
+}
+
+func TestExpandSessionSeries_OnlyAfter(t *testing.T) {
+	series := sampleSeries()
+
+	starts, err := services.ExpandSessionSeries(series, series.StartsAt.AddDate(0, 0, 5))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-09-08 08:00", "2025-09-10 08:00"}, localDays(starts, series.StartsAt.Location()))
+}
+
+func TestExpandSessionSeries_KeepsWallClockAcrossDST(t *testing.T) {
+	location, _ := time.LoadLocation("America/New_York")
+	series := &models.SessionSeries{
+		StartsAt: time.Date(2025, 10, 27, 9, 0, 0, 0, location),
+		Timezone: "America/New_York",
+		RRule:    "FREQ=WEEKLY;COUNT=2",
+	}
+
+	starts, err := services.ExpandSessionSeries(series, time.Time{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-10-27 09:00", "2025-11-03 09:00"}, localDays(starts, location))
+	assert.Equal(t, 7*24*time.Hour+time.Hour, starts[1].Sub(starts[0]))
+}
+
+func TestValidateSessionSeries_Invalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*models.SessionSeries)
+		err    error
+	}{
+		{"unbounded rule", func(s *models.SessionSeries) { s.RRule = "FREQ=WEEKLY;BYDAY=MO" }, services.ErrInvalidRecurrence},
+		{"hourly rule", func(s *models.SessionSeries) { s.RRule = "FREQ=HOURLY;COUNT=3" }, services.ErrInvalidRecurrence},
+		{"time of day in rule", func(s *models.SessionSeries) { s.RRule = "FREQ=DAILY;BYHOUR=9;COUNT=3" }, services.ErrInvalidRecurrence},
+		{"malformed rule", func(s *models.SessionSeries) { s.RRule = "FREQ=SOMETIMES" }, services.ErrInvalidRecurrence},
+		{"unknown time zone", func(s *models.SessionSeries) { s.Timezone = "Mars/Olympus" }, services.ErrInvalidRecurrence},
+		{"bad exdate", func(s *models.SessionSeries) { s.ExDates = models.StringList{"03/09/2025"} }, services.ErrInvalidRecurrence},
+		{"window out of order", func(s *models.SessionSeries) { s.ClosesAfterMinutes = intPtr(5) }, services.ErrInvalidCheckinWindow},
+	}
+
+	for _, tc := range cases {
+		series := sampleSeries()
+		tc.modify(series)
+		assert.ErrorIs(t, services.ValidateSessionSeries(series), tc.err, tc.name)
+	}
+
+	assert.NoError(t, services.ValidateSessionSeries(sampleSeries()))
+}
+
+func TestExpandSessionSeries_TooManyOccurrences(t *testing.T) {
+	series := sampleSeries()
+	series.RRule = "FREQ=DAILY;COUNT=501"
+
+	_, err := services.ExpandSessionSeries(series, time.Time{})
+
+	assert.ErrorIs(t, err, services.ErrInvalidRecurrence)
+}
+
+func TestBuildSeriesSession(t *testing.T) {
+	series := sampleSeries()
+	start := series.StartsAt.AddDate(0, 0, 2)
+
+	session := services.BuildSeriesSession(series, start)
+
+	assert.Equal(t, series.ClassID, session.ClassID)
+	assert.Equal(t, series.TeacherID, session.TeacherID)
+	assert.Equal(t, uint(7), *session.SeriesID)
+	assert.True(t, session.SessionDate.Equal(start))
+	assert.True(t, session.OpensAt.Equal(start.Add(-15*time.Minute)))
+	assert.True(t, session.LateAfter.Equal(start.Add(10*time.Minute)))
+	assert.True(t, session.ClosesAt.Equal(start.Add(90*time.Minute)))
+}
+
+func TestPlanSeriesSessions(t *testing.T) {
+	series := sampleSeries()
+	monday := series.StartsAt.AddDate(0, 0, 7)
+	wednesday := monday.AddDate(0, 0, 2)
+	friday := monday.AddDate(0, 0, 4)
+	upcoming := []models.AttendanceSession{
+		{ID: 10, SessionDate: &monday},
+		{ID: 11, SessionDate: &wednesday},
+	}
+
+	// Moved to 9:00 on Mondays and Fridays
+	series.TeacherID = uintPtr(4)
+	plan := services.PlanSeriesSessions(series, upcoming, []time.Time{
+		monday.Add(time.Hour), friday.Add(time.Hour),
+	})
+
+	require.Len(t, plan.Update, 1)
+	assert.Equal(t, uint(10), plan.Update[0].ID)
+	assert.True(t, plan.Update[0].SessionDate.Equal(monday.Add(time.Hour)))
+	assert.Equal(t, uint(4), *plan.Update[0].TeacherID)
+
+	require.Len(t, plan.Create, 1)
+	assert.True(t, plan.Create[0].SessionDate.Equal(friday.Add(time.Hour)))
+
+	require.Len(t, plan.Remove, 1)
+	assert.Equal(t, uint(11), plan.Remove[0].ID)
+}
+
+func TestApplySessionSeriesUpdate_KeepsOmittedFields(t *testing.T) {
+	series := sampleSeries()
+	rule := "FREQ=WEEKLY;BYDAY=TU;COUNT=2"
+
+	services.ApplySessionSeriesUpdate(series, models.UpdateSessionSeriesRequest{RRule: &rule, Capacity: intPtr(30)})
+
+	assert.Equal(t, rule, series.RRule)
+	assert.Equal(t, 30, *series.Capacity)
+	assert.Equal(t, 15, *series.OpensBeforeMinutes)
+	assert.Equal(t, uint(3), *series.TeacherID)
+}
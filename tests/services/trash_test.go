@@ -0,0 +1,32 @@
+package services
+
+import (
+	"hello-gin/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTrashResource(t *testing.T) {
+	for _, resource := range []string{"events", "students", "classes", "teachers", "rooms"} {
+		assert.True(t, services.IsTrashResource(resource), resource)
+	}
+	assert.False(t, services.IsTrashResource("users"))
+	assert.False(t, services.IsTrashResource("Events"))
+}
+
+func TestTrashResources_EventsPurgedFirst(t *testing.T) {
+	// Sessions of purged events refer to classes, teachers and rooms
+	assert.Equal(t, "events", services.TrashResources[0])
+}
+
+func TestTrashPurgeAt(t *testing.T) {
+	deletedAt := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+
+	purgeAt := services.TrashPurgeAt(deletedAt, 30*24*time.Hour)
+	if assert.NotNil(t, purgeAt) {
+		assert.Equal(t, time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC), *purgeAt)
+	}
+	assert.Nil(t, services.TrashPurgeAt(deletedAt, 0))
+}
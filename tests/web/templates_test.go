@@ -0,0 +1,96 @@
+package web
+
+import (
+	"bytes"
+	"hello-gin/internal/models"
+	"hello-gin/internal/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates_RenderCheckinForm(t *testing.T) {
+	// Setup
+	page := map[string]interface{}{
+		"Title":     "Workshop",
+		"EventName": "Workshop",
+		"SessionID": uint(7),
+		"Ticket":    "form.1700000000.sig",
+		"Form":      models.CreateAttendanceRequest{StudentName: "Nguyen <Van> A"},
+	}
+
+	// Execute
+	var out bytes.Buffer
+	err := web.Templates().ExecuteTemplate(&out, "checkin_form.html", page)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `action="/checkin/7"`)
+	assert.Contains(t, out.String(), `value="form.1700000000.sig"`)
+	assert.Contains(t, out.String(), "Nguyen &lt;Van&gt; A")
+}
+
+func TestTemplates_RenderCheckinResult(t *testing.T) {
+	// Setup
+	name, status := "Nguyen Van A", models.AttendanceStatusLate
+	checkedInAt := time.Now()
+	page := map[string]interface{}{
+		"Title":      "Checked in",
+		"Attendance": &models.Attendance{StudentName: &name, Status: &status, CheckedInAt: &checkedInAt},
+	}
+
+	// Execute
+	var out bytes.Buffer
+	err := web.Templates().ExecuteTemplate(&out, "checkin_result.html", page)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Nguyen Van A")
+	assert.Contains(t, out.String(), "Late")
+}
+
+func TestAssets_ServeStylesheet(t *testing.T) {
+	// Setup
+	handler := http.FileServer(web.Assets())
+	req, _ := http.NewRequest(http.MethodGet, "/checkin.css", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/css")
+}
+
+func TestTemplates_RenderCustomFormFields(t *testing.T) {
+	// Setup
+	page := map[string]interface{}{
+		"Title":     "Workshop",
+		"SessionID": uint(7),
+		"Fields": models.FormSchema{
+			{Key: "faculty", Label: "Faculty", Type: models.FormFieldSelect, Required: true, Choices: []string{"Mathematics", "Physics"}},
+			{Key: "topics", Label: "Topics", Type: models.FormFieldMultiSelect, Choices: []string{"AI", "Web"}},
+		},
+		"Form": models.CreateAttendanceRequest{
+			Answers: models.FormAnswers{"faculty": "Physics", "topics": []string{"Web"}},
+		},
+		"FieldErrors": map[string]string{"faculty": "is required"},
+	}
+
+	// Execute
+	var out bytes.Buffer
+	err := web.Templates().ExecuteTemplate(&out, "checkin_form.html", page)
+
+	// Assert
+	assert.NoError(t, err)
+	html := out.String()
+	assert.Contains(t, html, `name="answers.faculty"`)
+	assert.Contains(t, html, `<option value="Physics" selected>`)
+	assert.Contains(t, html, `value="Web" checked`)
+	assert.Contains(t, html, "is required")
+	assert.NotContains(t, html, `name="work_unit"`)
+}